@@ -5,44 +5,138 @@ package main
 import (
 	"flag"
 	"fmt"
-	"net/http"
-	"net/http/cookiejar"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/jumptown-skydiving/manifest-server/pkg/core"
+	"github.com/jumptown-skydiving/manifest-server/pkg/jsonfeed"
 	"github.com/jumptown-skydiving/manifest-server/pkg/server"
 	"github.com/jumptown-skydiving/manifest-server/pkg/settings"
-
-	"golang.org/x/net/publicsuffix"
 )
 
-func newWebServer(app *core.Controller) (*server.WebServer, error) {
-	settings := app.Settings()
-
+func newWebServer(apps []*core.Controller, settings *settings.Settings) (*server.WebServer, error) {
 	httpAddress := settings.WebServerAddress()
 	httpsAddress := settings.WebServerSecureAddress()
 	grpcAddress := settings.WebServerGRPCAddress()
 	certFile := settings.ServerCertFile()
 	keyFile := settings.ServerKeyFile()
-	webServer, err := server.NewWebServer(app, httpAddress, httpsAddress,
-		grpcAddress, certFile, keyFile)
+	webServer, err := server.NewWebServer(apps, httpAddress, httpsAddress,
+		grpcAddress, certFile, keyFile, settings.ServerH2C(),
+		settings.ServerCORSOrigins())
 	if err != nil {
 		return nil, err
 	}
 
-	webServer.SetContentFunc("/settings.html", settings.HTML)
-	webServer.SetContentFunc("/setconfig", settings.FormHandler)
+	for i, app := range apps {
+		registerDropzoneContent(webServer, app, i == 0)
+	}
+
+	return webServer, nil
+}
+
+// registerDropzoneContent registers the web pages for a dropzone. When
+// multiple dropzones are configured, each dropzone's pages are found beneath
+// a path named for its ID. The default dropzone's pages are also found at
+// the top level.
+func registerDropzoneContent(webServer *server.WebServer, app *core.Controller, isDefault bool) {
+	settings := app.Settings()
+	setContentFunc := func(path string, f server.WebContentFunc) {
+		if id := settings.DropzoneID(); id != "" {
+			webServer.SetContentFunc("/"+id+path, f)
+		}
+		if isDefault {
+			webServer.SetContentFunc(path, f)
+		}
+	}
+
+	setContentFunc("/status.html", app.HealthHTML)
+
+	// Apple notifies each dropzone's Services ID of account changes.
+	setContentFunc("/siwa", app.AppleEventHandler)
+
+	// The admin pages require users to sign in with a suitable role, and
+	// the forms on them to be posted with the session's CSRF token.
+	setContentFunc("/login", app.LoginHandler)
+	setContentFunc("/logout", app.LogoutHandler)
+	setContentFunc("/settings.html", app.WebPage(settings.HTML, "admin"))
+	setContentFunc("/setconfig", app.WebAction(settings.FormHandler, "admin"))
+	setContentFunc("/jumper_rules.html", app.WebPage(settings.JumperRulesHTML, "admin"))
+	setContentFunc("/setjumperrules", app.WebAction(settings.JumperRulesFormHandler, "admin"))
 
 	if jumprun := app.Jumprun(); jumprun != nil {
-		webServer.SetContentFunc("/jumprun.html", jumprun.HTML)
-		webServer.SetContentFunc("/setjumprun", jumprun.FormHandler)
+		setContentFunc("/jumprun.html", app.WebPage(jumprun.HTML, "admin", "pilot"))
+		setContentFunc("/setjumprun", app.WebAction(jumprun.FormHandler, "admin", "pilot"))
 	}
 
-	webServer.SetContentFunc("/siwa", app.AppleEventHandler)
+	// Pushing loads replaces the whole manifest, so it is only accepted
+	// with the push token.
+	if feed, ok := app.ManifestSource().(*jsonfeed.Controller); ok {
+		path := settings.ManifestPushPath()
+		if path != "" && settings.ManifestPushToken() != "" {
+			setContentFunc(path, feed.PushHandler)
+		}
+	}
 
-	return webServer, nil
+	if announcer := app.Announcer(); announcer != nil {
+		if path := settings.AnnouncementsPath(); path != "" {
+			setContentFunc(path, announcer.HTTPHandler)
+		}
+	}
+}
+
+// newControllers creates a controller for each configured dropzone, with the
+// default dropzone first. If no dropzones are configured, a single controller
+// is created from the top-level settings.
+func newControllers(s *settings.Settings) ([]*core.Controller, error) {
+	ids := s.Dropzones()
+	if len(ids) == 0 {
+		app, err := core.NewController(s)
+		if err != nil {
+			return nil, err
+		}
+		return []*core.Controller{app}, nil
+	}
+
+	defaultID := s.DefaultDropzone()
+	for i, id := range ids {
+		if id == defaultID {
+			ids[0], ids[i] = ids[i], ids[0]
+			break
+		}
+	}
+	if ids[0] != defaultID {
+		return nil, fmt.Errorf("default dropzone %q is not defined", defaultID)
+	}
+
+	dropzones := make([]*settings.Settings, len(ids))
+	for i, id := range ids {
+		dzSettings, err := s.ForDropzone(id)
+		if err != nil {
+			return nil, err
+		}
+		dropzones[i] = dzSettings
+	}
+	if err := settings.CheckDropzones(dropzones); err != nil {
+		return nil, err
+	}
+
+	var apps []*core.Controller
+	for i, id := range ids {
+		app, err := core.NewController(dropzones[i])
+		if err != nil {
+			closeControllers(apps)
+			return nil, fmt.Errorf("dropzone %q: %w", id, err)
+		}
+		apps = append(apps, app)
+	}
+	return apps, nil
+}
+
+func closeControllers(apps []*core.Controller) {
+	for _, app := range apps {
+		app.Close()
+	}
 }
 
 func newSettings(configFilename string) (*settings.Settings, error) {
@@ -63,27 +157,19 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Set up a cookie jar for the app to use. All HTTP requests will use
-	// this cookie jar.
-	jar, err := cookiejar.New(&cookiejar.Options{
-		PublicSuffixList: publicsuffix.List,
-	})
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Could not create cookie jar: %v\n", err)
-		os.Exit(1)
-	}
-	http.DefaultClient.Jar = jar
-
-	app, err := core.NewController(settings)
+	apps, err := newControllers(settings)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
 		os.Exit(1)
 	}
-	settings.SetUpdateFunc(func(_ string) {
-		app.WakeListeners(core.OptionsDataSource)
-	})
+	for _, app := range apps {
+		app := app
+		app.Settings().SetUpdateFunc(func(_ string) {
+			app.Publish(core.OptionsDataSource)
+		})
+	}
 
-	webServer, err := newWebServer(app)
+	webServer, err := newWebServer(apps, settings)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "cannot create web server: %v\n", err)
 		os.Exit(1)
@@ -103,7 +189,7 @@ func main() {
 
 	fmt.Fprintf(os.Stderr, "Server stopping for receipt of termination signal\n")
 
-	app.Close()
+	closeControllers(apps)
 	webServer.Close()
 
 	fmt.Fprintf(os.Stderr, "Server stopped\n")
@@ -1,6 +1,6 @@
 module github.com/jumptown-skydiving/manifest-server
 
-go 1.17
+go 1.20
 
 require (
 	github.com/kelvins/sunrisesunset v0.0.0-20210220141756-39fa1bd816d5
@@ -12,8 +12,10 @@ require (
 )
 
 require (
+	github.com/gorilla/websocket v1.5.0
 	github.com/mattn/go-sqlite3 v1.14.16
 	github.com/orangematt/siwa v0.0.0-20230123113919-59fbb0297c96
+	golang.org/x/image v0.18.0
 )
 
 require (
@@ -28,7 +30,7 @@ require (
 	github.com/spf13/jwalterweatherman v1.1.0 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
 	github.com/subosito/gotenv v1.4.2 // indirect
-	golang.org/x/text v0.8.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
 	google.golang.org/genproto v0.0.0-20230331144136-dcfb400f0633 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
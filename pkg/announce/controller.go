@@ -0,0 +1,226 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package announce
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/burble"
+	"github.com/jumptown-skydiving/manifest-server/pkg/settings"
+)
+
+const (
+	defaultTemplate = "{{.Call}} call for {{.AircraftName}} load {{.LoadNumber}}"
+
+	// maxRecentAnnouncements is the number of announcements retained for
+	// retrieval via HTTP.
+	maxRecentAnnouncements = 100
+
+	// delayMinutes is how far a load's call time must rise above the last
+	// threshold announced before the load is considered to have been
+	// delayed rather than Burble's timer having jittered. Once a load is
+	// delayed, thresholds that it crosses again are announced again.
+	delayMinutes = 2
+)
+
+// Announcement is a load call to be announced to jumpers.
+type Announcement struct {
+	ID           uint64    `json:"id"`
+	Time         time.Time `json:"time"`
+	LoadID       int64     `json:"load_id"`
+	AircraftName string    `json:"aircraft_name"`
+	LoadNumber   string    `json:"load_number"`
+	CallMinutes  int64     `json:"call_minutes"` // the threshold announced
+	Jumpers      []string  `json:"jumpers"`
+	Text         string    `json:"text"`
+}
+
+// templateData is the data available to the announcement template.
+type templateData struct {
+	Call         string // e.g. "15 minute" or "NOW"
+	CallMinutes  int64
+	AircraftName string
+	LoadNumber   string
+	Jumpers      []string
+	JumperList   string // Jumpers joined with ", "
+}
+
+type Controller struct {
+	settings *settings.Settings
+	template *template.Template
+
+	lock      sync.Mutex
+	announced map[int64]int64 // load ID -> last threshold announced, or -1
+	seen      bool
+	nextID    uint64
+	recent    []Announcement
+}
+
+func NewController(settings *settings.Settings) *Controller {
+	t, err := template.New("announcement").Parse(settings.AnnouncementTemplate())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid announcement template: %v\n", err)
+		t = template.Must(template.New("announcement").Parse(defaultTemplate))
+	}
+	return &Controller{
+		settings:  settings,
+		template:  t,
+		announced: make(map[int64]int64),
+		nextID:    1,
+	}
+}
+
+func loadJumperNames(l *burble.Load) []string {
+	var names []string
+	seen := make(map[string]struct{})
+	l.ForEachJumper(func(j *burble.Jumper) {
+		if j.IsHeading {
+			return
+		}
+		if _, ok := seen[j.Name]; !ok {
+			seen[j.Name] = struct{}{}
+			names = append(names, j.Name)
+		}
+	})
+	return names
+}
+
+func (c *Controller) newAnnouncement(l *burble.Load, threshold int64, now time.Time) Announcement {
+	a := Announcement{
+		ID:           c.nextID,
+		Time:         now,
+		LoadID:       l.ID,
+		AircraftName: l.AircraftName,
+		LoadNumber:   l.LoadNumber,
+		CallMinutes:  threshold,
+		Jumpers:      loadJumperNames(l),
+	}
+	c.nextID++
+
+	data := templateData{
+		Call:         fmt.Sprintf("%d minute", threshold),
+		CallMinutes:  threshold,
+		AircraftName: l.AircraftName,
+		LoadNumber:   l.LoadNumber,
+		Jumpers:      a.Jumpers,
+		JumperList:   strings.Join(a.Jumpers, ", "),
+	}
+	if threshold == 0 {
+		data.Call = "NOW"
+	}
+	var b strings.Builder
+	if err := c.template.Execute(&b, data); err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot execute announcement template: %v\n", err)
+		fmt.Fprintf(&b, "%s call for %s load %s", data.Call, l.AircraftName, l.LoadNumber)
+	}
+	a.Text = strings.TrimSpace(b.String())
+
+	return a
+}
+
+// Update examines the call times of loads and returns the announcements
+// that are due. Each threshold is announced at most once per load. If
+// several thresholds have been crossed since the last update, only the
+// last of them is announced. Nothing is announced for the first set of
+// loads seen, since those calls have most likely already been made, and a
+// load that appears later is only announced if its call time is exactly a
+// threshold.
+func (c *Controller) Update(loads []*burble.Load, now time.Time) []Announcement {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	thresholds := c.settings.AnnouncementThresholds()
+
+	var announcements []Announcement
+	announced := make(map[int64]int64, len(loads))
+	for _, l := range loads {
+		last, ok := c.announced[l.ID]
+		if l.IsNoTime {
+			if ok {
+				announced[l.ID] = last
+			}
+			continue
+		}
+
+		// Find the last threshold that the load has crossed
+		crossed := int64(-1)
+		for _, t := range thresholds {
+			if l.CallMinutes <= t {
+				crossed = t
+			}
+		}
+
+		switch {
+		case !ok && !c.seen:
+			announced[l.ID] = crossed
+		case !ok && crossed != l.CallMinutes:
+			// A new load has already passed the threshold that
+			// it crossed, so the call was never made.
+			announced[l.ID] = crossed
+		case ok && last >= 0 && l.CallMinutes > last+delayMinutes:
+			announced[l.ID] = crossed
+		case crossed >= 0 && (!ok || last < 0 || crossed < last):
+			announcements = append(announcements,
+				c.newAnnouncement(l, crossed, now))
+			announced[l.ID] = crossed
+		case ok:
+			announced[l.ID] = last
+		default:
+			announced[l.ID] = crossed
+		}
+	}
+	c.announced = announced
+	c.seen = true
+
+	c.recent = append(c.recent, announcements...)
+	if n := len(c.recent) - maxRecentAnnouncements; n > 0 {
+		c.recent = append([]Announcement(nil), c.recent[n:]...)
+	}
+
+	return announcements
+}
+
+// Recent returns the retained announcements with IDs greater than after.
+func (c *Controller) Recent(after uint64) []Announcement {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	announcements := []Announcement{}
+	for _, a := range c.recent {
+		if a.ID > after {
+			announcements = append(announcements, a)
+		}
+	}
+	return announcements
+}
+
+// HTTPHandler serves recent announcements as JSON. The optional "after"
+// query parameter limits the response to announcements with greater IDs,
+// so that a client may poll for new announcements.
+func (c *Controller) HTTPHandler(w http.ResponseWriter, req *http.Request) {
+	var after uint64
+	if s := req.URL.Query().Get("after"); s != "" {
+		var err error
+		if after, err = strconv.ParseUint(s, 10, 64); err != nil {
+			http.Error(w, "invalid after parameter", http.StatusBadRequest)
+			return
+		}
+	}
+
+	data, err := json.Marshal(c.Recent(after))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = w.Write(data)
+}
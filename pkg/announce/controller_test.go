@@ -0,0 +1,155 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package announce
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/burble"
+	"github.com/jumptown-skydiving/manifest-server/pkg/settings"
+)
+
+// testController returns a controller with the default thresholds of 20,
+// 15, 10, 5, and 0 minutes.
+func testController(t *testing.T) *Controller {
+	t.Helper()
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "config.yaml")
+	config := "options_file: " + filepath.Join(dir, "options.json") + "\n" +
+		"jumper_rules_file: " + filepath.Join(dir, "jumper_rules.json") + "\n"
+	if err := os.WriteFile(filename, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s, err := settings.NewSettingsWithFilename(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewController(s)
+}
+
+// absent is a call time meaning that the load is not on the manifest.
+const absent = -100
+
+func TestUpdate(t *testing.T) {
+	tests := []struct {
+		name  string
+		calls []int64 // the load's call time in successive updates
+		want  []int64 // the threshold announced in each update, or -1
+	}{
+		{
+			name:  "thresholds crossed",
+			calls: []int64{25, 21, 20, 16, 15, 12, 10},
+			want:  []int64{-1, -1, 20, -1, 15, -1, 10},
+		},
+		{
+			name:  "several thresholds crossed",
+			calls: []int64{25, 9, 4},
+			want:  []int64{-1, 10, 5},
+		},
+		{
+			name:  "jitter around a threshold",
+			calls: []int64{25, 15, 16, 15, 17, 14},
+			want:  []int64{-1, 15, -1, -1, -1, -1},
+		},
+		{
+			name:  "delay",
+			calls: []int64{25, 15, 18, 16, 15},
+			want:  []int64{-1, 15, -1, -1, 15},
+		},
+		{
+			name:  "delay past a higher threshold",
+			calls: []int64{25, 10, 22, 20},
+			want:  []int64{-1, 10, -1, 20},
+		},
+		{
+			name:  "present at startup",
+			calls: []int64{12, 11, 10},
+			want:  []int64{-1, -1, 10},
+		},
+		{
+			name:  "first seen between thresholds",
+			calls: []int64{absent, 18, 17, 15},
+			want:  []int64{-1, -1, -1, 15},
+		},
+		{
+			name:  "first seen at a threshold",
+			calls: []int64{absent, 15, 14},
+			want:  []int64{-1, 15, -1},
+		},
+		{
+			name:  "first seen shortly before departure",
+			calls: []int64{absent, 3, 0},
+			want:  []int64{-1, -1, 0},
+		},
+		{
+			name:  "no time",
+			calls: []int64{absent, 130, 15},
+			want:  []int64{-1, -1, 15},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testController(t)
+			now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+			for i, call := range tt.calls {
+				var loads []*burble.Load
+				if call != absent {
+					loads = append(loads, &burble.Load{
+						ID:           1,
+						AircraftName: "Otter",
+						LoadNumber:   "3",
+						CallMinutes:  call,
+						IsNoTime:     call >= 120,
+					})
+				}
+				got := int64(-1)
+				announcements := c.Update(loads, now)
+				if len(announcements) > 1 {
+					t.Fatalf("update %d: %d announcements, want at most 1", i+1, len(announcements))
+				}
+				if len(announcements) == 1 {
+					got = announcements[0].CallMinutes
+				}
+				if got != tt.want[i] {
+					t.Errorf("update %d at %d minutes: announced %d, want %d", i+1, call, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAnnouncementText(t *testing.T) {
+	c := testController(t)
+	c.Update(nil, time.Now())
+
+	l := &burble.Load{ID: 1, AircraftName: "Otter", LoadNumber: "3", CallMinutes: 15}
+	l.SportJumpers = []*burble.Jumper{burble.NewJumper(1, "Alice", "Sport")}
+	for _, tt := range []struct {
+		call int64
+		want string
+	}{
+		{15, "15 minute call for Otter load 3"},
+		{0, "NOW call for Otter load 3"},
+	} {
+		l.CallMinutes = tt.call
+		announcements := c.Update([]*burble.Load{l}, time.Now())
+		if len(announcements) != 1 {
+			t.Fatalf("%d announcements at %d minutes, want 1", len(announcements), tt.call)
+		}
+		a := announcements[0]
+		if a.Text != tt.want || len(a.Jumpers) != 1 || a.Jumpers[0] != "Alice" {
+			t.Errorf("announced %q to %q, want %q to Alice", a.Text, a.Jumpers, tt.want)
+		}
+	}
+
+	recent := c.Recent(0)
+	if len(recent) != 2 || recent[0].ID >= recent[1].ID {
+		t.Errorf("Recent(0) = %v, want 2 announcements in order", recent)
+	}
+	if got := c.Recent(recent[0].ID); len(got) != 1 || got[0].ID != recent[1].ID {
+		t.Errorf("Recent(%d) = %v, want only the last", recent[0].ID, got)
+	}
+}
@@ -0,0 +1,166 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package burble
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	capturePrefix     = "burble-"
+	captureSuffix     = ".json"
+	captureTimeFormat = "20060102T150405.000Z"
+)
+
+// capture archives raw data received from Burble at time t into dir. The
+// capture's time is encoded into its filename so that replay can order
+// captures without relying on file modification times.
+func (c *Controller) capture(dir string, data []byte, t time.Time) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	name := capturePrefix + t.UTC().Format(captureTimeFormat) + captureSuffix
+	filename := filepath.Join(dir, name)
+	tempFilename := filename + ".tmp"
+	if err := ioutil.WriteFile(tempFilename, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tempFilename, filename)
+}
+
+type replayCapture struct {
+	time     time.Time
+	filename string
+}
+
+// replayer feeds captured Burble responses back on a simulated clock. The
+// simulated clock starts at the time of the first capture when replay begins
+// and advances at speed times real time.
+type replayer struct {
+	captures []replayCapture
+	speed    float64
+	loop     bool
+	start    time.Time
+
+	// last is the index of the capture most recently returned by next.
+	// It is only accessed from Refresh.
+	last int
+}
+
+func newReplayer(dir string, speed float64, loop bool) (*replayer, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &replayer{
+		speed: speed,
+		loop:  loop,
+		start: time.Now(),
+		last:  -1,
+	}
+	if r.speed <= 0 {
+		r.speed = 1.0
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, capturePrefix) ||
+			!strings.HasSuffix(name, captureSuffix) {
+			continue
+		}
+		ts := strings.TrimSuffix(strings.TrimPrefix(name, capturePrefix), captureSuffix)
+		t, err := time.Parse(captureTimeFormat, ts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Ignoring Burble capture %q: %v\n", name, err)
+			continue
+		}
+		r.captures = append(r.captures, replayCapture{
+			time:     t,
+			filename: filepath.Join(dir, name),
+		})
+	}
+	if len(r.captures) == 0 {
+		return nil, fmt.Errorf("no Burble captures found in %s", dir)
+	}
+	sort.Slice(r.captures, func(i, j int) bool {
+		return r.captures[i].time.Before(r.captures[j].time)
+	})
+
+	return r, nil
+}
+
+// Now returns the current time on the simulated clock. When looping, the
+// clock wraps back to the first capture a second after the last capture.
+func (r *replayer) Now() time.Time {
+	first := r.captures[0].time
+	elapsed := time.Duration(float64(time.Since(r.start)) * r.speed)
+	if r.loop {
+		span := r.captures[len(r.captures)-1].time.Sub(first)
+		elapsed %= span + time.Second
+	}
+	return first.Add(elapsed)
+}
+
+// next returns the data from the latest capture at or before the current
+// simulated time. It returns nil if that capture has already been returned.
+func (r *replayer) next() ([]byte, error) {
+	now := r.Now()
+	i := sort.Search(len(r.captures), func(i int) bool {
+		return r.captures[i].time.After(now)
+	}) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i == r.last {
+		return nil, nil
+	}
+	r.last = i
+	return ioutil.ReadFile(r.captures[i].filename)
+}
+
+// Now returns the current time, or the time on the simulated clock if
+// captured responses are being replayed.
+func (c *Controller) Now() time.Time {
+	c.lock.Lock()
+	r := c.replay
+	c.lock.Unlock()
+
+	if r != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+func (c *Controller) refreshFromReplay(dir string) (bool, error) {
+	c.lock.Lock()
+	r := c.replay
+	c.lock.Unlock()
+
+	if r == nil {
+		var err error
+		r, err = newReplayer(dir, c.settings.BurbleReplaySpeed(),
+			c.settings.BurbleReplayLoop())
+		if err != nil {
+			return false, err
+		}
+		c.lock.Lock()
+		c.replay = r
+		c.lock.Unlock()
+	}
+
+	data, err := r.next()
+	if err != nil || data == nil {
+		return false, err
+	}
+	changed, err := c.parse(data)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", r.captures[r.last].filename, err)
+	}
+	return changed, nil
+}
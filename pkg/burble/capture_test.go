@@ -0,0 +1,251 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package burble
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testCaptureStart is the time of the first of the test captures.
+var testCaptureStart = time.Date(2023, 6, 10, 14, 0, 0, 0, time.UTC)
+
+// testCaptureOffsets are the times of the test captures, whose data is the
+// index of each capture.
+var testCaptureOffsets = []time.Duration{0, time.Minute, 3 * time.Minute}
+
+// writeTestCaptures captures each of the test captures into a temporary
+// directory, which it returns.
+func writeTestCaptures(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	c := &Controller{}
+	for i, offset := range testCaptureOffsets {
+		data := []byte{byte('0' + i)}
+		if err := c.capture(dir, data, testCaptureStart.Add(offset)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// setReplayElapsed sets the real time that has elapsed since replay began.
+func setReplayElapsed(r *replayer, elapsed time.Duration) {
+	r.start = time.Now().Add(-elapsed)
+}
+
+func TestCapture(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "captures")
+	c := &Controller{}
+	at := time.Date(2023, 6, 10, 9, 30, 15, 250e6, time.FixedZone("CDT", -5*60*60))
+	if err := c.capture(dir, []byte(`{"loads": []}`), at); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	// The time is in UTC, and the temporary file is renamed.
+	want := []string{"burble-20230610T143015.250Z.json"}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("captured %q, want %q", names, want)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, want[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"loads": []}` {
+		t.Errorf("captured %q", data)
+	}
+}
+
+func TestCaptureWriteFails(t *testing.T) {
+	dir := t.TempDir()
+	at := testCaptureStart
+	filename := filepath.Join(dir, capturePrefix+at.Format(captureTimeFormat)+captureSuffix)
+
+	// The temporary file cannot be written, so nothing is captured
+	// rather than a partial capture.
+	if err := os.Mkdir(filename+".tmp", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	c := &Controller{}
+	if err := c.capture(dir, []byte("data"), at); err == nil {
+		t.Fatal("capture succeeded")
+	}
+	if _, err := os.Stat(filename); !os.IsNotExist(err) {
+		t.Errorf("capture written despite failing: %v", err)
+	}
+}
+
+func TestNewReplayer(t *testing.T) {
+	dir := writeTestCaptures(t)
+	for _, name := range []string{
+		"burble-20230610T140000Z.json", // malformed time
+		"burble-20230610T140030.000Z.json.tmp",
+		"capture.json",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "burble-20230610T140030.000Z.json"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := newReplayer(dir, 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []time.Duration
+	for _, c := range r.captures {
+		got = append(got, c.time.Sub(testCaptureStart))
+	}
+	if !reflect.DeepEqual(got, testCaptureOffsets) {
+		t.Errorf("captures at %v, want %v", got, testCaptureOffsets)
+	}
+	if r.speed != 1 {
+		t.Errorf("speed %v, want 1", r.speed)
+	}
+
+	if _, err = newReplayer(t.TempDir(), 1, false); err == nil {
+		t.Error("replaying no captures succeeded")
+	}
+}
+
+func TestReplayerNext(t *testing.T) {
+	type step struct {
+		elapsed time.Duration // real time since replay began
+		want    string        // capture returned, or "" for none
+	}
+	tests := []struct {
+		name  string
+		speed float64
+		loop  bool
+		steps []step
+	}{
+		{
+			name:  "real time",
+			speed: 1,
+			steps: []step{
+				{0, "0"},
+				{30 * time.Second, ""},
+				{time.Minute, "1"},
+				{2 * time.Minute, ""},
+				{3 * time.Minute, "2"},
+				{time.Hour, ""},
+			},
+		},
+		{
+			name:  "skipped capture",
+			speed: 1,
+			steps: []step{
+				{0, "0"},
+				{4 * time.Minute, "2"},
+			},
+		},
+		{
+			name:  "faster",
+			speed: 60,
+			steps: []step{
+				{0, "0"},
+				{time.Second, "1"},
+				{2 * time.Second, ""},
+				{3 * time.Second, "2"},
+			},
+		},
+		{
+			name:  "slower",
+			speed: 0.5,
+			steps: []step{
+				{0, "0"},
+				{time.Minute, ""},
+				{2 * time.Minute, "1"},
+				{6 * time.Minute, "2"},
+			},
+		},
+		{
+			// The simulated clock wraps a second after the
+			// last capture.
+			name:  "looping",
+			speed: 1,
+			loop:  true,
+			steps: []step{
+				{0, "0"},
+				{3 * time.Minute, "2"},
+				{3*time.Minute + 10*time.Second, "0"},
+				{4*time.Minute + 10*time.Second, "1"},
+				{6*time.Minute + 10*time.Second, "0"},
+			},
+		},
+		{
+			name:  "looping faster",
+			speed: 60,
+			loop:  true,
+			steps: []step{
+				{3 * time.Second, "2"},
+				{5 * time.Second, "1"},
+			},
+		},
+	}
+	dir := writeTestCaptures(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := newReplayer(dir, tt.speed, tt.loop)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range tt.steps {
+				setReplayElapsed(r, s.elapsed)
+				data, err := r.next()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(data) != s.want {
+					t.Errorf("after %v, next() = %q, want %q", s.elapsed, data, s.want)
+				}
+			}
+		})
+	}
+}
+
+func TestReplayerNow(t *testing.T) {
+	tests := []struct {
+		name    string
+		speed   float64
+		loop    bool
+		elapsed time.Duration
+		want    time.Duration // since the first capture
+	}{
+		{"start", 1, false, 0, 0},
+		{"real time", 1, false, 2 * time.Minute, 2 * time.Minute},
+		{"faster", 30, false, 10 * time.Second, 5 * time.Minute},
+		{"after the last capture", 1, false, 10 * time.Minute, 10 * time.Minute},
+		{"looping", 1, true, 2 * time.Minute, 2 * time.Minute},
+		{"looped", 1, true, 3*time.Minute + 20*time.Second, 19 * time.Second},
+		{"looped faster", 2, true, 100 * time.Second, 19 * time.Second},
+	}
+	dir := writeTestCaptures(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := newReplayer(dir, tt.speed, tt.loop)
+			if err != nil {
+				t.Fatal(err)
+			}
+			setReplayElapsed(r, tt.elapsed)
+			got := r.Now().Sub(testCaptureStart)
+			// Allow for the real time that passes during the test.
+			if d := got - tt.want; d < 0 || d > time.Second {
+				t.Errorf("Now() is %v after the first capture, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,27 +4,34 @@ package burble
 
 import (
 	"bytes"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/cookiejar"
 	"net/url"
 	"os"
 	"reflect"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 	"unicode"
 
 	"github.com/jumptown-skydiving/manifest-server/pkg/decode"
 	"github.com/jumptown-skydiving/manifest-server/pkg/settings"
+	"github.com/jumptown-skydiving/manifest-server/pkg/throttle"
+
+	"golang.org/x/net/publicsuffix"
 )
 
 const (
-	burbleBaseURL     = "https://dzm.burblesoft.com"
-	burblePublicURL   = burbleBaseURL + "/jmp"
-	burbleManifestURL = burbleBaseURL + "/ajax_dzm2_frontend_jumpermanifestpublic"
+	burblePublicPath   = "/jmp"
+	burbleManifestPath = "/ajax_dzm2_frontend_jumpermanifestpublic"
+
+	// burbleMinColumns is the fewest columns of loads requested from
+	// Burble.
+	burbleMinColumns = 10
 )
 
 func parseGroupName(s string) string {
@@ -44,21 +51,26 @@ func parseGroupName(s string) string {
 	return s
 }
 
-func jumperFromJSON(json map[string]interface{}) *Jumper {
-	name := json["name"].(string)
-	id := decode.Int("id", json["id"])
-	shortName := json["jump"].(string)
-	if s, ok := json["handycam_jump"].(string); ok && s != "" {
+func jumperFromMember(m payloadMember, rules Rules) *Jumper {
+	shortName := m.Jump
+	if m.HandycamJump != "" {
 		shortName = "Handycam"
 	}
 
-	jumper := NewJumper(id, name, shortName)
-	if gn, ok := json["group_number"].(string); ok {
-		jumper.GroupName = parseGroupName(gn)
+	jumper := NewJumper(m.ID, m.Name, shortName)
+	if m.GroupNumber != "" {
+		jumper.GroupName = parseGroupName(m.GroupNumber)
 	}
-	if ftn, ok := json["formation_type_name"].(string); ok {
-		jumper.IsPondSwoop = strings.ToLower(ftn) == "pond swoop"
+	if m.Type == "Student" {
+		jumper.StudentProgram, jumper.AFFLevel = ParseStudentProgram(jumper.ShortName)
 	}
+	rules.Apply(jumper, RuleAttributes{
+		JumpType:       jumper.ShortName,
+		GroupName:      jumper.GroupName,
+		FormationType:  m.FormationTypeName,
+		MemberType:     m.Type,
+		StudentProgram: jumper.StudentProgram.String(),
+	})
 
 	// use rig_name if it's present, but fallback to broken rig_id instead
 	// rig_id is inconsistent with other name/id fields in the Burble data.
@@ -67,157 +79,270 @@ func jumperFromJSON(json map[string]interface{}) *Jumper {
 	// Update: Looks like Burble fixed this at some point over the summer.
 	//         Leave all of this here for now until we can verify the fix,
 	//         but add an additional "0" check for "rig_id"
-	if rigName, ok := json["rig_name"].(string); ok && rigName != "" {
-		jumper.RigName = rigName
-	} else if rigName, ok = json["rig_id"].(string); ok && rigName != "" && rigName != "0" {
-		jumper.RigName = rigName
+	if m.RigName != "" {
+		jumper.RigName = m.RigName
+	} else if m.RigID != "" && m.RigID != "0" {
+		jumper.RigName = m.RigID
 	}
 	return jumper
 }
 
 type Controller struct {
-	settings    *settings.Settings
+	settings *settings.Settings
+
+	// client makes the requests to Burble. Each controller has its own,
+	// so that each dropzone has its own Burble session cookies.
+	client *http.Client
+
 	columnCount int
 	loads       []*Load
 
+	// allLoads are all of the loads received from Burble, before those
+	// displayed are chosen from them.
+	allLoads []*Load
+
+	// lastRefresh is the time of the last successful refresh, and
+	// lastError is the error returned from the most recent refresh.
+	lastRefresh time.Time
+	lastError   error
+
+	// hint tracks the refresh delays requested by Burble.
+	hint throttle.Hint
+
+	// replay is non-nil when replaying captured data instead of
+	// querying Burble.
+	replay *replayer
+
+	// decodeErrors are the errors from decoding the most recent data
+	// from Burble. Loads with errors are skipped.
+	decodeErrors []decode.FieldError
+
+	// rules are compiled from rulesSource, and are only recompiled when
+	// the settings' jumper rules change.
+	rules       Rules
+	rulesSource []settings.JumperRule
+
 	lock sync.Mutex
 }
 
 func NewController(settings *settings.Settings) *Controller {
+	// cookiejar.New never returns an error.
+	jar, _ := cookiejar.New(&cookiejar.Options{
+		PublicSuffixList: publicsuffix.List,
+	})
 	return &Controller{
 		settings: settings,
+		client:   &http.Client{Jar: jar},
 	}
 }
 
+func (c *Controller) publicURL() string {
+	return c.settings.BurbleBaseURL() + burblePublicPath
+}
+
+func (c *Controller) manifestURL() string {
+	return c.settings.BurbleBaseURL() + burbleManifestPath
+}
+
 // RefreshCookies makes a throw-away request to get cookies from Burble so that
 // data refreshes will work.
 func (c *Controller) RefreshCookies() error {
-	// Create and use our own request rather than use http.DefaultClient.Get
-	// so that we can keep up the charade that we're a browser and not a
+	// Create and use our own request rather than use c.client.Get so
+	// that we can keep up the charade that we're a browser and not a
 	// server app scraping data!
 	dzid := c.settings.BurbleDropzoneID()
-	urlWithDZID := fmt.Sprintf("%s?dz_id=%d", burblePublicURL, dzid)
+	urlWithDZID := fmt.Sprintf("%s?dz_id=%d", c.publicURL(), dzid)
 	request, err := c.settings.NewHTTPRequest(http.MethodPost, urlWithDZID, nil)
 	if err != nil {
 		return err
 	}
 
-	if _, err = http.DefaultClient.Do(request); err != nil {
+	resp, err := c.client.Do(request)
+	if err != nil {
 		return err
 	}
 
 	// All we want are the cookies. They've been set in the cookie jar, so
 	// we can throw away the response body.
+	resp.Body.Close()
+
+	c.hint.Update(resp)
+	return throttle.CheckResponse(resp)
+}
 
-	return nil
+// NotBefore returns the earliest time at which Burble has asked to be queried
+// again.
+func (c *Controller) NotBefore() time.Time {
+	return c.hint.NotBefore()
 }
 
 // Refresh retrieves new data from Burble
 func (c *Controller) Refresh() (bool, error) {
-	u, err := url.Parse(burbleManifestURL)
+	changed, err := c.refresh()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err == nil {
+		c.lastRefresh = time.Now()
+	}
+	c.lastError = err
+
+	return changed, err
+}
+
+func (c *Controller) refresh() (bool, error) {
+	if dir := c.settings.BurbleReplayDir(); dir != "" {
+		return c.refreshFromReplay(dir)
+	}
+
+	data, err := c.fetch()
 	if err != nil {
+		// Burble rejects requests when our session cookies have
+		// expired, so get new ones for the next refresh.
+		var statusErr *throttle.StatusError
+		if errors.As(err, &statusErr) &&
+			(statusErr.StatusCode == http.StatusUnauthorized ||
+				statusErr.StatusCode == http.StatusForbidden) {
+			if cerr := c.RefreshCookies(); cerr != nil {
+				fmt.Fprintf(os.Stderr, "Error refreshing cookies: %v\n", cerr)
+			}
+		}
 		return false, err
 	}
-	if len(http.DefaultClient.Jar.Cookies(u)) == 0 {
+	if dir := c.settings.BurbleCaptureDir(); dir != "" {
+		if err = c.capture(dir, data, time.Now()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error capturing Burble data: %v\n", err)
+		}
+	}
+
+	changed, err := c.parse(data)
+	if err != nil {
+		// If we get unparseable data, dump it to a file so we can
+		// review it later to see what the problem is.
+		_ = ioutil.WriteFile("burble.json", data, 0644)
+		if cerr := c.RefreshCookies(); cerr != nil {
+			fmt.Fprintf(os.Stderr, "Error refreshing cookies: %v\n", cerr)
+		}
+	}
+	return changed, err
+}
+
+// fetch requests the raw manifest data from Burble.
+func (c *Controller) fetch() ([]byte, error) {
+	u, err := url.Parse(c.manifestURL())
+	if err != nil {
+		return nil, err
+	}
+	if len(c.client.Jar.Cookies(u)) == 0 {
 		if err = c.RefreshCookies(); err != nil {
-			return false, err
+			return nil, err
 		}
 	}
 
 	// Ask Burble for the number of columns we want to display + 1
 	// Do this so that we can filter out loads older tha min call minutes,
-	// but still be able to determine which jumpers are turning.
+	// but still be able to determine which jumpers are turning. Ask for
+	// at least burbleMinColumns so that changing the number of columns
+	// displayed does not change the loads from which events are derived.
 	burbleNumColumns := c.settings.DisplayColumns() + 1
+	if burbleNumColumns < burbleMinColumns {
+		burbleNumColumns = burbleMinColumns
+	}
 
 	dzid := c.settings.BurbleDropzoneID()
 	bodyString := fmt.Sprintf("aircraft=0&columns=%d&display_tandem=1&display_student=1&display_sport=1&display_menu=0&font_size=0&action=getLoads&dz_id=%d&date_format=m%%2Fd%%2FY&acl_application=Burble%%20DZM", burbleNumColumns, dzid)
 	body := bytes.NewReader([]byte(bodyString))
 
-	request, err := c.settings.NewHTTPRequest(http.MethodPost, burbleManifestURL, body)
+	request, err := c.settings.NewHTTPRequest(http.MethodPost, c.manifestURL(), body)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	request.Header.Set("Origin", burbleBaseURL)
-	request.Header.Set("Referer", burblePublicURL)
+	request.Header.Set("Origin", c.settings.BurbleBaseURL())
+	request.Header.Set("Referer", c.publicURL())
 	request.Header.Set("X-Requested-With", "XMLHttpRequest")
 
-	resp, err := http.DefaultClient.Do(request)
+	resp, err := c.client.Do(request)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	data, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return false, err
+	c.hint.Update(resp)
+	if err = throttle.CheckResponse(resp); err != nil {
+		return nil, err
 	}
+	return ioutil.ReadAll(resp.Body)
+}
 
-	// It would be nicer to parse the data into structs, but Burble returns
-	// JSON data that makes that impossible. Sometimes fields are ints as
-	// strings, sometimes they're ints, for empty loads, it's an empty
-	// array instead of null or an empty map, etc.
+// jumperRules returns the compiled jumper rules, compiling them only if they
+// have changed since they were last compiled, so that an invalid rule is
+// reported once rather than on every refresh.
+func (c *Controller) jumperRules() Rules {
+	source := c.settings.JumperRules()
 
-	var rawBurbleData interface{}
-	if err = json.Unmarshal(data, &rawBurbleData); err != nil {
-		// If we get unparseable data, dump it to a file so we can
-		// review it later to see what the problem is.
-		_ = ioutil.WriteFile("burble.json", data, 0644)
-		if err = c.RefreshCookies(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error refreshing cookies: %v\n", err)
-		}
-		return false, err
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.rulesSource != nil && reflect.DeepEqual(source, c.rulesSource) {
+		return c.rules
 	}
+	rules, err := CompileRules(source)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid jumper rule: %v\n", err)
+	}
+	if source == nil {
+		source = []settings.JumperRule{}
+	}
+	c.rules, c.rulesSource = rules, source
+	return rules
+}
 
-	var loads []*Load
-	burbleData := rawBurbleData.(map[string]interface{})
-	if _, ok := burbleData["loads"]; !ok {
-		// If we get unparseable data, dump it to a file so we can
-		// review it later to see what the problem is.
-		_ = ioutil.WriteFile("burble.json", data, 0644)
-		if err = c.RefreshCookies(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error refreshing cookies: %v\n", err)
-		}
-		return false, errors.New("Burble data is missing load information")
+// parse processes raw manifest data from Burble, whether freshly fetched or
+// replayed from a capture, and updates the controller's loads.
+func (c *Controller) parse(data []byte) (bool, error) {
+	p, decodeErrors, err := decodePayload(data)
+	if err != nil {
+		return false, err
 	}
+	c.reportDecodeErrors(decodeErrors)
 
+	var loads []*Load
 	definedJumptypeGroups := c.settings.GroupByJumpTypes()
 	organizerStrings := c.settings.OrganizerStrings()
-	sourceLoads := burbleData["loads"].([]interface{})
-	columnCount := burbleNumColumns - 1
-	for _, rawLoadData := range sourceLoads {
-		loadData, ok := rawLoadData.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
+	rules := c.jumperRules()
+	columnCount := c.settings.DisplayColumns()
+	for _, loadData := range p.Loads {
 		// Ignore loads that are not public. The old format had this
 		// field, but the new format does not. Honor it if it comes
 		// back.
-		if isPublic, ok := loadData["is_public"]; ok {
-			if !decode.Bool("is_public", isPublic) {
-				continue
-			}
+		if loadData.HasIsPublic && !loadData.IsPublic {
+			continue
 		}
 
 		l := Load{
-			ID:           decode.Int("id", loadData["id"]),
-			AircraftName: loadData["aircraft_name"].(string),
-			IsFueling:    decode.Bool("is_fueling", loadData["is_fueling"]),
-			IsTurning:    decode.Bool("is_turning", loadData["is_turning"]),
-			CallMinutes:  decode.Int("time_left", loadData["time_left"]),
+			ID:           loadData.ID,
+			AircraftName: loadData.AircraftName,
+			IsFueling:    loadData.IsFueling,
+			IsTurning:    loadData.IsTurning,
+			CallMinutes:  loadData.TimeLeft,
 		}
 		if l.CallMinutes >= 120 {
 			l.IsNoTime = true
 		}
 
 		// aircraft_name seems to always be "" in the new format
-		name := loadData["name"].(string)
+		name := loadData.Name
 		if l.AircraftName == "" {
 			if x := strings.LastIndex(name, " "); x != -1 {
 				l.AircraftName = name[:x]
 			}
 		}
-		l.LoadNumber = strings.TrimSpace(name[len(l.AircraftName)+1:])
+		if len(name) > len(l.AircraftName) {
+			l.LoadNumber = strings.TrimSpace(name[len(l.AircraftName)+1:])
+		}
+		aircraft, isRegistered := c.settings.LookupAircraft(l.AircraftName)
+		if isRegistered {
+			l.AircraftName = aircraft.Name
+		}
 
 		// Reporting of available slots seems to be something Burble has
 		// had ongoing difficulties with. How it's reported and its own
@@ -228,15 +353,15 @@ func (c *Controller) Refresh() (bool, error) {
 		// our own computation has continued to work and I'm feeling
 		// more trusting of it given the troubled history here.
 		var privateSlots, publicSlots int64
-		maxSlots := decode.Int("max_slots", loadData["max_slots"])
-		reserveSlots := decode.Int("reserve_slots", loadData["reserve_slots"])
+		maxSlots := loadData.MaxSlots
+		if maxSlots <= 0 && isRegistered {
+			maxSlots = int64(aircraft.Capacity)
+		}
+		reserveSlots := loadData.ReserveSlots
 
 		jumptypeGroups := make(map[string]*Jumper)
-		groups := loadData["groups"].([]interface{})
-		for _, rawGroupData := range groups {
-			members := rawGroupData.([]interface{})
-			memberData := members[0].(map[string]interface{})
-			primaryJumper := jumperFromJSON(memberData)
+		for _, members := range loadData.Groups {
+			primaryJumper := jumperFromMember(members[0], rules)
 
 			jump := strings.ToLower(primaryJumper.ShortName)
 			for _, o := range organizerStrings {
@@ -254,6 +379,7 @@ func (c *Controller) Refresh() (bool, error) {
 							ID:          int64(gx),
 							Name:        g.ManifestHeading,
 							IsOrganizer: true,
+							IsHeading:   true,
 							GroupName:   g.JumpType,
 						}
 						l.SportJumpers = append(l.SportJumpers, jumptypeGroups[jump])
@@ -263,7 +389,7 @@ func (c *Controller) Refresh() (bool, error) {
 					break
 				}
 			}
-			switch memberData["type"].(string) {
+			switch members[0].Type {
 			case "Sport Jumper":
 				l.SportJumpers = append(l.SportJumpers, primaryJumper)
 			case "Student":
@@ -273,20 +399,22 @@ func (c *Controller) Refresh() (bool, error) {
 				primaryJumper.IsTandem = true
 				l.Tandems = append(l.Tandems, primaryJumper)
 			}
-			for i, rawMemberData := range members {
-				memberData = rawMemberData.(map[string]interface{})
+			for i, member := range members {
 				switch {
-				case decode.Bool("is_public", memberData["is_public"]):
+				case member.IsPublic:
 					publicSlots++
-				case decode.Bool("is_private", memberData["is_private"]):
+				case member.IsPrivate:
 					privateSlots++
 				}
 				if i < 1 {
 					continue
 				}
-				jumper := jumperFromJSON(memberData)
+				jumper := jumperFromMember(member, rules)
 				primaryJumper.AddGroupMember(jumper)
 			}
+			if primaryJumper.IsStudent {
+				primaryJumper.inferStudentProgram()
+			}
 		}
 
 		// Group sport jumpers by organizer. Start by building a map of
@@ -389,6 +517,10 @@ func (c *Controller) Refresh() (bool, error) {
 		c.loads = finalLoads
 		changed = true
 	}
+	if !reflect.DeepEqual(c.allLoads, loads) {
+		c.allLoads = loads
+		changed = true
+	}
 
 	return changed, nil
 }
@@ -421,8 +553,24 @@ func (c *Controller) Loads() []*Load {
 	return c.loads
 }
 
+// AllLoads returns all of the loads received from Burble, including those
+// that are not displayed because of the display options.
+func (c *Controller) AllLoads() []*Load {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.allLoads
+}
+
 func (c *Controller) ColumnCount() int {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 	return c.columnCount
 }
+
+// Health returns the time of the last successful refresh and the error from
+// the most recent refresh, if any.
+func (c *Controller) Health() (time.Time, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lastRefresh, c.lastError
+}
@@ -0,0 +1,121 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package burble
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/settings"
+)
+
+func TestParseLoadNames(t *testing.T) {
+	tests := []struct {
+		name         string
+		aircraftName string
+		wantAircraft string
+		wantNumber   string
+	}{
+		{"Otter 3", "", "Otter", "3"},
+		{"King Air 12", "", "King Air", "12"},
+		{"Otter 3", "Otter", "Otter", "3"},
+		{"Otter", "Otter", "Otter", ""},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%s", tt.name, tt.aircraftName), func(t *testing.T) {
+			c := NewController(testSettings(t))
+			data := fmt.Sprintf(`{"loads": [{"id": 101, "name": %q, "aircraft_name": %q,
+				"time_left": 15, "groups": []}]}`, tt.name, tt.aircraftName)
+			if _, err := c.parse([]byte(data)); err != nil {
+				t.Fatal(err)
+			}
+			if errs := c.DecodeErrors(); len(errs) != 0 {
+				t.Fatalf("decode errors %v", errs)
+			}
+			loads := c.Loads()
+			if len(loads) != 1 {
+				t.Fatalf("%d loads, want 1", len(loads))
+			}
+			if l := loads[0]; l.AircraftName != tt.wantAircraft || l.LoadNumber != tt.wantNumber {
+				t.Errorf("aircraft %q and load number %q, want %q and %q",
+					l.AircraftName, l.LoadNumber, tt.wantAircraft, tt.wantNumber)
+			}
+		})
+	}
+}
+
+func TestJumperRulesCompiledOnce(t *testing.T) {
+	c := NewController(testSettings(t))
+	rules := c.jumperRules()
+	if len(rules) == 0 {
+		t.Fatal("no default rules")
+	}
+	if again := c.jumperRules(); &again[0] != &rules[0] {
+		t.Error("unchanged rules compiled again")
+	}
+
+	if err := c.settings.SetJumperRules([]settings.JumperRule{
+		{Match: settings.JumperRuleMatch{JumpType: "^vs$"}, Categories: []string{"videographer"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	changed := c.jumperRules()
+	if len(changed) != 1 {
+		t.Fatalf("%d rules after they changed, want 1", len(changed))
+	}
+	if again := c.jumperRules(); &again[0] != &changed[0] {
+		t.Error("unchanged rules compiled again")
+	}
+}
+
+func TestControllersHaveOwnCookies(t *testing.T) {
+	// Burble gives each dropzone a session cookie, which it expects with
+	// each request for that dropzone's manifest.
+	var (
+		lock     sync.Mutex
+		sessions []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := req.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		switch req.URL.Path {
+		case burblePublicPath:
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "dz" + req.Form.Get("dz_id")})
+		case burbleManifestPath:
+			cookie, err := req.Cookie("session")
+			if err != nil || cookie.Value != "dz"+req.Form.Get("dz_id") {
+				http.Error(w, "wrong session", http.StatusForbidden)
+				return
+			}
+			lock.Lock()
+			sessions = append(sessions, cookie.Value)
+			lock.Unlock()
+			fmt.Fprint(w, `{"loads": []}`)
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	defer server.Close()
+
+	var controllers []*Controller
+	for _, dzid := range []int{1, 2} {
+		s := testSettingsWithConfig(t, fmt.Sprintf("burble:\n  base_url: %s\n  dzid: %d\n", server.URL, dzid))
+		controllers = append(controllers, NewController(s))
+	}
+	for _, c := range append(controllers, controllers...) {
+		if _, err := c.Refresh(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []string{"dz1", "dz2", "dz1", "dz2"}
+	if !reflect.DeepEqual(sessions, want) {
+		t.Errorf("manifests requested with sessions %q, want %q", sessions, want)
+	}
+}
@@ -0,0 +1,252 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package burble
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+type EventType int
+
+const (
+	JumperAddedEvent   EventType = iota // a jumper was added to a load
+	JumperRemovedEvent                  // a jumper was removed from a load
+	JumperMovedEvent                    // a jumper moved from one load to another
+	LoadCreatedEvent                    // a load was added to the manifest
+	LoadDepartedEvent                   // a load left the manifest after its call
+	LoadRemovedEvent                    // a load left the manifest before its call
+	CallTimeEvent                       // a load's call time crossed a threshold
+	SlotsOpenedEvent                    // slots became available on a load
+)
+
+// CallTimeThresholds are the call times, in minutes, for which CallTimeEvents
+// are generated. They are in descending order.
+var CallTimeThresholds = []int64{20, 15, 10, 5}
+
+// Event describes a single change between two successive sets of loads.
+type Event struct {
+	Type         EventType
+	Time         time.Time
+	LoadID       int64
+	AircraftName string
+	LoadNumber   string
+
+	// JumperID and JumperName are set for jumper events. JumperID is 0 if
+	// the manifest source does not identify jumpers.
+	JumperID   int64
+	JumperName string
+
+	// FromLoadID, FromAircraftName, and FromLoadNumber identify the load
+	// that a jumper moved from for JumperMovedEvent.
+	FromLoadID       int64
+	FromAircraftName string
+	FromLoadNumber   string
+
+	// CallMinutes is the load's call time. For CallTimeEvent, it is the
+	// threshold that was crossed rather than the actual call time.
+	CallMinutes int64
+
+	// SlotsAvailable is the number of slots available on the load.
+	SlotsAvailable int64
+}
+
+func (e Event) String() string {
+	load := fmt.Sprintf("%s %s", e.AircraftName, e.LoadNumber)
+	switch e.Type {
+	case JumperAddedEvent:
+		return fmt.Sprintf("%s added to %s", e.JumperName, load)
+	case JumperRemovedEvent:
+		return fmt.Sprintf("%s removed from %s", e.JumperName, load)
+	case JumperMovedEvent:
+		return fmt.Sprintf("%s moved from %s %s to %s", e.JumperName,
+			e.FromAircraftName, e.FromLoadNumber, load)
+	case LoadCreatedEvent:
+		return fmt.Sprintf("%s added", load)
+	case LoadDepartedEvent:
+		return fmt.Sprintf("%s departed", load)
+	case LoadRemovedEvent:
+		return fmt.Sprintf("%s removed", load)
+	case CallTimeEvent:
+		return fmt.Sprintf("%d minute call for %s", e.CallMinutes, load)
+	case SlotsOpenedEvent:
+		if e.SlotsAvailable == 1 {
+			return fmt.Sprintf("1 slot open on %s", load)
+		}
+		return fmt.Sprintf("%d slots open on %s", e.SlotsAvailable, load)
+	}
+	return load
+}
+
+func newLoadEvent(t EventType, l *Load, now time.Time) Event {
+	return Event{
+		Type:           t,
+		Time:           now,
+		LoadID:         l.ID,
+		AircraftName:   l.AircraftName,
+		LoadNumber:     l.LoadNumber,
+		CallMinutes:    l.CallMinutes,
+		SlotsAvailable: l.SlotsAvailable,
+	}
+}
+
+func newJumperEvent(t EventType, l *Load, j *Jumper, now time.Time) Event {
+	e := newLoadEvent(t, l, now)
+	e.JumperID = j.ID
+	e.JumperName = j.Name
+	return e
+}
+
+// jumperKey identifies a jumper across loads. Burble identifies each jumper
+// by ID, but a source that does not leaves the ID 0, in which case the name
+// is the best identity available.
+type jumperKey struct {
+	id   int64
+	name string
+}
+
+func newJumperKey(j *Jumper) jumperKey {
+	if j.ID != 0 {
+		return jumperKey{id: j.ID}
+	}
+	return jumperKey{name: j.Name}
+}
+
+// loadJumpers returns the jumpers on a load, keyed by identity.
+func loadJumpers(l *Load) map[jumperKey]*Jumper {
+	jumpers := make(map[jumperKey]*Jumper)
+	l.ForEachJumper(func(j *Jumper) {
+		if !j.IsHeading {
+			jumpers[newJumperKey(j)] = j
+		}
+	})
+	return jumpers
+}
+
+// sortedJumpers returns the keys of jumpers sorted by name, so that events
+// are generated in a stable order.
+func sortedJumpers(jumpers map[jumperKey]*Jumper) []jumperKey {
+	keys := make([]jumperKey, 0, len(jumpers))
+	for k := range jumpers {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := jumpers[keys[i]], jumpers[keys[j]]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+	return keys
+}
+
+// Diff compares two successive sets of loads and returns the events that
+// describe the changes between them. A jumper that is removed from one load
+// and added to another is reported as having moved rather than as separate
+// removal and addition events.
+func Diff(oldLoads, newLoads []*Load, now time.Time) []Event {
+	var events []Event
+
+	oldByID := make(map[int64]*Load, len(oldLoads))
+	for _, l := range oldLoads {
+		oldByID[l.ID] = l
+	}
+	newByID := make(map[int64]*Load, len(newLoads))
+	for _, l := range newLoads {
+		newByID[l.ID] = l
+	}
+
+	// Jumpers removed from each load, keyed by identity, to find moves.
+	type removal struct {
+		jumper *Jumper
+		load   *Load
+	}
+	removed := make(map[jumperKey]removal)
+	var removedOrder []jumperKey
+	type addition struct {
+		jumper *Jumper
+		load   *Load
+		key    jumperKey
+	}
+	var added []addition
+
+	for _, ol := range oldLoads {
+		if _, ok := newByID[ol.ID]; ok {
+			continue
+		}
+		t := LoadRemovedEvent
+		if ol.CallMinutes <= 0 {
+			t = LoadDepartedEvent
+		}
+		events = append(events, newLoadEvent(t, ol, now))
+	}
+
+	for _, nl := range newLoads {
+		ol, ok := oldByID[nl.ID]
+		if !ok {
+			events = append(events, newLoadEvent(LoadCreatedEvent, nl, now))
+			jumpers := loadJumpers(nl)
+			for _, k := range sortedJumpers(jumpers) {
+				added = append(added, addition{jumper: jumpers[k], load: nl, key: k})
+			}
+			continue
+		}
+
+		oldJumpers := loadJumpers(ol)
+		newJumpers := loadJumpers(nl)
+		for _, k := range sortedJumpers(oldJumpers) {
+			if _, ok := newJumpers[k]; !ok {
+				if _, dup := removed[k]; !dup {
+					removedOrder = append(removedOrder, k)
+				}
+				removed[k] = removal{jumper: oldJumpers[k], load: ol}
+			}
+		}
+		for _, k := range sortedJumpers(newJumpers) {
+			if _, ok := oldJumpers[k]; !ok {
+				added = append(added, addition{jumper: newJumpers[k], load: nl, key: k})
+			}
+		}
+
+		// If several thresholds were crossed at once, only report the
+		// last of them.
+		if !nl.IsNoTime && !ol.IsNoTime {
+			crossed := int64(-1)
+			for _, threshold := range CallTimeThresholds {
+				if ol.CallMinutes > threshold && nl.CallMinutes <= threshold {
+					crossed = threshold
+				}
+			}
+			if crossed >= 0 {
+				e := newLoadEvent(CallTimeEvent, nl, now)
+				e.CallMinutes = crossed
+				events = append(events, e)
+			}
+		}
+
+		if nl.SlotsAvailable > ol.SlotsAvailable {
+			events = append(events, newLoadEvent(SlotsOpenedEvent, nl, now))
+		}
+	}
+
+	for _, a := range added {
+		if r, ok := removed[a.key]; ok {
+			delete(removed, a.key)
+			e := newJumperEvent(JumperMovedEvent, a.load, a.jumper, now)
+			e.FromLoadID = r.load.ID
+			e.FromAircraftName = r.load.AircraftName
+			e.FromLoadNumber = r.load.LoadNumber
+			events = append(events, e)
+			continue
+		}
+		events = append(events, newJumperEvent(JumperAddedEvent, a.load, a.jumper, now))
+	}
+	for _, k := range removedOrder {
+		if r, ok := removed[k]; ok {
+			events = append(events, newJumperEvent(JumperRemovedEvent, r.load, r.jumper, now))
+		}
+	}
+
+	return events
+}
@@ -0,0 +1,231 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package burble
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testJumperIDs are the Burble IDs of the jumpers in testLoad.
+var testJumperIDs = map[string]int64{"Alice": 1, "Bob": 2, "Carol": 3}
+
+// testLoad returns an Otter load with sport jumpers with the given names.
+func testLoad(id int64, callMinutes, slotsAvailable int64, names ...string) *Load {
+	l := &Load{
+		ID:             id,
+		AircraftName:   "Otter",
+		LoadNumber:     string(rune('0' + id)),
+		CallMinutes:    callMinutes,
+		SlotsAvailable: slotsAvailable,
+	}
+	for _, name := range names {
+		l.SportJumpers = append(l.SportJumpers, NewJumper(testJumperIDs[name], name, "Sport"))
+	}
+	return l
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		oldLoads []*Load
+		newLoads []*Load
+		want     []string
+	}{
+		{
+			name:     "no change",
+			oldLoads: []*Load{testLoad(1, 30, 5, "Alice")},
+			newLoads: []*Load{testLoad(1, 30, 5, "Alice")},
+			want:     nil,
+		},
+		{
+			name:     "load created with jumpers",
+			oldLoads: nil,
+			newLoads: []*Load{testLoad(1, 30, 5, "Bob", "Alice")},
+			want: []string{
+				"Otter 1 added",
+				"Alice added to Otter 1",
+				"Bob added to Otter 1",
+			},
+		},
+		{
+			name:     "load departed after its call",
+			oldLoads: []*Load{testLoad(1, 0, 5, "Alice")},
+			newLoads: nil,
+			want:     []string{"Otter 1 departed"},
+		},
+		{
+			name:     "load removed before its call",
+			oldLoads: []*Load{testLoad(1, 12, 5, "Alice")},
+			newLoads: nil,
+			want:     []string{"Otter 1 removed"},
+		},
+		{
+			name:     "jumper added and removed",
+			oldLoads: []*Load{testLoad(1, 30, 5, "Alice", "Bob")},
+			newLoads: []*Load{testLoad(1, 30, 5, "Alice", "Carol")},
+			want: []string{
+				"Carol added to Otter 1",
+				"Bob removed from Otter 1",
+			},
+		},
+		{
+			name: "jumper moved",
+			oldLoads: []*Load{
+				testLoad(1, 20, 5, "Alice", "Bob"),
+				testLoad(2, 40, 5, "Carol"),
+			},
+			newLoads: []*Load{
+				testLoad(1, 20, 6, "Alice"),
+				testLoad(2, 40, 4, "Bob", "Carol"),
+			},
+			want: []string{
+				"6 slots open on Otter 1",
+				"Bob moved from Otter 1 to Otter 2",
+			},
+		},
+		{
+			name: "jumper moved to a new load",
+			oldLoads: []*Load{
+				testLoad(1, 20, 5, "Alice", "Bob"),
+			},
+			newLoads: []*Load{
+				testLoad(1, 20, 5, "Alice"),
+				testLoad(2, 40, 5, "Bob"),
+			},
+			want: []string{
+				"Otter 2 added",
+				"Bob moved from Otter 1 to Otter 2",
+			},
+		},
+		{
+			name:     "call time threshold crossed",
+			oldLoads: []*Load{testLoad(1, 16, 5)},
+			newLoads: []*Load{testLoad(1, 15, 5)},
+			want:     []string{"15 minute call for Otter 1"},
+		},
+		{
+			name:     "only the last of several thresholds crossed",
+			oldLoads: []*Load{testLoad(1, 21, 5)},
+			newLoads: []*Load{testLoad(1, 9, 5)},
+			want:     []string{"10 minute call for Otter 1"},
+		},
+		{
+			name:     "call time not crossing a threshold",
+			oldLoads: []*Load{testLoad(1, 14, 5)},
+			newLoads: []*Load{testLoad(1, 11, 5)},
+			want:     nil,
+		},
+		{
+			name:     "slots filled",
+			oldLoads: []*Load{testLoad(1, 30, 5)},
+			newLoads: []*Load{testLoad(1, 30, 4, "Alice")},
+			want:     []string{"Alice added to Otter 1"},
+		},
+		{
+			name:     "one slot opened",
+			oldLoads: []*Load{testLoad(1, 30, 0, "Alice")},
+			newLoads: []*Load{testLoad(1, 30, 1)},
+			want: []string{
+				"1 slot open on Otter 1",
+				"Alice removed from Otter 1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+			events := Diff(tt.oldLoads, tt.newLoads, now)
+			var got []string
+			for _, e := range events {
+				if !e.Time.Equal(now) {
+					t.Errorf("event %q has time %v, want %v", e, e.Time, now)
+				}
+				got = append(got, e.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffIgnoresNoTimeLoads(t *testing.T) {
+	oldLoad := testLoad(1, 30, 5)
+	oldLoad.IsNoTime = true
+	newLoad := testLoad(1, 5, 5)
+	if events := Diff([]*Load{oldLoad}, []*Load{newLoad}, time.Now()); len(events) != 0 {
+		t.Errorf("Diff() = %v, want no events for a load without a call time", events)
+	}
+}
+
+func TestDiffIgnoresHeadings(t *testing.T) {
+	newLoad := testLoad(1, 30, 5, "Alice")
+	heading := NewJumper(0, "FUN JUMPERS", "")
+	heading.IsHeading = true
+	newLoad.SportJumpers = append([]*Jumper{heading}, newLoad.SportJumpers...)
+
+	events := Diff([]*Load{testLoad(1, 30, 5, "Alice")}, []*Load{newLoad}, time.Now())
+	if len(events) != 0 {
+		t.Errorf("Diff() = %v, want no events for a heading", events)
+	}
+}
+
+func TestDiffGroupMembers(t *testing.T) {
+	oldLoad := testLoad(1, 30, 5, "Alice")
+	newLoad := testLoad(1, 30, 5, "Alice")
+	newLoad.SportJumpers[0].AddGroupMember(NewJumper(testJumperIDs["Bob"], "Bob", "Sport"))
+
+	var got []string
+	for _, e := range Diff([]*Load{oldLoad}, []*Load{newLoad}, time.Now()) {
+		got = append(got, e.String())
+	}
+	if want := []string{"Bob added to Otter 1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %q, want %q", got, want)
+	}
+}
+
+func TestDiffJumperIdentity(t *testing.T) {
+	jumpers := func(jumpers ...*Jumper) *Load {
+		l := testLoad(1, 30, 5)
+		l.SportJumpers = jumpers
+		return l
+	}
+	tests := []struct {
+		name    string
+		oldLoad *Load
+		newLoad *Load
+		want    []string
+	}{
+		{
+			name:    "jumper renamed",
+			oldLoad: jumpers(NewJumper(7, "Alex", "Sport")),
+			newLoad: jumpers(NewJumper(7, "Alexander", "Sport")),
+			want:    nil,
+		},
+		{
+			name:    "jumpers with the same name",
+			oldLoad: jumpers(NewJumper(7, "Alex Smith", "Sport")),
+			newLoad: jumpers(NewJumper(7, "Alex Smith", "Sport"), NewJumper(8, "Alex Smith", "Sport")),
+			want:    []string{"Alex Smith added to Otter 1"},
+		},
+		{
+			name:    "jumpers without IDs",
+			oldLoad: jumpers(NewJumper(0, "Alice", "Sport")),
+			newLoad: jumpers(NewJumper(0, "Alice", "Sport"), NewJumper(0, "Bob", "Sport")),
+			want:    []string{"Bob added to Otter 1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, e := range Diff([]*Load{tt.oldLoad}, []*Load{tt.newLoad}, time.Now()) {
+				got = append(got, e.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
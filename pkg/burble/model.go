@@ -21,6 +21,18 @@ type Jumper struct {
 	IsTurning      bool      `json:"is_turning"`
 	IsPondSwoop    bool      `json:"is_pond_swoop"`
 	IsLowPull      bool      `json:"is_low_pull"`
+	IsHeading      bool      `json:"is_heading"` // not a jumper; heads a jump type group
+
+	// StudentProgram and AFFLevel are set for students. AFFLevel is 0 if
+	// the level is not known.
+	StudentProgram StudentProgram `json:"student_program,omitempty"`
+	AFFLevel       int            `json:"aff_level,omitempty"`
+
+	// Display attributes assigned by Rules
+	Prefix        string `json:"prefix,omitempty"`
+	Icon          string `json:"icon,omitempty"`
+	Color         uint32 `json:"color,omitempty"` // 0 for the default color
+	HideShortName bool   `json:"hide_short_name,omitempty"`
 }
 
 func NewJumper(id int64, name, shortName string) *Jumper {
@@ -33,21 +45,6 @@ func NewJumper(id int64, name, shortName string) *Jumper {
 	if strings.HasPrefix(strings.ToLower(j.Name), "jm ") {
 		j.Name = strings.TrimSpace(j.Name[3:])
 	}
-	jump := strings.ToLower(j.ShortName)
-	if jump == "vs" {
-		j.ShortName = "Video"
-		j.IsVideographer = true
-	}
-
-	if strings.HasSuffix(j.ShortName, " H/P") {
-		j.IsLowPull = true
-	}
-	if strings.HasPrefix(j.ShortName, "3-5k") {
-		j.IsLowPull = true
-	}
-	if strings.HasPrefix(j.ShortName, "3.5k") {
-		j.IsLowPull = true
-	}
 
 	return j
 }
@@ -0,0 +1,201 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package burble
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/decode"
+)
+
+// It would be nicer to unmarshal Burble's data directly into these structs,
+// but Burble returns JSON data that makes that impossible. Sometimes fields
+// are ints as strings, sometimes they're ints, for empty loads, it's an empty
+// array instead of null or an empty map, etc. So the data is unmarshaled
+// into an interface{} and then decoded field by field.
+
+// payloadMember is a single member of a group as returned by Burble.
+type payloadMember struct {
+	ID                int64
+	Name              string
+	Jump              string
+	HandycamJump      string
+	GroupNumber       string
+	FormationTypeName string
+	RigName           string
+	RigID             string
+	Type              string
+	IsPublic          bool
+	IsPrivate         bool
+}
+
+// payloadLoad is a single load as returned by Burble.
+type payloadLoad struct {
+	ID           int64
+	Name         string
+	AircraftName string
+	HasIsPublic  bool
+	IsPublic     bool
+	IsFueling    bool
+	IsTurning    bool
+	TimeLeft     int64
+	MaxSlots     int64
+	ReserveSlots int64
+
+	// Groups is a list of groups, each of which has at least one member.
+	// The first member of each group is its primary jumper.
+	Groups [][]payloadMember
+}
+
+// payload is the data returned by Burble for a manifest request.
+type payload struct {
+	Loads []payloadLoad
+}
+
+func decodeMember(d *decode.Decoder, raw interface{}, field string) (payloadMember, bool) {
+	var m payloadMember
+
+	mark := d.Mark()
+	obj, ok := d.Object(raw, field)
+	if !ok {
+		return m, false
+	}
+
+	prefix := d.Prefix
+	d.Prefix = prefix + field + "."
+	defer func() { d.Prefix = prefix }()
+
+	m.ID = d.OptionalInt(obj, "id")
+	m.Name = d.String(obj, "name")
+	m.Jump = d.OptionalString(obj, "jump")
+	m.HandycamJump = d.OptionalString(obj, "handycam_jump")
+	m.GroupNumber = d.OptionalString(obj, "group_number")
+	m.FormationTypeName = d.OptionalString(obj, "formation_type_name")
+	m.RigName = d.OptionalString(obj, "rig_name")
+	m.RigID = d.OptionalString(obj, "rig_id")
+	m.Type = d.OptionalString(obj, "type")
+	m.IsPublic = d.Bool(obj, "is_public")
+	m.IsPrivate = d.Bool(obj, "is_private")
+
+	return m, !d.Failed(mark)
+}
+
+func decodeLoad(d *decode.Decoder, raw interface{}, index int) (payloadLoad, bool) {
+	var l payloadLoad
+
+	d.ID = 0
+	mark := d.Mark()
+	obj, ok := d.Object(raw, fmt.Sprintf("loads[%d]", index))
+	if !ok {
+		return l, false
+	}
+
+	// Decode the ID first so that it's attached to all other errors
+	d.ID = d.Int(obj, "id")
+	l.ID = d.ID
+
+	l.Name = d.String(obj, "name")
+	l.AircraftName = d.OptionalString(obj, "aircraft_name")
+	if _, ok = obj["is_public"]; ok {
+		l.HasIsPublic = true
+		l.IsPublic = d.Bool(obj, "is_public")
+	}
+	l.IsFueling = d.Bool(obj, "is_fueling")
+	l.IsTurning = d.Bool(obj, "is_turning")
+	l.TimeLeft = d.Int(obj, "time_left")
+	l.MaxSlots = d.OptionalInt(obj, "max_slots")
+	l.ReserveSlots = d.OptionalInt(obj, "reserve_slots")
+
+	groups, _ := d.Array(obj["groups"], "groups")
+	for gx, rawGroup := range groups {
+		groupField := fmt.Sprintf("groups[%d]", gx)
+		members, ok := d.Array(rawGroup, groupField)
+		if !ok {
+			continue
+		}
+		if len(members) == 0 {
+			d.Fail(groupField, rawGroup, errors.New("group has no members"))
+			continue
+		}
+
+		group := make([]payloadMember, 0, len(members))
+		for mx, rawMember := range members {
+			memberField := fmt.Sprintf("%s[%d]", groupField, mx)
+			if m, ok := decodeMember(d, rawMember, memberField); ok {
+				group = append(group, m)
+			}
+		}
+		l.Groups = append(l.Groups, group)
+	}
+
+	return l, !d.Failed(mark)
+}
+
+// decodePayload decodes raw data returned from Burble. A load that fails to
+// decode is omitted from the payload, and the reasons why are included in
+// the returned errors. An error is only returned if the data as a whole is
+// unusable.
+func decodePayload(data []byte) (*payload, []decode.FieldError, error) {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, nil, err
+	}
+
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, nil, errors.New("Burble data is not an object")
+	}
+	rawLoads, ok := obj["loads"]
+	if !ok {
+		return nil, nil, errors.New("Burble data is missing load information")
+	}
+
+	d := &decode.Decoder{}
+	loads, ok := d.Array(rawLoads, "loads")
+	if !ok {
+		return nil, nil, errors.New("Burble load information is not an array")
+	}
+
+	p := &payload{}
+	for i, rawLoad := range loads {
+		if l, ok := decodeLoad(d, rawLoad, i); ok {
+			p.Loads = append(p.Loads, l)
+		}
+	}
+
+	return p, d.Errors, nil
+}
+
+// reportDecodeErrors records the errors from decoding the most recent data
+// from Burble. Since the same data is typically returned on every refresh,
+// errors are only logged when they change.
+func (c *Controller) reportDecodeErrors(errs []decode.FieldError) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if reflect.DeepEqual(c.decodeErrors, errs) {
+		return
+	}
+	c.decodeErrors = errs
+
+	skipped := make(map[int64]struct{})
+	for _, err := range errs {
+		skipped[err.ID] = struct{}{}
+		fmt.Fprintf(os.Stderr, "Burble load %v\n", err)
+	}
+	if len(skipped) > 0 {
+		fmt.Fprintf(os.Stderr, "Skipped %d Burble load(s) with errors\n", len(skipped))
+	}
+}
+
+// DecodeErrors returns the errors encountered decoding the most recent data
+// from Burble. Each load with errors is omitted from Loads.
+func (c *Controller) DecodeErrors() []decode.FieldError {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.decodeErrors
+}
@@ -0,0 +1,113 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package burble
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodePayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		loads   []int64  // IDs of the loads decoded
+		errs    []string // fields with errors
+		wantErr bool
+	}{
+		{
+			name:  "no loads",
+			data:  `{"loads": []}`,
+			loads: nil,
+		},
+		{
+			name:  "loads as empty object",
+			data:  `{"loads": {}}`,
+			loads: nil,
+		},
+		{
+			name: "loosely typed fields",
+			data: `{"loads": [{"id": "101", "name": "Otter 1", "aircraft_name": "Otter",
+				"is_fueling": "0", "is_turning": 1, "time_left": "15", "max_slots": 22,
+				"groups": [[{"id": 1, "name": "Alice", "is_public": "true"}]]}]}`,
+			loads: []int64{101},
+		},
+		{
+			name: "malformed load is skipped",
+			data: `{"loads": [
+				{"id": 101, "name": "Otter 1", "time_left": 15, "groups": []},
+				{"id": 102, "name": "Otter 2", "time_left": "soon", "groups": []},
+				{"id": 103, "name": "Otter 3", "time_left": 30, "groups": {}}]}`,
+			loads: []int64{101, 103},
+			errs:  []string{"time_left"},
+		},
+		{
+			name: "malformed member skips its load",
+			data: `{"loads": [{"id": 101, "name": "Otter 1", "time_left": 15,
+				"groups": [[{"id": 1}]]}]}`,
+			errs: []string{"groups[0][0].name"},
+		},
+		{
+			name: "empty group skips its load",
+			data: `{"loads": [{"id": 101, "name": "Otter 1", "time_left": 15,
+				"groups": [[]]}]}`,
+			errs: []string{"groups[0]"},
+		},
+		{
+			name: "load name without load number",
+			data: `{"loads": [{"id": 101, "name": "Otter", "aircraft_name": "Otter",
+				"time_left": 15, "groups": []}]}`,
+			loads: []int64{101},
+		},
+		{
+			name: "fractional time left",
+			data: `{"loads": [{"id": 101, "name": "Otter 1", "time_left": 15.5, "groups": []}]}`,
+			errs: []string{"time_left"},
+		},
+		{
+			name:    "not an object",
+			data:    `[]`,
+			wantErr: true,
+		},
+		{
+			name:    "missing loads",
+			data:    `{}`,
+			wantErr: true,
+		},
+		{
+			name:    "loads not an array",
+			data:    `{"loads": "none"}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid JSON",
+			data:    `{"loads": [`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, decodeErrors, err := decodePayload([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodePayload error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			var loads []int64
+			for _, l := range p.Loads {
+				loads = append(loads, l.ID)
+			}
+			if !reflect.DeepEqual(loads, tt.loads) {
+				t.Errorf("decoded loads %v, want %v", loads, tt.loads)
+			}
+			var errs []string
+			for _, err := range decodeErrors {
+				errs = append(errs, err.Field)
+			}
+			if !reflect.DeepEqual(errs, tt.errs) {
+				t.Errorf("errors in %q, want %q", errs, tt.errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package burble
+
+import (
+	"regexp"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/settings"
+)
+
+// RuleAttributes are the attributes of a jumper that rules match against.
+type RuleAttributes struct {
+	JumpType      string
+	GroupName     string
+	FormationType string
+	MemberType    string
+
+	// StudentProgram is the name of the jumper's student program, if any.
+	StudentProgram string
+}
+
+type compiledRule struct {
+	jumpType       *regexp.Regexp
+	groupName      *regexp.Regexp
+	formationType  *regexp.Regexp
+	memberType     *regexp.Regexp
+	studentProgram *regexp.Regexp
+	color          uint32
+	rule           settings.JumperRule
+}
+
+// Rules classify jumpers according to settings.JumperRule.
+type Rules []compiledRule
+
+// CompileRules prepares rules for use. Rules that cannot be compiled are
+// ignored, but the first error encountered is returned.
+func CompileRules(rules []settings.JumperRule) (Rules, error) {
+	var (
+		compiled Rules
+		firstErr error
+	)
+	for _, r := range rules {
+		c, err := compileRule(r)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		compiled = append(compiled, c)
+	}
+	return compiled, firstErr
+}
+
+func compileRule(r settings.JumperRule) (compiledRule, error) {
+	c := compiledRule{rule: r}
+	if err := r.Validate(); err != nil {
+		return c, err
+	}
+	c.jumpType, _ = settings.CompileRulePattern(r.Match.JumpType)
+	c.groupName, _ = settings.CompileRulePattern(r.Match.GroupName)
+	c.formationType, _ = settings.CompileRulePattern(r.Match.FormationType)
+	c.memberType, _ = settings.CompileRulePattern(r.Match.MemberType)
+	c.studentProgram, _ = settings.CompileRulePattern(r.Match.StudentProgram)
+	if r.Color != "" {
+		c.color, _ = settings.ParseColor(r.Color)
+	}
+	return c, nil
+}
+
+func patternMatches(re *regexp.Regexp, s string) bool {
+	return re == nil || re.MatchString(s)
+}
+
+func (c *compiledRule) matches(attrs RuleAttributes) bool {
+	return patternMatches(c.jumpType, attrs.JumpType) &&
+		patternMatches(c.groupName, attrs.GroupName) &&
+		patternMatches(c.formationType, attrs.FormationType) &&
+		patternMatches(c.memberType, attrs.MemberType) &&
+		patternMatches(c.studentProgram, attrs.StudentProgram)
+}
+
+// Apply classifies j according to every rule that matches attrs.
+func (rules Rules) Apply(j *Jumper, attrs RuleAttributes) {
+	for i := range rules {
+		c := &rules[i]
+		if !c.matches(attrs) {
+			continue
+		}
+		for _, category := range c.rule.Categories {
+			switch category {
+			case "videographer":
+				j.IsVideographer = true
+			case "low_pull":
+				j.IsLowPull = true
+			case "pond_swoop":
+				j.IsPondSwoop = true
+			case "organizer":
+				j.IsOrganizer = true
+			case "student":
+				j.IsStudent = true
+			}
+		}
+		if c.rule.StudentProgram != "" {
+			if err := j.StudentProgram.UnmarshalText([]byte(c.rule.StudentProgram)); err == nil {
+				j.AFFLevel = 0
+				attrs.StudentProgram = c.rule.StudentProgram
+			}
+		}
+		if c.rule.ShortName != "" {
+			j.ShortName = c.rule.ShortName
+		}
+		if c.rule.HideShortName {
+			j.HideShortName = true
+		}
+		if c.rule.Prefix != "" {
+			j.Prefix = c.rule.Prefix
+		}
+		if c.rule.Icon != "" {
+			j.Icon = c.rule.Icon
+		}
+		if c.rule.Color != "" {
+			j.Color = c.color
+		}
+	}
+}
+
+// ApplyToLoad classifies every jumper on a load. The member type of each
+// jumper is inferred from the list that it is in, and the program of each
+// student is derived from the jump type unless it is already set.
+func (rules Rules) ApplyToLoad(l *Load) {
+	apply := func(jumpers []*Jumper, memberType string) {
+		for _, j := range jumpers {
+			f := func(j *Jumper) {
+				if !j.IsHeading {
+					rules.Apply(j, RuleAttributes{
+						JumpType:   j.ShortName,
+						GroupName:  j.GroupName,
+						MemberType: memberType,
+					})
+				}
+			}
+			if memberType == "Student" {
+				j.IsStudent = true
+				if j.StudentProgram == UnknownStudentProgram {
+					j.StudentProgram, j.AFFLevel = ParseStudentProgram(j.ShortName)
+				}
+				rules.Apply(j, RuleAttributes{
+					JumpType:       j.ShortName,
+					GroupName:      j.GroupName,
+					MemberType:     memberType,
+					StudentProgram: j.StudentProgram.String(),
+				})
+				j.ForEachGroupMember(f)
+				j.inferStudentProgram()
+				continue
+			}
+			f(j)
+			j.ForEachGroupMember(f)
+		}
+	}
+	apply(l.Tandems, "Tandem")
+	apply(l.Students, "Student")
+	apply(l.SportJumpers, "Sport Jumper")
+}
@@ -0,0 +1,320 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package burble
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/settings"
+)
+
+// testSettings returns the default settings, saving state to a temporary
+// directory.
+func testSettings(t *testing.T) *settings.Settings {
+	t.Helper()
+	return testSettingsWithConfig(t, "")
+}
+
+// testSettingsWithConfig returns settings read from config, saving state to
+// a temporary directory.
+func testSettingsWithConfig(t *testing.T, config string) *settings.Settings {
+	t.Helper()
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "config.yaml")
+	config = "options_file: " + filepath.Join(dir, "options.json") + "\n" +
+		"jumper_rules_file: " + filepath.Join(dir, "jumper_rules.json") + "\n" +
+		config
+	if err := os.WriteFile(filename, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s, err := settings.NewSettingsWithFilename(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// defaultRules returns the rules used when none are configured.
+func defaultRules(t *testing.T) Rules {
+	t.Helper()
+	rules, err := CompileRules(testSettings(t).JumperRules())
+	if err != nil {
+		t.Fatalf("default rules: %v", err)
+	}
+	return rules
+}
+
+func TestCompileRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    settings.JumperRule
+		wantErr bool
+	}{
+		{"empty", settings.JumperRule{}, false},
+		{"valid", settings.JumperRule{
+			Match:          settings.JumperRuleMatch{JumpType: "^vs$"},
+			Categories:     []string{"videographer"},
+			Color:          "#00ffff",
+			StudentProgram: "coach",
+		}, false},
+		{"invalid pattern", settings.JumperRule{Match: settings.JumperRuleMatch{GroupName: "("}}, true},
+		{"unrecognized category", settings.JumperRule{Categories: []string{"wingsuit"}}, true},
+		{"unrecognized student program", settings.JumperRule{StudentProgram: "tandem"}, true},
+		{"invalid color", settings.JumperRule{Color: "blue"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// An invalid rule is skipped, but the rules around it are kept.
+			rules, err := CompileRules([]settings.JumperRule{{}, tt.rule, {}})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CompileRules error = %v, want error %v", err, tt.wantErr)
+			}
+			want := 3
+			if tt.wantErr {
+				want = 2
+			}
+			if len(rules) != want {
+				t.Errorf("compiled %d rules, want %d", len(rules), want)
+			}
+		})
+	}
+}
+
+func TestRulesApply(t *testing.T) {
+	rules, err := CompileRules([]settings.JumperRule{
+		{
+			Match:      settings.JumperRuleMatch{JumpType: "^fun"},
+			Categories: []string{"organizer"},
+			Prefix:     "Fun",
+			Color:      "#ff0000",
+		},
+		{
+			Match:      settings.JumperRuleMatch{JumpType: "^fun", GroupName: "^tuesday"},
+			Categories: []string{"low_pull"},
+			Prefix:     "Tuesday",
+		},
+		{
+			Match:          settings.JumperRuleMatch{MemberType: "^Student$", JumpType: "^ground"},
+			StudentProgram: "coach",
+		},
+		{
+			Match:     settings.JumperRuleMatch{StudentProgram: "^coach$"},
+			ShortName: "Coach",
+			Icon:      "🎓",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		attrs RuleAttributes
+		want  Jumper
+	}{
+		{
+			name:  "no match",
+			attrs: RuleAttributes{JumpType: "Sport"},
+			want:  Jumper{ShortName: "Sport"},
+		},
+		{
+			name:  "case insensitive match",
+			attrs: RuleAttributes{JumpType: "FUN JUMP"},
+			want:  Jumper{ShortName: "FUN JUMP", IsOrganizer: true, Prefix: "Fun", Color: 0xff0000},
+		},
+		{
+			name:  "later rules take precedence",
+			attrs: RuleAttributes{JumpType: "Fun Jump", GroupName: "Tuesday Fun"},
+			want: Jumper{ShortName: "Fun Jump", IsOrganizer: true, IsLowPull: true,
+				Prefix: "Tuesday", Color: 0xff0000},
+		},
+		{
+			name:  "student program matched after it is set",
+			attrs: RuleAttributes{JumpType: "Ground School", MemberType: "Student"},
+			want:  Jumper{ShortName: "Coach", StudentProgram: CoachStudentProgram, Icon: "🎓"},
+		},
+		{
+			name:  "student program matched",
+			attrs: RuleAttributes{JumpType: "Coach 1", MemberType: "Student", StudentProgram: "coach"},
+			want:  Jumper{ShortName: "Coach", Icon: "🎓"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Jumper{ShortName: tt.attrs.JumpType, AFFLevel: 4}
+			tt.want.AFFLevel = 4
+			if tt.want.StudentProgram != UnknownStudentProgram {
+				tt.want.AFFLevel = 0
+			}
+			rules.Apply(j, tt.attrs)
+			if !reflect.DeepEqual(*j, tt.want) {
+				t.Errorf("Apply() = %+v, want %+v", *j, tt.want)
+			}
+		})
+	}
+}
+
+// TestDefaultRules checks that the default rules classify jumpers as they
+// were classified before rules were configurable.
+func TestDefaultRules(t *testing.T) {
+	rules := defaultRules(t)
+
+	tests := []struct {
+		name  string
+		attrs RuleAttributes
+		want  Jumper
+	}{
+		{
+			name:  "sport jumper",
+			attrs: RuleAttributes{JumpType: "Sport", MemberType: "Sport Jumper"},
+			want:  Jumper{ShortName: "Sport"},
+		},
+		{
+			name:  "videographer",
+			attrs: RuleAttributes{JumpType: "VS", MemberType: "Sport Jumper"},
+			want:  Jumper{ShortName: "Video", IsVideographer: true},
+		},
+		{
+			name:  "hop and pop",
+			attrs: RuleAttributes{JumpType: "Sport H/P", MemberType: "Sport Jumper"},
+			want:  Jumper{ShortName: "Sport H/P", IsLowPull: true},
+		},
+		{
+			// Patterns ignore case, which the fixed checks did not.
+			name:  "lower case hop and pop",
+			attrs: RuleAttributes{JumpType: "Sport h/p", MemberType: "Sport Jumper"},
+			want:  Jumper{ShortName: "Sport h/p", IsLowPull: true},
+		},
+		{
+			name:  "upper case low pull",
+			attrs: RuleAttributes{JumpType: "3-5K", MemberType: "Sport Jumper"},
+			want:  Jumper{ShortName: "3-5K", IsLowPull: true, Prefix: "H&P", Color: 0xff00ff},
+		},
+		{
+			name:  "low pull",
+			attrs: RuleAttributes{JumpType: "3-5k", MemberType: "Sport Jumper"},
+			want:  Jumper{ShortName: "3-5k", IsLowPull: true, Prefix: "H&P", Color: 0xff00ff},
+		},
+		{
+			name:  "low pull pond swoop",
+			attrs: RuleAttributes{JumpType: "3.5k", MemberType: "Sport Jumper", FormationType: "Pond Swoop"},
+			want: Jumper{ShortName: "3.5k", IsLowPull: true, IsPondSwoop: true,
+				Prefix: "H&P", Icon: "🏄", Color: 0x00ffff},
+		},
+		{
+			name:  "pond swoop",
+			attrs: RuleAttributes{JumpType: "Sport", MemberType: "Sport Jumper", FormationType: "pond swoop"},
+			want:  Jumper{ShortName: "Sport", IsPondSwoop: true, Icon: "🏄", Color: 0x00ffff},
+		},
+		{
+			name:  "rental gear",
+			attrs: RuleAttributes{JumpType: "Sport + Gear", MemberType: "Sport Jumper"},
+			want:  Jumper{ShortName: "Sport + Gear", Color: 0x00ff00},
+		},
+		{
+			name:  "AFF student",
+			attrs: RuleAttributes{JumpType: "AFF 3", MemberType: "Student", StudentProgram: "aff"},
+			want:  Jumper{ShortName: "AFF 3", Color: 0x00ff00},
+		},
+		{
+			name:  "hop and pop student",
+			attrs: RuleAttributes{JumpType: "3.5k H/P", MemberType: "Student", StudentProgram: "hop_and_pop"},
+			want:  Jumper{ShortName: "3.5k H/P", IsLowPull: true, Prefix: "H&P", Color: 0x00ff00},
+		},
+		{
+			name:  "low pull student",
+			attrs: RuleAttributes{JumpType: "3-5k", MemberType: "Student"},
+			want:  Jumper{ShortName: "3-5k", IsLowPull: true, Color: 0x00ff00},
+		},
+		{
+			name:  "pond swoop student",
+			attrs: RuleAttributes{JumpType: "AFF 7", MemberType: "Student", StudentProgram: "aff", FormationType: "Pond Swoop"},
+			want:  Jumper{ShortName: "AFF 7", IsPondSwoop: true, Icon: "🏄", Color: 0x00ff00},
+		},
+		{
+			name:  "tandem",
+			attrs: RuleAttributes{JumpType: "Tandem 10k", MemberType: "Tandem"},
+			want:  Jumper{ShortName: "Tandem 10k", Prefix: "Tandem", HideShortName: true, Color: 0xffff00},
+		},
+		{
+			name:  "pond swoop tandem",
+			attrs: RuleAttributes{JumpType: "Tandem 10k", MemberType: "Tandem", FormationType: "Pond Swoop"},
+			want: Jumper{ShortName: "Tandem 10k", IsPondSwoop: true, Prefix: "Tandem",
+				HideShortName: true, Icon: "🏄", Color: 0xffff00},
+		},
+
+		// Students in these programs were green before programs were
+		// distinguished.
+		{
+			name:  "coach student",
+			attrs: RuleAttributes{JumpType: "Coach 2", MemberType: "Student", StudentProgram: "coach"},
+			want:  Jumper{ShortName: "Coach 2", Color: 0xff8000},
+		},
+		{
+			name:  "IAD student",
+			attrs: RuleAttributes{JumpType: "IAD", MemberType: "Student", StudentProgram: "iad"},
+			want:  Jumper{ShortName: "IAD", Color: 0xc080ff},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Jumper{ShortName: tt.attrs.JumpType}
+			rules.Apply(j, tt.attrs)
+			if !reflect.DeepEqual(*j, tt.want) {
+				t.Errorf("Apply() = %+v, want %+v", *j, tt.want)
+			}
+		})
+	}
+}
+
+func TestRulesApplyToLoad(t *testing.T) {
+	rules, err := CompileRules([]settings.JumperRule{
+		{Match: settings.JumperRuleMatch{MemberType: "^Tandem$"}, Prefix: "Tandem"},
+		{Match: settings.JumperRuleMatch{MemberType: "^Student$"}, Prefix: "Student"},
+		{Match: settings.JumperRuleMatch{MemberType: "^Sport Jumper$"}, Prefix: "Sport"},
+		{Match: settings.JumperRuleMatch{StudentProgram: "^aff$"}, Icon: "🎓"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	student := NewJumper(1, "Alice", "AFF 4")
+	instructor := NewJumper(2, "Bob", "AFF I")
+	instructor.IsInstructor = true
+	student.AddGroupMember(instructor)
+	heading := NewJumper(0, "FUN JUMPERS", "")
+	heading.IsHeading = true
+	l := &Load{
+		Tandems:      []*Jumper{NewJumper(3, "Carol", "Tandem")},
+		Students:     []*Jumper{student},
+		SportJumpers: []*Jumper{heading, NewJumper(4, "Dave", "Sport")},
+	}
+	rules.ApplyToLoad(l)
+
+	tests := []struct {
+		name   string
+		jumper *Jumper
+		prefix string
+		icon   string
+	}{
+		{"tandem", l.Tandems[0], "Tandem", ""},
+		{"student", student, "Student", "🎓"},
+		{"student group member", instructor, "Student", ""},
+		{"heading", heading, "", ""},
+		{"sport jumper", l.SportJumpers[1], "Sport", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.jumper.Prefix != tt.prefix || tt.jumper.Icon != tt.icon {
+				t.Errorf("prefix %q and icon %q, want %q and %q",
+					tt.jumper.Prefix, tt.jumper.Icon, tt.prefix, tt.icon)
+			}
+		})
+	}
+	if !student.IsStudent || student.StudentProgram != AFFStudentProgram || student.AFFLevel != 4 {
+		t.Errorf("student %+v, want an AFF level 4 student", student)
+	}
+}
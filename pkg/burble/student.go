@@ -0,0 +1,88 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package burble
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// StudentProgram is the training program of a student's jump, which
+// determines the instructors that the student needs.
+type StudentProgram int
+
+const (
+	UnknownStudentProgram   StudentProgram = iota
+	AFFStudentProgram                      // Accelerated Freefall
+	CoachStudentProgram                    // coached jump
+	IADStudentProgram                      // instructor assisted deployment or static line
+	HopAndPopStudentProgram                // hop and pop
+)
+
+var studentProgramNames = []string{"", "aff", "coach", "iad", "hop_and_pop"}
+
+func (p StudentProgram) String() string {
+	if p < 0 || int(p) >= len(studentProgramNames) {
+		return strconv.Itoa(int(p))
+	}
+	return studentProgramNames[p]
+}
+
+func (p StudentProgram) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *StudentProgram) UnmarshalText(text []byte) error {
+	for i, name := range studentProgramNames {
+		if string(text) == name {
+			*p = StudentProgram(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unrecognized student program %q", text)
+}
+
+var (
+	affJumpRegexp       = regexp.MustCompile(`(?i)\baff\b\D*(\d+)?`)
+	iadJumpRegexp       = regexp.MustCompile(`(?i)\b(iad|s/l|static ?line)\b`)
+	coachJumpRegexp     = regexp.MustCompile(`(?i)\bcoach`)
+	hopAndPopJumpRegexp = regexp.MustCompile(`(?i)(\bh/p\b|\bh&p\b|\bhop\b)`)
+)
+
+// ParseStudentProgram derives a student's program, and for AFF, the level,
+// from the Burble jump type.
+func ParseStudentProgram(jumpType string) (StudentProgram, int) {
+	if m := affJumpRegexp.FindStringSubmatch(jumpType); m != nil {
+		level, _ := strconv.Atoi(m[1])
+		return AFFStudentProgram, level
+	}
+	switch {
+	case iadJumpRegexp.MatchString(jumpType):
+		return IADStudentProgram, 0
+	case coachJumpRegexp.MatchString(jumpType):
+		return CoachStudentProgram, 0
+	case hopAndPopJumpRegexp.MatchString(jumpType):
+		return HopAndPopStudentProgram, 0
+	}
+	return UnknownStudentProgram, 0
+}
+
+// inferStudentProgram infers the program of a student whose jump type does
+// not identify it from the student's group. A student with two or more
+// instructors must be on an AFF jump, since no other program uses more than
+// one. This must be called after group members have been added.
+func (j *Jumper) inferStudentProgram() {
+	if j.StudentProgram != UnknownStudentProgram {
+		return
+	}
+	instructors := 0
+	for _, member := range j.GroupMembers {
+		if member.IsInstructor {
+			instructors++
+		}
+	}
+	if instructors >= 2 {
+		j.StudentProgram = AFFStudentProgram
+	}
+}
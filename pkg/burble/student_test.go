@@ -0,0 +1,89 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package burble
+
+import "testing"
+
+func TestParseStudentProgram(t *testing.T) {
+	tests := []struct {
+		jumpType string
+		program  StudentProgram
+		level    int
+	}{
+		{"AFF 3", AFFStudentProgram, 3},
+		{"aff level 7", AFFStudentProgram, 7},
+		{"AFF-5 Recurrency", AFFStudentProgram, 5},
+		{"AFF", AFFStudentProgram, 0},
+		{"AFF I", AFFStudentProgram, 0},
+		{"IAD 2", IADStudentProgram, 0},
+		{"S/L", IADStudentProgram, 0},
+		{"Static Line", IADStudentProgram, 0},
+		{"staticline", IADStudentProgram, 0},
+		{"Coach 3", CoachStudentProgram, 0},
+		{"Coached Jump", CoachStudentProgram, 0},
+		{"3.5k H/P", HopAndPopStudentProgram, 0},
+		{"Solo H&P", HopAndPopStudentProgram, 0},
+		{"Hop", HopAndPopStudentProgram, 0},
+		{"Tandem", UnknownStudentProgram, 0},
+		{"Daffy", UnknownStudentProgram, 0},
+		{"Shopping", UnknownStudentProgram, 0},
+		{"", UnknownStudentProgram, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.jumpType, func(t *testing.T) {
+			program, level := ParseStudentProgram(tt.jumpType)
+			if program != tt.program || level != tt.level {
+				t.Errorf("ParseStudentProgram(%q) = %v, %d, want %v, %d",
+					tt.jumpType, program, level, tt.program, tt.level)
+			}
+		})
+	}
+}
+
+func TestStudentProgramText(t *testing.T) {
+	for _, p := range []StudentProgram{UnknownStudentProgram, AFFStudentProgram,
+		CoachStudentProgram, IADStudentProgram, HopAndPopStudentProgram} {
+		text, err := p.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got StudentProgram
+		if err = got.UnmarshalText(text); err != nil || got != p {
+			t.Errorf("UnmarshalText(%q) = %v, %v, want %v", text, got, err, p)
+		}
+	}
+
+	var p StudentProgram
+	if err := p.UnmarshalText([]byte("tandem")); err == nil {
+		t.Error("UnmarshalText accepted an unrecognized program")
+	}
+}
+
+func TestInferStudentProgram(t *testing.T) {
+	tests := []struct {
+		name        string
+		program     StudentProgram
+		instructors int
+		want        StudentProgram
+	}{
+		{"two instructors", UnknownStudentProgram, 2, AFFStudentProgram},
+		{"one instructor", UnknownStudentProgram, 1, UnknownStudentProgram},
+		{"known program", CoachStudentProgram, 2, CoachStudentProgram},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := NewJumper(1, "Alice", "Student")
+			j.StudentProgram = tt.program
+			for i := 0; i < tt.instructors; i++ {
+				instructor := NewJumper(int64(i+2), "Instructor", "")
+				instructor.IsInstructor = true
+				j.AddGroupMember(instructor)
+			}
+			j.AddGroupMember(NewJumper(9, "Video", "VS"))
+			j.inferStudentProgram()
+			if j.StudentProgram != tt.want {
+				t.Errorf("program %v, want %v", j.StudentProgram, tt.want)
+			}
+		})
+	}
+}
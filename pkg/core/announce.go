@@ -0,0 +1,58 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package core
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/announce"
+)
+
+// Announcer returns the load call announcement controller, or nil if
+// announcements are disabled.
+func (c *Controller) Announcer() *announce.Controller {
+	return c.announcer
+}
+
+func (c *Controller) announceLoads() {
+	if c.announcer == nil {
+		return
+	}
+	announcements := c.announcer.Update(c.manifestSource.Loads(), c.manifestSource.Now())
+	if len(announcements) > 0 {
+		c.publishAnnouncements(announcements)
+	}
+}
+
+// AddAnnouncementListener registers a channel to receive load call
+// announcements. Announcements are delivered without blocking, so the
+// channel should be buffered.
+func (c *Controller) AddAnnouncementListener(l chan []announce.Announcement) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.listenerID++
+	id := c.listenerID
+	c.announcementListeners[id] = l
+	return id
+}
+
+func (c *Controller) RemoveAnnouncementListener(id int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.announcementListeners, id)
+}
+
+func (c *Controller) publishAnnouncements(announcements []announce.Announcement) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for id, l := range c.announcementListeners {
+		select {
+		case l <- announcements:
+		default:
+			fmt.Fprintf(os.Stderr, "Dropped %d announcements for slow listener %d\n",
+				len(announcements), id)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package core
+
+import (
+	"context"
+	"sync"
+)
+
+// AllDataSources subscribes to every DataSource.
+const AllDataSources = ^DataSource(0)
+
+// BusStats are counters describing the activity of a Bus.
+type BusStats struct {
+	Subscribers int    `json:"subscribers"`
+	Published   uint64 `json:"published"` // calls to Publish
+	Delivered   uint64 `json:"delivered"` // notifications taken by subscribers
+	Coalesced   uint64 `json:"coalesced"` // notifications merged into one already pending
+	Dropped     uint64 `json:"dropped"`   // publications that no subscriber wanted
+}
+
+// Bus notifies subscribers that data sources have changed. Publishers never
+// block: each subscriber accumulates the DataSource bits published since it
+// last took them, so a slow subscriber sees several changes coalesced into a
+// single notification rather than holding up everyone else.
+type Bus struct {
+	lock        sync.Mutex
+	nextID      int
+	subscribers map[int]*Subscription
+	stats       BusStats
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		subscribers: make(map[int]*Subscription),
+	}
+}
+
+// Subscription receives notifications for a set of topics from a Bus.
+type Subscription struct {
+	bus    *Bus
+	id     int
+	topics DataSource
+	ready  chan struct{}
+
+	// done is closed by Unsubscribe, which releases the goroutine that
+	// waits for the subscription's context.
+	done     chan struct{}
+	doneOnce sync.Once
+
+	lock    sync.Mutex
+	pending DataSource
+}
+
+// Subscribe subscribes to the data sources in topics. The subscription is
+// cancelled when ctx is done or Unsubscribe is called.
+func (b *Bus) Subscribe(ctx context.Context, topics DataSource) *Subscription {
+	b.lock.Lock()
+	b.nextID++
+	s := &Subscription{
+		bus:    b,
+		id:     b.nextID,
+		topics: topics,
+		ready:  make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+	b.subscribers[s.id] = s
+	b.stats.Subscribers = len(b.subscribers)
+	b.lock.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.Unsubscribe()
+		case <-s.done:
+		}
+	}()
+	return s
+}
+
+// Publish notifies subscribers that source has changed. It never blocks.
+func (b *Bus) Publish(source DataSource) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.stats.Published++
+	wanted := false
+	for _, s := range b.subscribers {
+		bits := source & s.topics
+		if bits == 0 {
+			continue
+		}
+		wanted = true
+
+		s.lock.Lock()
+		if s.pending != 0 {
+			b.stats.Coalesced++
+		}
+		s.pending |= bits
+		s.lock.Unlock()
+
+		select {
+		case s.ready <- struct{}{}:
+		default:
+		}
+	}
+	if !wanted {
+		b.stats.Dropped++
+	}
+}
+
+// Stats returns the bus's counters.
+func (b *Bus) Stats() BusStats {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.stats
+}
+
+// Ready returns a channel that receives a value when notifications are
+// pending. Call Take to retrieve them.
+func (s *Subscription) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// Take returns and clears the DataSource bits published since it was last
+// called. It returns 0 if there are none.
+func (s *Subscription) Take() DataSource {
+	s.lock.Lock()
+	source := s.pending
+	s.pending = 0
+	s.lock.Unlock()
+
+	if source != 0 {
+		s.bus.lock.Lock()
+		s.bus.stats.Delivered++
+		s.bus.lock.Unlock()
+	}
+	return source
+}
+
+// Unsubscribe cancels the subscription. It is safe to call more than once.
+func (s *Subscription) Unsubscribe() {
+	s.doneOnce.Do(func() { close(s.done) })
+
+	s.bus.lock.Lock()
+	defer s.bus.lock.Unlock()
+	delete(s.bus.subscribers, s.id)
+	s.bus.stats.Subscribers = len(s.bus.subscribers)
+}
@@ -0,0 +1,158 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package core
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestBusCoalescing(t *testing.T) {
+	tests := []struct {
+		name      string
+		topics    []DataSource // of each subscriber
+		published []DataSource
+		want      []DataSource // taken by each subscriber
+		stats     BusStats
+	}{
+		{
+			name:      "single publication",
+			topics:    []DataSource{AllDataSources},
+			published: []DataSource{BurbleDataSource},
+			want:      []DataSource{BurbleDataSource},
+			stats:     BusStats{Subscribers: 1, Published: 1, Delivered: 1},
+		},
+		{
+			name:      "publications coalesced",
+			topics:    []DataSource{AllDataSources},
+			published: []DataSource{BurbleDataSource, METARDataSource, BurbleDataSource},
+			want:      []DataSource{BurbleDataSource | METARDataSource},
+			stats:     BusStats{Subscribers: 1, Published: 3, Delivered: 1, Coalesced: 2},
+		},
+		{
+			name:      "topics filtered",
+			topics:    []DataSource{BurbleDataSource, METARDataSource | WindsAloftDataSource},
+			published: []DataSource{BurbleDataSource | WindsAloftDataSource, OptionsDataSource},
+			want:      []DataSource{BurbleDataSource, WindsAloftDataSource},
+			stats:     BusStats{Subscribers: 2, Published: 2, Delivered: 2, Dropped: 1},
+		},
+		{
+			name:      "nothing pending",
+			topics:    []DataSource{BurbleDataSource},
+			published: []DataSource{METARDataSource},
+			want:      []DataSource{0},
+			stats:     BusStats{Subscribers: 1, Published: 1, Dropped: 1},
+		},
+		{
+			name:      "no subscribers",
+			published: []DataSource{BurbleDataSource},
+			stats:     BusStats{Published: 1, Dropped: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			b := NewBus()
+			var subs []*Subscription
+			for _, topics := range tt.topics {
+				subs = append(subs, b.Subscribe(ctx, topics))
+			}
+			for _, source := range tt.published {
+				b.Publish(source)
+			}
+			for i, s := range subs {
+				if tt.want[i] != 0 {
+					select {
+					case <-s.Ready():
+					default:
+						t.Errorf("subscriber %d not ready", i)
+					}
+				}
+				if got := s.Take(); got != tt.want[i] {
+					t.Errorf("subscriber %d took %#x, want %#x", i, got, tt.want[i])
+				}
+				if got := s.Take(); got != 0 {
+					t.Errorf("subscriber %d took %#x again, want 0", i, got)
+				}
+			}
+			if got := b.Stats(); got != tt.stats {
+				t.Errorf("Stats() = %+v, want %+v", got, tt.stats)
+			}
+		})
+	}
+}
+
+func TestBusPublishDoesNotBlock(t *testing.T) {
+	b := NewBus()
+	s := b.Subscribe(context.Background(), AllDataSources)
+	defer s.Unsubscribe()
+
+	// Nobody is receiving from Ready, which holds one notification.
+	for i := 0; i < 100; i++ {
+		b.Publish(BurbleDataSource)
+	}
+	<-s.Ready()
+	select {
+	case <-s.Ready():
+		t.Error("Ready signalled more than once for coalesced publications")
+	default:
+	}
+	if got := s.Take(); got != BurbleDataSource {
+		t.Errorf("Take() = %#x, want %#x", got, BurbleDataSource)
+	}
+}
+
+func TestBusUnsubscribe(t *testing.T) {
+	b := NewBus()
+	ctx, cancel := context.WithCancel(context.Background())
+	s1 := b.Subscribe(ctx, AllDataSources)
+	s2 := b.Subscribe(context.Background(), AllDataSources)
+
+	s2.Unsubscribe()
+	s2.Unsubscribe()
+	if got := b.Stats().Subscribers; got != 1 {
+		t.Fatalf("%d subscribers after Unsubscribe, want 1", got)
+	}
+
+	cancel()
+	deadline := time.Now().Add(5 * time.Second)
+	for b.Stats().Subscribers != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("subscription not cancelled with its context")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	b.Publish(BurbleDataSource)
+	if got := s1.Take(); got != 0 {
+		t.Errorf("cancelled subscription took %#x, want 0", got)
+	}
+	if got := b.Stats().Dropped; got != 1 {
+		t.Errorf("Dropped = %d, want 1", got)
+	}
+}
+
+func TestBusUnsubscribeReleasesGoroutine(t *testing.T) {
+	b := NewBus()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	before := runtime.NumGoroutine()
+	for i := 0; i < 100; i++ {
+		b.Subscribe(ctx, AllDataSources).Unsubscribe()
+	}
+
+	// The goroutines exit asynchronously, so allow them a moment.
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d goroutines after Unsubscribe, want %d",
+				runtime.NumGoroutine(), before)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
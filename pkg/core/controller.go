@@ -15,6 +15,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/jumptown-skydiving/manifest-server/pkg/announce"
 	"github.com/jumptown-skydiving/manifest-server/pkg/burble"
 	"github.com/jumptown-skydiving/manifest-server/pkg/db"
 	"github.com/jumptown-skydiving/manifest-server/pkg/jumprun"
@@ -37,6 +38,8 @@ const (
 	SunriseDataSource               = 1 << 6
 	PreSunsetDataSource             = 1 << 7 // Fires once per minute for an hour prior to sunset
 	SunsetDataSource                = 1 << 8
+	FuelDataSource                  = 1 << 9
+	HealthDataSource                = 1 << 10 // A data source was refreshed, successfully or not
 )
 
 type Controller struct {
@@ -44,26 +47,46 @@ type Controller struct {
 
 	db               db.Connection
 	location         *time.Location
-	burbleSource     *burble.Controller
+	manifestSource   ManifestSource
+	announcer        *announce.Controller
+	fuelRequests     []*db.FuelRequest
 	jumprun          *jumprun.Controller
 	metarSource      *metar.Controller
 	windsAloftSource *winds.Controller
+	sourceHealth     []*sourceHealthTracker
 
 	siwa *siwa.Manager
 
-	settings   *settings.Settings
-	listeners  map[int]chan DataSource
-	listenerID int
-	done       chan struct{}
-	wg         sync.WaitGroup
+	// siwaWeb signs users in to the web pages, if it is configured.
+	siwaWeb *siwa.Manager
+
+	settings              *settings.Settings
+	bus                   *Bus
+	eventListeners        map[int]chan []burble.Event
+	announcementListeners map[int]chan []announce.Announcement
+	listenerID            int
+	done                  chan struct{}
+	supervisor            *Supervisor
+
+	eventMutex sync.Mutex
+	lastLoads  []*burble.Load
+	loadsSeen  bool
+
+	// loadsRecorded is when the loads were last recorded in the load
+	// history.
+	historyMutex  sync.Mutex
+	loadsRecorded time.Time
 }
 
 func NewController(settings *settings.Settings) (*Controller, error) {
 	c := &Controller{
-		settings:  settings,
-		listeners: make(map[int]chan DataSource),
-		done:      make(chan struct{}),
+		settings:              settings,
+		bus:                   NewBus(),
+		eventListeners:        make(map[int]chan []burble.Event),
+		announcementListeners: make(map[int]chan []announce.Announcement),
+		done:                  make(chan struct{}),
 	}
+	c.supervisor = NewSupervisor(c.done)
 
 	var err error
 	c.siwa, err = settings.NewSignInWithAppleManager()
@@ -73,53 +96,77 @@ func NewController(settings *settings.Settings) (*Controller, error) {
 	if c.siwa != nil {
 		c.siwa.SetDelegate(c)
 	}
+	c.siwaWeb, err = settings.NewSignInWithAppleWebManager()
+	if err != nil {
+		return nil, err
+	}
+	if c.siwaWeb != nil {
+		c.siwaWeb.SetDelegate(c)
+	}
 
 	c.db, err = db.Connect(settings)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to initialize database: %w", err)
 	}
 
+	if err = c.loadFuelRequests(); err != nil {
+		return nil, fmt.Errorf("Failed to load fuel requests: %w", err)
+	}
+
 	loc, err := settings.Location()
 	if err != nil {
 		return nil, fmt.Errorf("Invalid timezone: %w", err)
 	}
 	c.location = loc
 
-	c.burbleSource = burble.NewController(c.settings)
-	c.launchDataSource(
-		func() time.Time { return time.Now().Add(10 * time.Second) },
-		"Burble",
-		c.burbleSource.Refresh,
-		func() { c.WakeListeners(BurbleDataSource) })
+	if c.settings.AnnouncementsEnabled() {
+		c.announcer = announce.NewController(c.settings)
+	}
 
+	var sourceName string
+	c.manifestSource, sourceName, err = c.newManifestSource()
+	if err != nil {
+		return nil, err
+	}
 	if c.settings.METAREnabled() {
 		c.metarSource = metar.NewController(c.settings)
-		c.launchDataSource(
-			func() time.Time { return time.Now().Add(5 * time.Minute) },
-			"METAR",
-			c.metarSource.Refresh,
-			func() { c.WakeListeners(METARDataSource) })
 	}
-
 	if c.settings.WindsEnabled() {
 		c.windsAloftSource = winds.NewController(c.settings)
-		c.launchDataSource(
-			func() time.Time { return time.Now().Add(15 * time.Minute) },
-			"Winds Aloft",
-			c.windsAloftSource.Refresh,
-			func() { c.WakeListeners(WindsAloftDataSource) })
 	}
-
 	if c.settings.JumprunEnabled() {
 		c.jumprun = jumprun.NewController(c.settings,
-			func() { c.WakeListeners(JumprunDataSource) })
+			func() { c.Publish(JumprunDataSource) })
 	}
 
-	c.wg.Add(1)
-	go func() {
-		defer c.wg.Done()
-		c.runAtSunriseSunset()
-	}()
+	// Refresh schedules depend on the time of sunrise and sunset, which
+	// depend on all of the sources above, so don't start refreshing any
+	// of them until they all exist.
+	manifestHealth := c.newSourceHealth("manifest", sourceName, c.settings.ManifestStaleAfter())
+	if b, ok := c.manifestSource.(*burble.Controller); ok {
+		manifestHealth.decodeErrors = b.DecodeErrors
+	}
+	c.launchDataSource(
+		c.newRefreshSchedule(c.manifestSource, c.manifestRefreshInterval),
+		manifestHealth,
+		c.refreshManifest,
+		c.manifestUpdated)
+	if c.metarSource != nil {
+		c.launchDataSource(
+			c.newRefreshSchedule(c.metarSource, c.metarRefreshInterval),
+			c.newSourceHealth("metar", "METAR", c.settings.METARStaleAfter()),
+			c.metarSource.Refresh,
+			func() { c.Publish(METARDataSource) })
+	}
+	if c.windsAloftSource != nil {
+		c.launchDataSource(
+			c.newRefreshSchedule(c.windsAloftSource, c.windsRefreshInterval),
+			c.newSourceHealth("winds", "Winds Aloft", c.settings.WindsStaleAfter()),
+			c.windsAloftSource.Refresh,
+			func() { c.Publish(WindsAloftDataSource) })
+	}
+
+	c.supervisor.Go("Sunrise/Sunset", c.runAtSunriseSunset)
 
 	return c, nil
 }
@@ -130,10 +177,22 @@ func (c *Controller) Done() <-chan struct{} {
 
 func (c *Controller) Close() {
 	close(c.done)
-	c.wg.Wait()
+	c.supervisor.Wait()
 	c.db.Close()
 }
 
+// Go runs f in a background worker supervised along with the controller's
+// own, so that it is restarted if it panics. f must return once Done is
+// closed.
+func (c *Controller) Go(name string, f func()) {
+	c.supervisor.Go(name, f)
+}
+
+// Workers returns the status of the controller's background workers.
+func (c *Controller) Workers() []WorkerStatus {
+	return c.supervisor.Workers()
+}
+
 func (c *Controller) Settings() *settings.Settings {
 	return c.settings
 }
@@ -142,10 +201,6 @@ func (c *Controller) Location() *time.Location {
 	return c.location
 }
 
-func (c *Controller) BurbleSource() *burble.Controller {
-	return c.burbleSource
-}
-
 func (c *Controller) Jumprun() *jumprun.Controller {
 	return c.jumprun
 }
@@ -232,6 +287,12 @@ func (c *Controller) SeparationDelay(speed int) int {
 }
 
 func (c *Controller) SeparationStrings() (uint32, string) {
+	return c.SeparationStringsIn(metar.DefaultUnits)
+}
+
+// SeparationStringsIn returns the color and text describing exit separation,
+// with temperatures expressed in the specified units.
+func (c *Controller) SeparationStringsIn(units metar.Units) (uint32, string) {
 	windsAloftSource := c.WindsAloftSource()
 
 	color := uint32(0xffffff)
@@ -239,21 +300,28 @@ func (c *Controller) SeparationStrings() (uint32, string) {
 		return color, ""
 	}
 
-	// We're only interested in 13000 feet
-	samples := windsAloftSource.Samples()
-	if len(samples) < 14 {
+	// We're only interested in the primary aircraft's exit altitude
+	return c.separationStrings(c.settings.PrimaryAircraft(), windsAloftSource.Samples(), units)
+}
+
+// separationStrings returns the color and text describing exit separation
+// for aircraft, given the winds aloft samples at each thousand feet.
+func (c *Controller) separationStrings(aircraft settings.Aircraft, samples []winds.Sample, units metar.Units) (uint32, string) {
+	color := uint32(0xffffff)
+	index := (aircraft.ExitAltitude + 500) / 1000
+	if len(samples) <= index {
 		return color, ""
 	}
-	sample := samples[13]
+	sample := samples[index]
 
 	var (
 		str, t string
 		speed  int
 	)
 	if sample.LightAndVariable {
-		speed = 85
+		speed = aircraft.JumprunAirspeed
 	} else {
-		speed = 85 - sample.Speed
+		speed = aircraft.JumprunAirspeed - sample.Speed
 	}
 	if speed <= 0 {
 		color = 0xff0000
@@ -264,8 +332,7 @@ func (c *Controller) SeparationStrings() (uint32, string) {
 			c.SeparationDelay(speed))
 	}
 
-	t = fmt.Sprintf("(%d℃ / %d℉)", sample.Temperature,
-		int64(metar.FahrenheitFromCelsius(float64(sample.Temperature))))
+	t = fmt.Sprintf("(%s)", metar.FormatTemperature(float64(sample.Temperature), units))
 
 	if str != "" && t != "" {
 		return color, fmt.Sprintf("%s %s", str, t)
@@ -281,24 +348,29 @@ func (c *Controller) SeparationStrings() (uint32, string) {
 }
 
 func (c *Controller) launchDataSource(
-	nextRefresh func() time.Time,
-	sourceName string,
+	schedule *refreshSchedule,
+	health *sourceHealthTracker,
 	refresh func() (bool, error),
 	update func(),
 ) {
-	c.wg.Add(1)
-	go func() {
-		defer c.wg.Done()
+	c.supervisor.Go(health.name(), func() {
 		for {
-			if changed, err := refresh(); err != nil {
-				fmt.Fprintf(os.Stderr, "Error refreshing %s: %v\n", sourceName, err)
-			} else if changed {
+			attempt := time.Now()
+			changed, err := refresh()
+			if err == nil && changed {
 				update()
 			}
 
-			nextTime := nextRefresh()
+			nextTime := schedule.next(time.Now(), err)
+			health.record(attempt, err, schedule.interval(), nextTime)
+			c.Publish(HealthDataSource)
+
 			refreshPeriod := time.Until(nextTime)
-			t := time.NewTicker(refreshPeriod)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error refreshing %s: %v (retrying in %s)\n",
+					health.name(), err, refreshPeriod.Round(time.Second))
+			}
+			t := time.NewTimer(refreshPeriod)
 
 			select {
 			case <-c.Done():
@@ -309,7 +381,7 @@ func (c *Controller) launchDataSource(
 				break
 			}
 		}
-	}()
+	})
 }
 
 func (c *Controller) Coordinates() (latitude float64, longitude float64, err error) {
@@ -335,9 +407,11 @@ func (c *Controller) Coordinates() (latitude float64, longitude float64, err err
 			}
 		}
 	}
-	var ok bool
-	if latitude, longitude, ok = c.METARSource().Location(); ok {
-		return latitude, longitude, nil
+	if m := c.METARSource(); m != nil {
+		var ok bool
+		if latitude, longitude, ok = m.Location(); ok {
+			return latitude, longitude, nil
+		}
 	}
 	err = errors.New("location is unknown")
 	return
@@ -408,28 +482,20 @@ func (c *Controller) SunsetMessage() string {
 	return ""
 }
 
-func (c *Controller) AddListener(l chan DataSource) int {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-
-	c.listenerID++
-	id := c.listenerID
-	c.listeners[id] = l
-	return id
+// Bus returns the bus on which changes to data sources are published.
+func (c *Controller) Bus() *Bus {
+	return c.bus
 }
 
-func (c *Controller) RemoveListener(id int) {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	delete(c.listeners, id)
+// Subscribe subscribes to changes to the data sources in topics until ctx is
+// done.
+func (c *Controller) Subscribe(ctx context.Context, topics DataSource) *Subscription {
+	return c.bus.Subscribe(ctx, topics)
 }
 
-func (c *Controller) WakeListeners(source DataSource) {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	for _, l := range c.listeners {
-		l <- source
-	}
+// Publish notifies subscribers that source has changed.
+func (c *Controller) Publish(source DataSource) {
+	c.bus.Publish(source)
 }
 
 func (c *Controller) sunrise() {
@@ -446,24 +512,37 @@ func (c *Controller) sunrise() {
 			}
 		}
 	}
-	c.WakeListeners(SunriseDataSource)
+	c.Publish(SunriseDataSource)
 }
 
 func (c *Controller) sunset() {
-	c.WakeListeners(SunsetDataSource)
+	c.Publish(SunsetDataSource)
 }
 
 func (c *Controller) runAtSunriseSunset() {
 	lastPre := []int{-1, -1}
 	lastSunrise := []int{0, 0, 0}
 	lastSunset := []int{0, 0, 0}
+	lastErr := ""
 	t := time.NewTicker(1 * time.Second)
+	defer t.Stop()
 	for {
+		// The times may not be known until a data source supplies a
+		// location, so keep trying, but only log each error once.
 		sunrise, sunset, err := c.SunriseAndSunsetTimes()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "SunriseAndSunsetTimes ERROR: %v\n", err)
-			return
+			if err.Error() != lastErr {
+				fmt.Fprintf(os.Stderr, "SunriseAndSunsetTimes ERROR: %v\n", err)
+				lastErr = err.Error()
+			}
+			select {
+			case <-c.Done():
+				return
+			case <-t.C:
+			}
+			continue
 		}
+		lastErr = ""
 
 		now := c.CurrentTime()
 		if now.Equal(sunset) || now.After(sunset) {
@@ -476,7 +555,7 @@ func (c *Controller) runAtSunriseSunset() {
 		} else if sunset.After(now) && sunset.Sub(now).Hours() <= 1 {
 			thisPre := []int{now.Hour(), now.Minute()}
 			if !reflect.DeepEqual(lastPre, thisPre) {
-				c.WakeListeners(PreSunsetDataSource)
+				c.Publish(PreSunsetDataSource)
 				lastPre = thisPre
 			}
 		}
@@ -490,14 +569,13 @@ func (c *Controller) runAtSunriseSunset() {
 		} else if sunrise.After(now) && sunrise.Sub(now).Hours() <= 1 {
 			thisPre := []int{now.Hour(), now.Minute()}
 			if !reflect.DeepEqual(lastPre, thisPre) {
-				c.WakeListeners(PreSunriseDataSource)
+				c.Publish(PreSunriseDataSource)
 				lastPre = thisPre
 			}
 		}
 
 		select {
 		case <-c.Done():
-			t.Stop()
 			return
 		case <-t.C:
 		}
@@ -0,0 +1,99 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package core
+
+import (
+	"testing"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/metar"
+	"github.com/jumptown-skydiving/manifest-server/pkg/settings"
+	"github.com/jumptown-skydiving/manifest-server/pkg/winds"
+)
+
+func TestSeparationStrings(t *testing.T) {
+	// samples returns winds at each thousand feet up to 14,000 feet, with
+	// the given speed and temperature at altitude.
+	samples := func(altitude, speed, temperature int) []winds.Sample {
+		s := make([]winds.Sample, 15)
+		for i := range s {
+			s[i].Altitude = i * 1000
+			s[i].LightAndVariable = true
+		}
+		s[altitude/1000] = winds.Sample{
+			Altitude:         altitude,
+			Speed:            speed,
+			Temperature:      temperature,
+			LightAndVariable: speed <= 0,
+		}
+		return s
+	}
+	otter := settings.Aircraft{Name: "Otter", JumprunAirspeed: 85, ExitAltitude: 13000}
+
+	tests := []struct {
+		name      string
+		aircraft  settings.Aircraft
+		samples   []winds.Sample
+		units     metar.Units
+		wantColor uint32
+		want      string
+	}{
+		{
+			name:      "headwind",
+			aircraft:  otter,
+			samples:   samples(13000, 20, -5),
+			wantColor: 0xffffff,
+			want:      "Separation is 10 seconds (-5℃ / 23℉)",
+		},
+		{
+			name:      "light and variable",
+			aircraft:  otter,
+			samples:   samples(13000, 0, -5),
+			wantColor: 0xffffff,
+			want:      "Separation is 7 seconds (-5℃ / 23℉)",
+		},
+		{
+			name:      "winds faster than jumprun",
+			aircraft:  otter,
+			samples:   samples(13000, 90, -5),
+			wantColor: 0xff0000,
+			want:      "Winds are 90 knots (-5℃ / 23℉)",
+		},
+		{
+			name:      "units",
+			aircraft:  otter,
+			samples:   samples(13000, 20, -5),
+			units:     metar.ImperialUnits,
+			wantColor: 0xffffff,
+			want:      "Separation is 10 seconds (23℉)",
+		},
+		{
+			name:      "exit altitude rounded",
+			aircraft:  settings.Aircraft{JumprunAirspeed: 85, ExitAltitude: 10400},
+			samples:   samples(10000, 20, 0),
+			wantColor: 0xffffff,
+			want:      "Separation is 10 seconds (0℃ / 32℉)",
+		},
+		{
+			name:      "exit altitude above samples",
+			aircraft:  settings.Aircraft{JumprunAirspeed: 85, ExitAltitude: 18000},
+			samples:   samples(13000, 20, -5),
+			wantColor: 0xffffff,
+		},
+	}
+	c := &Controller{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			color, got := c.separationStrings(tt.aircraft, tt.samples, tt.units)
+			if color != tt.wantColor || got != tt.want {
+				t.Errorf("separationStrings() = %#x, %q, want %#x, %q", color, got, tt.wantColor, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeparationStringsWithoutWinds(t *testing.T) {
+	c := testController(t)
+	if color, got := c.SeparationStringsIn(metar.DefaultUnits); color != 0xffffff || got != "" {
+		t.Errorf("SeparationStringsIn() = %#x, %q, want white and empty", color, got)
+	}
+}
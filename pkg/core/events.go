@@ -0,0 +1,65 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package core
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/burble"
+)
+
+// diffLoads compares the loads currently reported by the manifest source with
+// those from the previous update and publishes the resulting events. Nothing
+// is published for the first set of loads seen, since every load would
+// otherwise be reported as newly created. All of the loads are compared, not
+// only those displayed, so that loads scrolling into or out of the display
+// are not reported as created or removed.
+func (c *Controller) diffLoads() {
+	c.eventMutex.Lock()
+	defer c.eventMutex.Unlock()
+
+	loads := c.manifestSource.AllLoads()
+	if !c.loadsSeen {
+		c.lastLoads = loads
+		c.loadsSeen = true
+		return
+	}
+
+	events := burble.Diff(c.lastLoads, loads, c.manifestSource.Now())
+	c.lastLoads = loads
+	if len(events) > 0 {
+		c.publishEvents(events)
+	}
+}
+
+// AddEventListener registers a channel to receive manifest change events.
+// Events are delivered without blocking, so the channel should be buffered.
+func (c *Controller) AddEventListener(l chan []burble.Event) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.listenerID++
+	id := c.listenerID
+	c.eventListeners[id] = l
+	return id
+}
+
+func (c *Controller) RemoveEventListener(id int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.eventListeners, id)
+}
+
+func (c *Controller) publishEvents(events []burble.Event) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for id, l := range c.eventListeners {
+		select {
+		case l <- events:
+		default:
+			fmt.Fprintf(os.Stderr, "Dropped %d manifest events for slow listener %d\n",
+				len(events), id)
+		}
+	}
+}
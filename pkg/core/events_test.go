@@ -0,0 +1,103 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package core
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/burble"
+)
+
+// testManifestSource is a ManifestSource whose loads are set by tests.
+type testManifestSource struct {
+	loads, allLoads []*burble.Load
+}
+
+func (s *testManifestSource) Refresh() (bool, error)     { return false, nil }
+func (s *testManifestSource) Loads() []*burble.Load      { return s.loads }
+func (s *testManifestSource) AllLoads() []*burble.Load   { return s.allLoads }
+func (s *testManifestSource) ColumnCount() int           { return len(s.loads) }
+func (s *testManifestSource) Health() (time.Time, error) { return time.Time{}, nil }
+func (s *testManifestSource) Now() time.Time             { return time.Now() }
+func (s *testManifestSource) setLoads(loads, allLoads []*burble.Load) {
+	s.loads, s.allLoads = loads, allLoads
+}
+
+// testEventLoad returns an Otter load with a jumper named for the load.
+func testEventLoad(id, callMinutes int64) *burble.Load {
+	l := &burble.Load{
+		ID:             id,
+		AircraftName:   "Otter",
+		LoadNumber:     string(rune('0' + id)),
+		CallMinutes:    callMinutes,
+		SlotsAvailable: 5,
+	}
+	l.SportJumpers = []*burble.Jumper{burble.NewJumper(id, "Jumper "+l.LoadNumber, "Sport")}
+	return l
+}
+
+func TestDiffLoadsDisplayWindow(t *testing.T) {
+	l1, l2, l3 := testEventLoad(1, 2), testEventLoad(2, 30), testEventLoad(3, 50)
+	departed := testEventLoad(1, -1)
+	l4 := testEventLoad(4, 70)
+
+	tests := []struct {
+		name      string
+		displayed []*burble.Load
+		all       []*burble.Load
+		want      []string
+	}{
+		{
+			name:      "first loads",
+			displayed: []*burble.Load{l1, l2},
+			all:       []*burble.Load{l1, l2, l3},
+		},
+		{
+			name:      "load scrolls into the display",
+			displayed: []*burble.Load{l1, l2, l3},
+			all:       []*burble.Load{l1, l2, l3},
+		},
+		{
+			name:      "load scrolls out of the display",
+			displayed: []*burble.Load{l2, l3},
+			all:       []*burble.Load{departed, l2, l3},
+		},
+		{
+			name:      "fewer columns displayed",
+			displayed: []*burble.Load{l2},
+			all:       []*burble.Load{departed, l2, l3},
+		},
+		{
+			name:      "load leaves the manifest",
+			displayed: []*burble.Load{l2},
+			all:       []*burble.Load{l2, l3, l4},
+			want:      []string{"Otter 1 departed", "Otter 4 added", "Jumper 4 added to Otter 4"},
+		},
+	}
+
+	source := &testManifestSource{}
+	c := &Controller{
+		manifestSource: source,
+		eventListeners: make(map[int]chan []burble.Event),
+	}
+	events := make(chan []burble.Event, len(tests))
+	c.AddEventListener(events)
+	for _, tt := range tests {
+		source.setLoads(tt.displayed, tt.all)
+		c.diffLoads()
+
+		var got []string
+		select {
+		case published := <-events:
+			for _, e := range published {
+				got = append(got, e.String())
+			}
+		default:
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: events %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package core
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/db"
+)
+
+// FuelRequests returns the active fuel requests in the order in which they
+// were made.
+func (c *Controller) FuelRequests() []*db.FuelRequest {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.fuelRequests
+}
+
+// loadFuelRequests reloads the active fuel requests from the database.
+func (c *Controller) loadFuelRequests() error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	requests, err := c.db.QueryActiveFuelRequests(tx)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err = c.CommitDatabaseTransaction(tx); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	c.fuelRequests = requests
+	c.mutex.Unlock()
+	return nil
+}
+
+// updateFuelRequests performs a change to fuel requests in a transaction and
+// then notifies listeners of the change.
+func (c *Controller) updateFuelRequests(f func(tx *sql.Tx) error) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err = f(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err = c.CommitDatabaseTransaction(tx); err != nil {
+		return err
+	}
+
+	if err = c.loadFuelRequests(); err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot load fuel requests: %v\n", err)
+	}
+	c.Publish(FuelDataSource)
+	return nil
+}
+
+// RequestFuel creates a fuel request for an aircraft on behalf of user. If
+// the aircraft already has an active request, that request is returned
+// instead of creating another.
+func (c *Controller) RequestFuel(user *db.User, aircraftName, note string) (*db.FuelRequest, error) {
+	aircraftName = strings.TrimSpace(aircraftName)
+	if a, ok := c.settings.LookupAircraft(aircraftName); ok {
+		aircraftName = a.Name
+	} else if len(c.settings.Aircraft()) > 0 {
+		return nil, fmt.Errorf("unknown aircraft %q", aircraftName)
+	}
+	if aircraftName == "" {
+		return nil, errors.New("aircraft name is required")
+	}
+
+	var request *db.FuelRequest
+	err := c.updateFuelRequests(func(tx *sql.Tx) error {
+		var err error
+		request, err = c.db.CreateFuelRequest(tx, user, aircraftName,
+			strings.TrimSpace(note), time.Now())
+		return err
+	})
+	return request, err
+}
+
+// AcknowledgeFuelRequest records that user has seen a fuel request and will
+// handle it.
+func (c *Controller) AcknowledgeFuelRequest(user *db.User, id int64) error {
+	return c.updateFuelRequests(func(tx *sql.Tx) error {
+		return c.db.AcknowledgeFuelRequest(tx, id, user, time.Now())
+	})
+}
+
+// CompleteFuelRequest records that user has fueled the aircraft for a fuel
+// request, which is no longer active afterward.
+func (c *Controller) CompleteFuelRequest(user *db.User, id int64) error {
+	return c.updateFuelRequests(func(tx *sql.Tx) error {
+		return c.db.CompleteFuelRequest(tx, id, user, time.Now())
+	})
+}
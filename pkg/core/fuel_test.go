@@ -0,0 +1,53 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package core
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestRequestFuelConcurrently(t *testing.T) {
+	c, _ := testHistoryController(t)
+	tx, err := c.db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := c.db.CreateUser(tx, "pilot", "Test", "Pilot", "", false, true)
+	if err != nil {
+		_ = tx.Rollback()
+		t.Fatal(err)
+	}
+	if err = c.CommitDatabaseTransaction(tx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Pilots on the same aircraft all requesting fuel at once make only
+	// one request between them.
+	const pilots = 10
+	var wg sync.WaitGroup
+	ids := make([]int64, pilots)
+	for i := 0; i < pilots; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			request, err := c.RequestFuel(user, " Otter ", "")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			ids[i] = request.ID
+		}(i)
+	}
+	wg.Wait()
+
+	requests := c.FuelRequests()
+	if len(requests) != 1 {
+		t.Fatalf("%d active fuel requests, want 1", len(requests))
+	}
+	for _, id := range ids {
+		if id != requests[0].ID {
+			t.Errorf("RequestFuel() returned request %d, want %d", id, requests[0].ID)
+		}
+	}
+}
@@ -0,0 +1,328 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/decode"
+)
+
+// SourceHealth describes how well a data source is being refreshed.
+type SourceHealth struct {
+	ID   string `json:"id"`   // e.g. "manifest", "metar", "winds"
+	Name string `json:"name"` // e.g. "Burble", "METAR", "Winds Aloft"
+
+	LastAttempt         time.Time `json:"last_attempt"`
+	LastSuccess         time.Time `json:"last_success"`
+	LastError           string    `json:"last_error,omitempty"` // empty if the last attempt succeeded
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	NextRefresh         time.Time `json:"next_refresh"`
+
+	// DecodeErrors describes the fields of the most recent data that
+	// could not be decoded. The items containing them are omitted.
+	DecodeErrors []string `json:"decode_errors,omitempty"`
+
+	// StaleAfter is how long after the last success the source's data is
+	// considered to be stale. It is never less than twice the source's
+	// current refresh interval, so that a source that is deliberately
+	// refreshed slowly is not reported as stale.
+	StaleAfter time.Duration `json:"-"`
+}
+
+// DataAge returns how old the source's data is, or 0 if the source has never
+// been refreshed successfully.
+func (h SourceHealth) DataAge(now time.Time) time.Duration {
+	if h.LastSuccess.IsZero() {
+		return 0
+	}
+	return now.Sub(h.LastSuccess)
+}
+
+// IsStale returns true if the source has never been refreshed successfully,
+// or if its data is older than StaleAfter.
+func (h SourceHealth) IsStale(now time.Time) bool {
+	return h.LastSuccess.IsZero() || h.DataAge(now) > h.StaleAfter
+}
+
+// IsHealthy returns true if the source's data is current and its most recent
+// refresh succeeded.
+func (h SourceHealth) IsHealthy(now time.Time) bool {
+	return h.ConsecutiveFailures == 0 && !h.IsStale(now)
+}
+
+type sourceHealthTracker struct {
+	lock       sync.Mutex
+	health     SourceHealth
+	staleAfter time.Duration
+
+	// decodeErrors, if not nil, returns the errors decoding the source's
+	// most recent data.
+	decodeErrors func() []decode.FieldError
+}
+
+func (c *Controller) newSourceHealth(id, name string, staleAfter time.Duration) *sourceHealthTracker {
+	t := &sourceHealthTracker{
+		health: SourceHealth{
+			ID:         id,
+			Name:       name,
+			StaleAfter: staleAfter,
+		},
+		staleAfter: staleAfter,
+	}
+	c.sourceHealth = append(c.sourceHealth, t)
+	return t
+}
+
+func (t *sourceHealthTracker) name() string {
+	return t.health.Name
+}
+
+// record records the result of a refresh attempt.
+func (t *sourceHealthTracker) record(
+	attempt time.Time,
+	err error,
+	interval time.Duration,
+	nextRefresh time.Time,
+) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	h := &t.health
+	h.LastAttempt = attempt
+	if err != nil {
+		h.LastError = err.Error()
+		h.ConsecutiveFailures++
+	} else {
+		h.LastSuccess = attempt
+		h.LastError = ""
+		h.ConsecutiveFailures = 0
+	}
+	h.NextRefresh = nextRefresh
+	h.StaleAfter = t.staleAfter
+	if h.StaleAfter < 2*interval {
+		h.StaleAfter = 2 * interval
+	}
+}
+
+func (t *sourceHealthTracker) get() SourceHealth {
+	t.lock.Lock()
+	h := t.health
+	t.lock.Unlock()
+
+	if t.decodeErrors != nil {
+		for _, err := range t.decodeErrors() {
+			h.DecodeErrors = append(h.DecodeErrors, err.Error())
+		}
+	}
+	return h
+}
+
+// SourceHealth returns the health of each data source, in the order in which
+// the sources were launched.
+func (c *Controller) SourceHealth() []SourceHealth {
+	health := make([]SourceHealth, len(c.sourceHealth))
+	for i, t := range c.sourceHealth {
+		health[i] = t.get()
+	}
+	return health
+}
+
+// LookupSourceHealth returns the health of the data source with the given
+// ID.
+func (c *Controller) LookupSourceHealth(id string) (SourceHealth, bool) {
+	for _, t := range c.sourceHealth {
+		if h := t.get(); h.ID == id {
+			return h, true
+		}
+	}
+	return SourceHealth{}, false
+}
+
+// HealthHTML serves a page describing the health of each data source and
+// background worker, along with the update bus's counters. The response status is 503 if any source is unhealthy
+// or any worker is restarting, so that the page may be used by monitoring
+// tools. Adding "format=json" to the query returns the same information as
+// JSON.
+func (c *Controller) HealthHTML(w http.ResponseWriter, req *http.Request) {
+	now := time.Now()
+	health := c.SourceHealth()
+	workers := c.Workers()
+	status := http.StatusOK
+	for _, h := range health {
+		if !h.IsHealthy(now) {
+			status = http.StatusServiceUnavailable
+			break
+		}
+	}
+	for _, w := range workers {
+		if w.State == WorkerRestarting {
+			status = http.StatusServiceUnavailable
+			break
+		}
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	if req.URL.Query().Get("format") == "json" {
+		type jsonHealth struct {
+			SourceHealth
+			DataAge    int64 `json:"data_age"`    // seconds
+			StaleAfter int64 `json:"stale_after"` // seconds
+			IsStale    bool  `json:"is_stale"`
+			IsHealthy  bool  `json:"is_healthy"`
+		}
+		response := struct {
+			Sources []jsonHealth   `json:"sources"`
+			Workers []WorkerStatus `json:"workers"`
+			Bus     BusStats       `json:"bus"`
+		}{
+			Sources: make([]jsonHealth, len(health)),
+			Workers: workers,
+			Bus:     c.bus.Stats(),
+		}
+		for i, h := range health {
+			response.Sources[i] = jsonHealth{
+				SourceHealth: h,
+				DataAge:      int64(h.DataAge(now) / time.Second),
+				StaleAfter:   int64(h.StaleAfter / time.Second),
+				IsStale:      h.IsStale(now),
+				IsHealthy:    h.IsHealthy(now),
+			}
+		}
+		data, err := json.Marshal(response)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write(data)
+		return
+	}
+
+	type row struct {
+		SourceHealth
+		Healthy bool
+		Age     string
+	}
+	rows := make([]row, len(health))
+	for i, h := range health {
+		h.LastAttempt = h.LastAttempt.In(c.location)
+		h.LastSuccess = h.LastSuccess.In(c.location)
+		h.NextRefresh = h.NextRefresh.In(c.location)
+		rows[i] = row{
+			SourceHealth: h,
+			Healthy:      h.IsHealthy(now),
+		}
+		if !h.LastSuccess.IsZero() {
+			rows[i].Age = h.DataAge(now).Round(time.Second).String()
+		}
+	}
+
+	for i := range workers {
+		workers[i].Started = workers[i].Started.In(c.location)
+		workers[i].LastFailed = workers[i].LastFailed.In(c.location)
+		workers[i].NextRestart = workers[i].NextRestart.In(c.location)
+	}
+
+	data := struct {
+		Sources []row
+		Workers []WorkerStatus
+		Bus     BusStats
+	}{
+		Sources: rows,
+		Workers: workers,
+		Bus:     c.bus.Stats(),
+	}
+	b := &bytes.Buffer{}
+	if err := healthTemplate.Execute(b, &data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write(b.Bytes())
+}
+
+var healthTemplate = template.Must(template.New("health").Parse(healthHTML))
+
+const healthHTML = `<html>
+<head>
+	<title>Manifest Status</title>
+	<meta http-equiv="refresh" content="30">
+	<style>
+	td, th { padding: 4px 12px; text-align: left; }
+	.ok { color: green; }
+	.failed { color: red; }
+	</style>
+</head>
+<body>
+	<h3>Data Sources</h3>
+	<hr>
+	<table>
+		<tr>
+			<th>Source</th>
+			<th>Status</th>
+			<th>Data Age</th>
+			<th>Last Attempt</th>
+			<th>Last Success</th>
+			<th>Failures</th>
+			<th>Next Refresh</th>
+			<th>Last Error</th>
+			<th>Decode Errors</th>
+		</tr>
+		{{range .Sources}}
+		<tr>
+			<td>{{.Name}}</td>
+			<td>{{if .Healthy}}<span class="ok">OK</span>{{else}}<span class="failed">{{if .ConsecutiveFailures}}Failing{{else}}Stale{{end}}</span>{{end}}</td>
+			<td>{{if .Age}}{{.Age}}{{else}}never refreshed{{end}}</td>
+			<td>{{if not .LastAttempt.IsZero}}{{.LastAttempt.Format "15:04:05"}}{{end}}</td>
+			<td>{{if not .LastSuccess.IsZero}}{{.LastSuccess.Format "15:04:05"}}{{end}}</td>
+			<td>{{.ConsecutiveFailures}}</td>
+			<td>{{if not .NextRefresh.IsZero}}{{.NextRefresh.Format "15:04:05"}}{{end}}</td>
+			<td>{{.LastError}}</td>
+			<td>{{range .DecodeErrors}}{{.}}<br>{{end}}</td>
+		</tr>
+		{{end}}
+	</table>
+	<h3>Workers</h3>
+	<hr>
+	<table>
+		<tr>
+			<th>Worker</th>
+			<th>State</th>
+			<th>Started</th>
+			<th>Restarts</th>
+			<th>Last Failed</th>
+			<th>Next Restart</th>
+			<th>Last Failure</th>
+		</tr>
+		{{range .Workers}}
+		<tr>
+			<td>{{.Name}}</td>
+			<td><span class="{{if eq .State.String "restarting"}}failed{{else}}ok{{end}}">{{.State}}</span></td>
+			<td>{{.Started.Format "15:04:05"}}</td>
+			<td>{{.Restarts}}</td>
+			<td>{{if not .LastFailed.IsZero}}{{.LastFailed.Format "15:04:05"}}{{end}}</td>
+			<td>{{if not .NextRestart.IsZero}}{{.NextRestart.Format "15:04:05"}}{{end}}</td>
+			<td>{{.LastFailure}}</td>
+		</tr>
+		{{end}}
+	</table>
+	<h3>Update Bus</h3>
+	<hr>
+	<table>
+		<tr><th>Subscribers</th><td>{{.Bus.Subscribers}}</td></tr>
+		<tr><th>Published</th><td>{{.Bus.Published}}</td></tr>
+		<tr><th>Delivered</th><td>{{.Bus.Delivered}}</td></tr>
+		<tr><th>Coalesced</th><td>{{.Bus.Coalesced}}</td></tr>
+		<tr><th>Dropped</th><td>{{.Bus.Dropped}}</td></tr>
+	</table>
+</body>
+</html>
+`
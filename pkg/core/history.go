@@ -0,0 +1,120 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/burble"
+	"github.com/jumptown-skydiving/manifest-server/pkg/db"
+)
+
+// loadsSeenInterval is how often loads are recorded in the load history when
+// they have not changed, which keeps their last seen times current.
+const loadsSeenInterval = time.Minute
+
+func loadRecordJumpers(r *db.LoadRecord, j *burble.Jumper, leader string) {
+	if !j.IsHeading {
+		r.Jumpers = append(r.Jumpers, db.LoadJumperRecord{
+			ID:          j.ID,
+			Name:        j.Name,
+			ShortName:   j.ShortName,
+			RigName:     j.RigName,
+			GroupLeader: leader,
+		})
+	}
+	for _, member := range j.GroupMembers {
+		loadRecordJumpers(r, member, j.Name)
+	}
+}
+
+// recordLoads archives the loads currently reported by the manifest source
+// in the database. Replayed loads were archived when they were captured, and
+// are not archived again.
+func (c *Controller) recordLoads() {
+	if c.replaying() {
+		return
+	}
+	loads := c.manifestSource.Loads()
+	if len(loads) == 0 {
+		return
+	}
+
+	tx, err := c.db.Begin()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot record load history: %v\n", err)
+		return
+	}
+
+	now := c.manifestSource.Now()
+	for _, l := range loads {
+		r := db.LoadRecord{
+			ID:           l.ID,
+			AircraftName: l.AircraftName,
+			LoadNumber:   l.LoadNumber,
+			CallMinutes:  l.CallMinutes,
+		}
+		for _, jumpers := range [][]*burble.Jumper{l.Tandems, l.Students, l.SportJumpers} {
+			for _, j := range jumpers {
+				loadRecordJumpers(&r, j, "")
+			}
+		}
+		if err = c.db.RecordLoad(tx, &r, now); err != nil {
+			_ = tx.Rollback()
+			fmt.Fprintf(os.Stderr, "Cannot record load %d history: %v\n", l.ID, err)
+			return
+		}
+	}
+
+	if err = c.CommitDatabaseTransaction(tx); err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot record load history: %v\n", err)
+		return
+	}
+
+	c.historyMutex.Lock()
+	c.loadsRecorded = time.Now()
+	c.historyMutex.Unlock()
+}
+
+// refreshManifest refreshes the manifest source. Loads are recorded when
+// they change, but loads that do not change for a while are also recorded
+// again every loadsSeenInterval so that the history shows that they were
+// still on the manifest.
+func (c *Controller) refreshManifest() (bool, error) {
+	changed, err := c.manifestSource.Refresh()
+	if err != nil || changed {
+		return changed, err
+	}
+
+	c.historyMutex.Lock()
+	stale := time.Since(c.loadsRecorded) >= loadsSeenInterval
+	c.historyMutex.Unlock()
+	if stale {
+		c.recordLoads()
+	}
+	return false, nil
+}
+
+// manifestUpdated is called whenever the manifest source's loads change.
+func (c *Controller) manifestUpdated() {
+	c.recordLoads()
+	c.diffLoads()
+	c.announceLoads()
+	c.Publish(BurbleDataSource)
+}
+
+// QueryLoadHistory returns archived loads matching query, most recent first.
+func (c *Controller) QueryLoadHistory(query db.LoadQuery) ([]*db.LoadRecord, error) {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	loads, err := c.db.QueryLoads(tx, query)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	return loads, c.CommitDatabaseTransaction(tx)
+}
@@ -0,0 +1,89 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/burble"
+	"github.com/jumptown-skydiving/manifest-server/pkg/db"
+	"github.com/jumptown-skydiving/manifest-server/pkg/settings"
+)
+
+// testHistoryController returns a controller with a database and a manifest
+// source whose loads are set by the test.
+func testHistoryController(t *testing.T) (*Controller, *testManifestSource) {
+	t.Helper()
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "config.yaml")
+	config := "options_file: " + filepath.Join(dir, "options.json") + "\n" +
+		"jumper_rules_file: " + filepath.Join(dir, "jumper_rules.json") + "\n" +
+		"database:\n" +
+		"  driver: sqlite3\n" +
+		"  filename: " + filepath.Join(dir, "database.sqlite3") + "\n"
+	if err := os.WriteFile(filename, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s, err := settings.NewSettingsWithFilename(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := db.Connect(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(conn.Close)
+
+	source := &testManifestSource{}
+	return &Controller{settings: s, db: conn, bus: NewBus(), manifestSource: source}, source
+}
+
+func TestRefreshManifestRecordsUnchangedLoads(t *testing.T) {
+	c, source := testHistoryController(t)
+	loads := []*burble.Load{testEventLoad(1, 20)}
+	source.setLoads(loads, loads)
+
+	lastSeen := func() time.Time {
+		t.Helper()
+		records, err := c.QueryLoadHistory(db.LoadQuery{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(records) != 1 {
+			t.Fatalf("%d loads recorded, want 1", len(records))
+		}
+		return records[0].LastSeen
+	}
+
+	// The loads have not changed, but have not been recorded either.
+	if _, err := c.refreshManifest(); err != nil {
+		t.Fatal(err)
+	}
+	recorded := lastSeen()
+
+	// Unchanged loads are not recorded again on every refresh...
+	c.historyMutex.Lock()
+	c.loadsRecorded = time.Now().Add(-loadsSeenInterval / 2)
+	c.historyMutex.Unlock()
+	time.Sleep(time.Second)
+	if _, err := c.refreshManifest(); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastSeen(); !got.Equal(recorded) {
+		t.Errorf("last seen %v, want %v until the interval passes", got, recorded)
+	}
+
+	// ...but are once the interval passes, so that they are not stale.
+	c.historyMutex.Lock()
+	c.loadsRecorded = time.Now().Add(-loadsSeenInterval)
+	c.historyMutex.Unlock()
+	if _, err := c.refreshManifest(); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastSeen(); !got.After(recorded) {
+		t.Errorf("last seen %v, want after %v", got, recorded)
+	}
+}
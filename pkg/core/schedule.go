@@ -0,0 +1,135 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package core
+
+import (
+	"math/rand"
+	"time"
+)
+
+// refreshSchedule decides when a data source is next refreshed. Failing
+// sources back off exponentially, and every delay is randomly varied so that
+// refreshes do not happen in lockstep.
+type refreshSchedule struct {
+	// interval returns the delay between successful refreshes, which may
+	// depend on the current state of the world.
+	interval func() time.Duration
+
+	// notBefore returns the earliest time at which the upstream has asked
+	// to be queried again. It may be nil.
+	notBefore func() time.Time
+
+	maxBackoff time.Duration
+	jitter     float64
+
+	failures int
+	rand     *rand.Rand
+}
+
+// upstreamHint is implemented by data sources that track the refresh delays
+// requested by their upstream servers.
+type upstreamHint interface {
+	NotBefore() time.Time
+}
+
+func (c *Controller) newRefreshSchedule(
+	source interface{},
+	interval func() time.Duration,
+) *refreshSchedule {
+	s := &refreshSchedule{
+		interval:   interval,
+		maxBackoff: c.settings.RefreshMaxBackoff(),
+		jitter:     c.settings.RefreshJitter(),
+		rand:       rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	if h, ok := source.(upstreamHint); ok {
+		s.notBefore = h.NotBefore
+	}
+	return s
+}
+
+// next returns the time at which a source should next be refreshed, given
+// the result of the refresh that just completed.
+func (s *refreshSchedule) next(now time.Time, err error) time.Time {
+	d := s.interval()
+
+	// No source waits longer than the maximum backoff or its own
+	// interval, whichever is longer, even if its upstream asks it to.
+	limit := s.maxBackoff
+	if d > limit {
+		limit = d
+	}
+
+	if err != nil {
+		s.failures++
+		for i := 1; i < s.failures && d < limit; i++ {
+			d *= 2
+		}
+		if d > limit {
+			d = limit
+		}
+	} else {
+		s.failures = 0
+	}
+
+	if s.jitter > 0 {
+		d += time.Duration(float64(d) * s.jitter * (2*s.rand.Float64() - 1))
+	}
+
+	t := now.Add(d)
+	if s.notBefore != nil {
+		notBefore := s.notBefore()
+		if maxTime := now.Add(limit); notBefore.After(maxTime) {
+			notBefore = maxTime
+		}
+		if notBefore.After(t) {
+			t = notBefore
+		}
+	}
+	return t
+}
+
+// isNight returns true if the current time is between sunset and sunrise.
+// It returns false if the times of sunrise and sunset are not known.
+func (c *Controller) isNight() bool {
+	sunrise, sunset, err := c.SunriseAndSunsetTimes()
+	if err != nil {
+		return false
+	}
+	now := c.CurrentTime()
+	return now.Before(sunrise) || now.After(sunset)
+}
+
+// manifestRefreshInterval speeds up refreshes while a load is close to its
+// call, and slows them down while there are no loads on the manifest,
+// especially at night.
+func (c *Controller) manifestRefreshInterval() time.Duration {
+	loads := c.manifestSource.Loads()
+	nearCallMinutes := c.settings.ManifestRefreshNearCallMinutes()
+	for _, l := range loads {
+		if !l.IsNoTime && l.CallMinutes >= 0 && l.CallMinutes <= nearCallMinutes {
+			return c.settings.ManifestRefreshNearCallInterval()
+		}
+	}
+	switch {
+	case len(loads) > 0:
+		return c.settings.ManifestRefreshInterval()
+	case c.isNight():
+		return c.settings.ManifestRefreshNightInterval()
+	}
+	return c.settings.ManifestRefreshIdleInterval()
+}
+
+func (c *Controller) metarRefreshInterval() time.Duration {
+	if c.isNight() {
+		return c.settings.METARRefreshNightInterval()
+	}
+	return c.settings.METARRefreshInterval()
+}
+
+func (c *Controller) windsRefreshInterval() time.Duration {
+	if c.isNight() {
+		return c.settings.WindsRefreshNightInterval()
+	}
+	return c.settings.WindsRefreshInterval()
+}
@@ -0,0 +1,118 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package core
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestRefreshScheduleNext(t *testing.T) {
+	errFailed := errors.New("failed")
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		interval   time.Duration
+		maxBackoff time.Duration
+		notBefore  time.Duration // from now, or 0 for no hint
+		errs       []error       // results of successive refreshes
+		want       []time.Duration
+	}{
+		{
+			name:       "success",
+			interval:   time.Minute,
+			maxBackoff: 10 * time.Minute,
+			errs:       []error{nil, nil},
+			want:       []time.Duration{time.Minute, time.Minute},
+		},
+		{
+			name:       "failures back off to the limit",
+			interval:   time.Minute,
+			maxBackoff: 10 * time.Minute,
+			errs:       []error{errFailed, errFailed, errFailed, errFailed, errFailed, errFailed},
+			want: []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute,
+				8 * time.Minute, 10 * time.Minute, 10 * time.Minute},
+		},
+		{
+			name:       "success resets backoff",
+			interval:   time.Minute,
+			maxBackoff: 10 * time.Minute,
+			errs:       []error{errFailed, errFailed, nil, errFailed},
+			want:       []time.Duration{time.Minute, 2 * time.Minute, time.Minute, time.Minute},
+		},
+		{
+			name:       "interval longer than the maximum backoff",
+			interval:   20 * time.Minute,
+			maxBackoff: 10 * time.Minute,
+			errs:       []error{errFailed, errFailed},
+			want:       []time.Duration{20 * time.Minute, 20 * time.Minute},
+		},
+		{
+			name:       "upstream asks for a longer delay",
+			interval:   time.Minute,
+			maxBackoff: 10 * time.Minute,
+			notBefore:  3 * time.Minute,
+			errs:       []error{nil, errFailed, errFailed, errFailed},
+			want: []time.Duration{3 * time.Minute, 3 * time.Minute, 3 * time.Minute,
+				4 * time.Minute},
+		},
+		{
+			name:       "upstream delay limited to the maximum backoff",
+			interval:   time.Minute,
+			maxBackoff: 10 * time.Minute,
+			notBefore:  time.Hour,
+			errs:       []error{nil},
+			want:       []time.Duration{10 * time.Minute},
+		},
+		{
+			name:       "upstream delay limited to the interval",
+			interval:   20 * time.Minute,
+			maxBackoff: 10 * time.Minute,
+			notBefore:  time.Hour,
+			errs:       []error{nil},
+			want:       []time.Duration{20 * time.Minute},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &refreshSchedule{
+				interval:   func() time.Duration { return tt.interval },
+				maxBackoff: tt.maxBackoff,
+			}
+			if tt.notBefore != 0 {
+				s.notBefore = func() time.Time { return now.Add(tt.notBefore) }
+			}
+			for i, err := range tt.errs {
+				if got := s.next(now, err).Sub(now); got != tt.want[i] {
+					t.Errorf("refresh %d: next in %v, want %v", i+1, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRefreshScheduleJitter(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	s := &refreshSchedule{
+		interval:   func() time.Duration { return 100 * time.Second },
+		maxBackoff: time.Hour,
+		jitter:     0.1,
+		rand:       rand.New(rand.NewSource(1)),
+	}
+	varied := false
+	for i := 0; i < 100; i++ {
+		d := s.next(now, nil).Sub(now)
+		if d < 90*time.Second || d > 110*time.Second {
+			t.Fatalf("next in %v, want within 10%% of 100s", d)
+		}
+		if d != 100*time.Second {
+			varied = true
+		}
+	}
+	if !varied {
+		t.Error("jitter did not vary the delay")
+	}
+}
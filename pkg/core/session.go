@@ -153,3 +153,33 @@ func (c *Controller) LookupUser(tx *sql.Tx, userid string) (*db.User, error) {
 func (c *Controller) QueryRoles(tx *sql.Tx, user *db.User) ([]string, error) {
 	return c.db.QueryRoles(tx, user)
 }
+
+// SessionUser returns the user signed in to a session and the user's roles.
+func (c *Controller) SessionUser(
+	ctx context.Context,
+	sessionid string,
+) (*db.User, []string, error) {
+	tx, err := c.BeginDatabaseTransaction()
+	if err != nil {
+		return nil, nil, fmt.Errorf("BeginDatabaseTransaction: %w", err)
+	}
+	session, err := c.LookupSession(ctx, tx, sessionid)
+	if err != nil {
+		_ = c.AbortDatabaseTransaction(tx)
+		return nil, nil, fmt.Errorf("LookupSession: %w", err)
+	}
+	user, err := c.LookupUser(tx, session.UserID)
+	if err != nil {
+		_ = c.AbortDatabaseTransaction(tx)
+		return nil, nil, fmt.Errorf("LookupUser: %w", err)
+	}
+	roles, err := c.QueryRoles(tx, user)
+	if err != nil {
+		_ = c.AbortDatabaseTransaction(tx)
+		return nil, nil, fmt.Errorf("QueryRoles: %w", err)
+	}
+	if err = c.CommitDatabaseTransaction(tx); err != nil {
+		return nil, nil, fmt.Errorf("CommitDatabaseTransaction: %w", err)
+	}
+	return user, roles, nil
+}
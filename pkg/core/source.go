@@ -0,0 +1,63 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/burble"
+	"github.com/jumptown-skydiving/manifest-server/pkg/jsonfeed"
+)
+
+// ManifestSource is a source of manifest loads. Burble is the usual source,
+// but loads may also come from other tooling via the jsonfeed package.
+type ManifestSource interface {
+	// Refresh retrieves new data from the source and reports whether
+	// any loads or the column count have changed.
+	Refresh() (bool, error)
+
+	// Loads returns the loads most recently retrieved from the source
+	// that are to be displayed.
+	Loads() []*burble.Load
+
+	// AllLoads returns all of the loads most recently retrieved from
+	// the source, before Loads are chosen from them according to the
+	// display options.
+	AllLoads() []*burble.Load
+
+	// ColumnCount returns the number of load columns to display.
+	ColumnCount() int
+
+	// Health returns the time of the last successful refresh and the
+	// error from the most recent refresh, if any.
+	Health() (time.Time, error)
+
+	// Now returns the time at which the loads are current. A source
+	// replaying loads observed in the past returns the time on its
+	// simulated clock.
+	Now() time.Time
+}
+
+func (c *Controller) newManifestSource() (ManifestSource, string, error) {
+	switch source := c.settings.ManifestSource(); source {
+	case "", "burble":
+		return burble.NewController(c.settings), "Burble", nil
+	case "json":
+		return jsonfeed.NewController(c.settings, c.manifestUpdated),
+			"JSON Manifest", nil
+	default:
+		return nil, "", fmt.Errorf("unrecognized manifest source %q", source)
+	}
+}
+
+func (c *Controller) ManifestSource() ManifestSource {
+	return c.manifestSource
+}
+
+// replaying returns true if the manifest source is replaying captured
+// Burble responses rather than reporting current loads.
+func (c *Controller) replaying() bool {
+	_, ok := c.manifestSource.(*burble.Controller)
+	return ok && c.settings.BurbleReplayDir() != ""
+}
@@ -0,0 +1,203 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+const (
+	minWorkerBackoff = time.Second
+	maxWorkerBackoff = time.Minute
+
+	// workerResetTime is how long a worker must run before failing for
+	// its restart backoff to be reset.
+	workerResetTime = 5 * time.Minute
+)
+
+type WorkerState int
+
+const (
+	WorkerRunning    WorkerState = iota // the worker is running
+	WorkerRestarting                    // the worker failed and is waiting to be restarted
+	WorkerStopped                       // the worker was stopped by its supervisor
+)
+
+var workerStateNames = []string{"running", "restarting", "stopped"}
+
+func (s WorkerState) String() string {
+	if s < 0 || int(s) >= len(workerStateNames) {
+		return fmt.Sprintf("WorkerState(%d)", int(s))
+	}
+	return workerStateNames[s]
+}
+
+func (s WorkerState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// WorkerStatus describes the state of a supervised worker.
+type WorkerStatus struct {
+	Name        string      `json:"name"`
+	State       WorkerState `json:"state"`
+	Started     time.Time   `json:"started"` // when the worker was most recently started
+	Restarts    int         `json:"restarts"`
+	LastFailure string      `json:"last_failure,omitempty"`
+	LastFailed  time.Time   `json:"last_failed"`
+	NextRestart time.Time   `json:"next_restart"` // set while restarting
+}
+
+type worker struct {
+	status WorkerStatus
+}
+
+// Supervisor runs background workers. A worker that panics or returns before
+// the supervisor is stopped is logged and restarted with exponential backoff,
+// so that a single bad payload or transient error does not silently disable a
+// feature or kill the process.
+type Supervisor struct {
+	done <-chan struct{}
+	wg   sync.WaitGroup
+
+	// now and sleep are the clock used to time restarts, which tests
+	// replace. sleep returns false if the supervisor is stopped first.
+	now   func() time.Time
+	sleep func(d time.Duration) bool
+
+	lock    sync.Mutex
+	workers []*worker
+}
+
+// NewSupervisor creates a supervisor whose workers are stopped when done is
+// closed. Workers must themselves return when done is closed.
+func NewSupervisor(done <-chan struct{}) *Supervisor {
+	s := &Supervisor{
+		done: done,
+		now:  time.Now,
+	}
+	s.sleep = s.sleepUntilStopped
+	return s
+}
+
+// Go runs f in a new supervised worker.
+func (s *Supervisor) Go(name string, f func()) {
+	w := &worker{
+		status: WorkerStatus{
+			Name: name,
+		},
+	}
+	s.lock.Lock()
+	s.workers = append(s.workers, w)
+	s.lock.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(w, f)
+	}()
+}
+
+// Wait waits for all workers to return after the supervisor is stopped.
+func (s *Supervisor) Wait() {
+	s.wg.Wait()
+}
+
+// Workers returns the status of each worker in the order in which they were
+// started.
+func (s *Supervisor) Workers() []WorkerStatus {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	status := make([]WorkerStatus, len(s.workers))
+	for i, w := range s.workers {
+		status[i] = w.status
+	}
+	return status
+}
+
+func (s *Supervisor) stopped() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// sleepUntilStopped waits for d to pass, returning false if the supervisor
+// is stopped first.
+func (s *Supervisor) sleepUntilStopped(d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-s.done:
+		return false
+	case <-t.C:
+		return !s.stopped()
+	}
+}
+
+func (s *Supervisor) run(w *worker, f func()) {
+	backoff := minWorkerBackoff
+	for {
+		started := s.now()
+		s.lock.Lock()
+		w.status.State = WorkerRunning
+		w.status.Started = started
+		w.status.NextRestart = time.Time{}
+		s.lock.Unlock()
+
+		failure := runWorker(w.status.Name, f)
+		if s.stopped() {
+			break
+		}
+		if failure == "" {
+			failure = "returned unexpectedly"
+		}
+
+		now := s.now()
+		if now.Sub(started) >= workerResetTime {
+			backoff = minWorkerBackoff
+		}
+		fmt.Fprintf(os.Stderr, "Worker %s failed: %s (restarting in %s)\n",
+			w.status.Name, failure, backoff)
+
+		s.lock.Lock()
+		w.status.State = WorkerRestarting
+		w.status.Restarts++
+		w.status.LastFailure = failure
+		w.status.LastFailed = now
+		w.status.NextRestart = now.Add(backoff)
+		s.lock.Unlock()
+
+		if !s.sleep(backoff) {
+			break
+		}
+
+		backoff *= 2
+		if backoff > maxWorkerBackoff {
+			backoff = maxWorkerBackoff
+		}
+	}
+
+	s.lock.Lock()
+	w.status.State = WorkerStopped
+	w.status.NextRestart = time.Time{}
+	s.lock.Unlock()
+}
+
+// runWorker runs f, recovering from any panic. It returns a description of
+// the panic, or "" if f returned normally.
+func runWorker(name string, f func()) (failure string) {
+	defer func() {
+		if r := recover(); r != nil {
+			failure = fmt.Sprintf("panic: %v", r)
+			fmt.Fprintf(os.Stderr, "Worker %s %s\n%s", name, failure, debug.Stack())
+		}
+	}()
+	f()
+	return ""
+}
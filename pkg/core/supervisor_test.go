@@ -0,0 +1,176 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package core
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testSupervisor returns a supervisor on a simulated clock, which only
+// advances when a worker calls advance or the supervisor sleeps. The
+// durations that the supervisor sleeps are recorded in sleeps. The clock
+// must only be used from a single worker.
+func testSupervisor(done <-chan struct{}) (s *Supervisor, advance func(time.Duration), sleeps *[]time.Duration) {
+	now := time.Date(2023, 6, 10, 14, 0, 0, 0, time.UTC)
+	sleeps = &[]time.Duration{}
+	s = NewSupervisor(done)
+	s.now = func() time.Time { return now }
+	s.sleep = func(d time.Duration) bool {
+		*sleeps = append(*sleeps, d)
+		now = now.Add(d)
+		return !s.stopped()
+	}
+	advance = func(d time.Duration) { now = now.Add(d) }
+	return s, advance, sleeps
+}
+
+func TestSupervisorRestarts(t *testing.T) {
+	tests := []struct {
+		name        string
+		runs        []time.Duration // how long each run lasts before failing
+		wantSleeps  []time.Duration
+		wantFailure string
+	}{
+		{
+			name:        "backoff grows",
+			runs:        []time.Duration{0, 0, 0, 0},
+			wantSleeps:  []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second},
+			wantFailure: "returned unexpectedly",
+		},
+		{
+			name: "backoff limited",
+			runs: []time.Duration{0, 0, 0, 0, 0, 0, 0, 0},
+			wantSleeps: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second,
+				8 * time.Second, 16 * time.Second, 32 * time.Second, time.Minute, time.Minute},
+			wantFailure: "returned unexpectedly",
+		},
+		{
+			name:        "backoff reset after running",
+			runs:        []time.Duration{0, 0, 0, 5 * time.Minute, 0},
+			wantSleeps:  []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, time.Second, 2 * time.Second},
+			wantFailure: "returned unexpectedly",
+		},
+		{
+			name:        "backoff not reset",
+			runs:        []time.Duration{0, 0, 0, 4 * time.Minute, 0},
+			wantSleeps:  []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second},
+			wantFailure: "returned unexpectedly",
+		},
+		{
+			name:        "panic",
+			runs:        []time.Duration{0, -1, -1},
+			wantSleeps:  []time.Duration{time.Second, 2 * time.Second, 4 * time.Second},
+			wantFailure: "panic: worker failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(chan struct{})
+			s, advance, sleeps := testSupervisor(done)
+
+			// Each run fails, and a negative duration panics,
+			// until the runs are exhausted and the supervisor is
+			// stopped.
+			var runs int
+			s.Go("test", func() {
+				defer func() { runs++ }()
+				if runs == len(tt.runs) {
+					close(done)
+					return
+				}
+				if tt.runs[runs] < 0 {
+					panic("worker failed")
+				}
+				advance(tt.runs[runs])
+			})
+			s.Wait()
+
+			if runs != len(tt.runs)+1 {
+				t.Errorf("worker ran %d times, want %d", runs, len(tt.runs)+1)
+			}
+			if !reflect.DeepEqual(*sleeps, tt.wantSleeps) {
+				t.Errorf("restarted after %v, want %v", *sleeps, tt.wantSleeps)
+			}
+			status := s.Workers()[0]
+			if status.State != WorkerStopped || status.Restarts != len(tt.runs) ||
+				status.LastFailure != tt.wantFailure || !status.NextRestart.IsZero() {
+				t.Errorf("status %+v, want stopped after %d restarts with failure %q",
+					status, len(tt.runs), tt.wantFailure)
+			}
+		})
+	}
+}
+
+func TestSupervisorStatusWhileRestarting(t *testing.T) {
+	done := make(chan struct{})
+	s, _, _ := testSupervisor(done)
+	restarting := make(chan WorkerStatus)
+	s.sleep = func(time.Duration) bool {
+		restarting <- s.Workers()[0]
+		<-done
+		return false
+	}
+	s.Go("test", func() { panic("worker failed") })
+
+	status := <-restarting
+	close(done)
+	s.Wait()
+
+	if status.State != WorkerRestarting || status.Restarts != 1 ||
+		status.LastFailure != "panic: worker failed" ||
+		status.NextRestart.Sub(status.LastFailed) != minWorkerBackoff {
+		t.Errorf("status %+v while restarting", status)
+	}
+	if status := s.Workers()[0]; status.State != WorkerStopped || !status.NextRestart.IsZero() {
+		t.Errorf("status %+v after stopping, want stopped", status)
+	}
+}
+
+func TestSupervisorStop(t *testing.T) {
+	done := make(chan struct{})
+	s := NewSupervisor(done)
+	started := make(chan struct{})
+	var runs int
+	s.Go("test", func() {
+		runs++
+		close(started)
+		<-done
+	})
+	<-started
+	if status := s.Workers()[0]; status.State != WorkerRunning || status.Name != "test" {
+		t.Errorf("status %+v, want running", status)
+	}
+
+	close(done)
+	s.Wait()
+	if runs != 1 {
+		t.Errorf("worker ran %d times, want 1", runs)
+	}
+	if status := s.Workers()[0]; status.State != WorkerStopped || status.Restarts != 0 {
+		t.Errorf("status %+v, want stopped without restarting", status)
+	}
+}
+
+func TestSupervisorStopWhileRestarting(t *testing.T) {
+	done := make(chan struct{})
+	s := NewSupervisor(done)
+	failed := make(chan struct{})
+	s.Go("test", func() {
+		close(failed)
+		panic("worker failed")
+	})
+	<-failed
+
+	// The worker is not restarted for a second, but stops at once.
+	stopped := time.Now()
+	close(done)
+	s.Wait()
+	if elapsed := time.Since(stopped); elapsed >= minWorkerBackoff {
+		t.Errorf("stopped after %v, want before restarting", elapsed)
+	}
+	if status := s.Workers()[0]; status.State != WorkerStopped || status.Restarts > 1 {
+		t.Errorf("status %+v, want stopped", status)
+	}
+}
@@ -0,0 +1,404 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/db"
+	"github.com/jumptown-skydiving/manifest-server/pkg/webauth"
+)
+
+// Users sign in to the web pages with Sign In With Apple, which posts the
+// result back to the login page. The session that is created is like an
+// app's, but its ID is kept in a cookie, and because there is no refresh
+// token to keep it alive, it ends when it would otherwise be refreshed.
+// Cookies are always Secure, since Sign In With Apple only returns to pages
+// served via HTTPS, even when a proxy terminates TLS for the server.
+const (
+	webSessionCookie   = "manifest_session"
+	webLoginCookie     = "manifest_login"
+	webLoginTimeout    = 10 * time.Minute
+	webSessionProvider = "web"
+
+	appleAuthorizeURL = "https://appleid.apple.com/auth/authorize"
+)
+
+// webSessionCookieName returns the name of the cookie that holds the web
+// session ID. Each dropzone's sessions are its own, so each dropzone has its
+// own cookie.
+func (c *Controller) webSessionCookieName() string {
+	if id := c.settings.DropzoneID(); id != "" {
+		return webSessionCookie + "_" + id
+	}
+	return webSessionCookie
+}
+
+// csrfToken returns the CSRF token for a session. It is derived from the
+// session ID, which only the session's browser knows, so that it need not be
+// stored.
+func csrfToken(sessionid string) string {
+	mac := hmac.New(sha256.New, []byte(sessionid))
+	mac.Write([]byte("csrf"))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// randomToken returns a random string for use as a state or nonce.
+func randomToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// localRedirect returns next if it is a path on this server, so that the
+// login page cannot be used to send users elsewhere, or else fallback.
+func localRedirect(next, fallback string) string {
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") ||
+		strings.HasPrefix(next, "/\\") {
+		return fallback
+	}
+	return next
+}
+
+// webSession returns the ID of the session that the request's cookie
+// identifies and the roles of the user signed in to it.
+func (c *Controller) webSession(req *http.Request) (string, []string, error) {
+	cookie, err := req.Cookie(c.webSessionCookieName())
+	if err != nil {
+		return "", nil, db.ErrInvalidSessionID
+	}
+	_, roles, err := c.SessionUser(req.Context(), cookie.Value)
+	if err != nil {
+		return "", nil, err
+	}
+	return cookie.Value, roles, nil
+}
+
+func hasRole(userRoles []string, roles []string) bool {
+	for _, userRole := range userRoles {
+		for _, role := range roles {
+			if userRole == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WebPage returns a handler that serves f only to users who are signed in to
+// the web pages with one of roles. Other users are sent to the login page.
+// f may find the session's CSRF token with webauth.CSRFToken.
+func (c *Controller) WebPage(
+	f func(http.ResponseWriter, *http.Request),
+	roles ...string,
+) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		sessionid, userRoles, err := c.webSession(req)
+		if err != nil {
+			next := url.Values{"next": {req.URL.RequestURI()}}
+			http.Redirect(w, req, "login?"+next.Encode(), http.StatusSeeOther)
+			return
+		}
+		if !hasRole(userRoles, roles) {
+			http.Error(w, "Permission Denied", http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		f(w, webauth.WithCSRFToken(req, csrfToken(sessionid)))
+	}
+}
+
+// WebAction is like WebPage, but for a form handler that changes something.
+// It accepts only POST requests that carry the session's CSRF token, and it
+// refuses users who are not signed in rather than sending them to the login
+// page.
+func (c *Controller) WebAction(
+	f func(http.ResponseWriter, *http.Request),
+	roles ...string,
+) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		sessionid, userRoles, err := c.webSession(req)
+		if err != nil || !hasRole(userRoles, roles) {
+			http.Error(w, "Permission Denied", http.StatusForbidden)
+			return
+		}
+		token := csrfToken(sessionid)
+		submitted := req.PostFormValue(webauth.CSRFField)
+		if subtle.ConstantTimeCompare([]byte(submitted), []byte(token)) != 1 {
+			http.Error(w, "invalid CSRF token", http.StatusForbidden)
+			return
+		}
+		f(w, webauth.WithCSRFToken(req, token))
+	}
+}
+
+// LoginHandler serves the login page, which sends users to Apple to sign
+// in, and accepts the result that Apple posts back to it. Users are then
+// sent to the page given by the "next" query parameter.
+func (c *Controller) LoginHandler(w http.ResponseWriter, req *http.Request) {
+	if c.siwaWeb == nil {
+		http.Error(w, "Sign In With Apple is not configured for the web",
+			http.StatusNotFound)
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		next := localRedirect(req.URL.Query().Get("next"), "settings.html")
+		if _, _, err := c.webSession(req); err == nil {
+			http.Redirect(w, req, next, http.StatusSeeOther)
+			return
+		}
+		c.writeLoginPage(w, req, next, "")
+	case http.MethodPost:
+		c.completeLogin(w, req)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// writeLoginPage writes a page from which the user may sign in, remembering
+// the state of the attempt in a cookie. Apple posts the result back from
+// its own site, so the cookie must be sent with cross-site requests.
+func (c *Controller) writeLoginPage(w http.ResponseWriter, req *http.Request, next, errorMessage string) {
+	state, err := randomToken()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	login := url.Values{
+		"state": {state},
+		"nonce": {nonce},
+		"next":  {next},
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     webLoginCookie,
+		Value:    base64.RawURLEncoding.EncodeToString([]byte(login.Encode())),
+		Path:     "/",
+		MaxAge:   int(webLoginTimeout / time.Second),
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteNoneMode,
+	})
+
+	authorize := url.Values{
+		"client_id":     {c.settings.SignInWithAppleServicesID()},
+		"redirect_uri":  {"https://" + req.Host + req.URL.Path},
+		"response_type": {"code id_token"},
+		"response_mode": {"form_post"},
+		"scope":         {"name email"},
+		"state":         {state},
+		"nonce":         {nonce},
+	}
+	page := struct {
+		AuthorizeURL string
+		Error        string
+	}{
+		AuthorizeURL: appleAuthorizeURL + "?" + authorize.Encode(),
+		Error:        errorMessage,
+	}
+
+	b := &bytes.Buffer{}
+	if err = loginTemplate.Execute(b, &page); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	h := w.Header()
+	h.Set("Content-Type", "text/html; charset=utf-8")
+	h.Set("Cache-Control", "no-store")
+	if errorMessage != "" {
+		w.WriteHeader(http.StatusUnauthorized)
+	}
+	_, _ = w.Write(b.Bytes())
+}
+
+// completeLogin creates a session for a user whose sign in Apple has posted
+// back to the login page.
+func (c *Controller) completeLogin(w http.ResponseWriter, req *http.Request) {
+	var login url.Values
+	if cookie, err := req.Cookie(webLoginCookie); err == nil {
+		if data, err := base64.RawURLEncoding.DecodeString(cookie.Value); err == nil {
+			login, _ = url.ParseQuery(string(data))
+		}
+	}
+	next := localRedirect(login.Get("next"), "settings.html")
+	http.SetCookie(w, &http.Cookie{
+		Name:     webLoginCookie,
+		Path:     "/",
+		MaxAge:   -1,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteNoneMode,
+	})
+
+	state := req.PostFormValue("state")
+	if login == nil || state == "" ||
+		subtle.ConstantTimeCompare([]byte(state), []byte(login.Get("state"))) != 1 {
+		c.writeLoginPage(w, req, next, "The sign in attempt has expired. Please try again.")
+		return
+	}
+	if e := req.PostFormValue("error"); e != "" {
+		c.writeLoginPage(w, req, next, fmt.Sprintf("Sign In With Apple failed: %s", e))
+		return
+	}
+
+	session, err := c.newWebSession(req.Context(), req.PostFormValue("id_token"),
+		login.Get("nonce"), req.PostFormValue("user"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "web sign in: %v\n", err)
+		c.writeLoginPage(w, req, next, "Sign In With Apple failed.")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     c.webSessionCookieName(),
+		Value:    session.ID,
+		Path:     "/",
+		Expires:  session.RefreshTime,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, req, next, http.StatusSeeOther)
+}
+
+// newWebSession creates a web session for the user identified by an identity
+// token. Apple sends the user's name only the first time that the user
+// signs in.
+func (c *Controller) newWebSession(
+	ctx context.Context,
+	identityToken string,
+	nonce string,
+	userJSON string,
+) (*db.Session, error) {
+	id, err := c.siwaWeb.VerifyIdentityToken(ctx, identityToken, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("VerifyIdentityToken: %w", err)
+	}
+	if nonce == "" || subtle.ConstantTimeCompare([]byte(id.Nonce), []byte(nonce)) != 1 {
+		return nil, errors.New("VerifyIdentityToken: nonce mismatch")
+	}
+
+	var user struct {
+		Name struct {
+			FirstName string `json:"firstName"`
+			LastName  string `json:"lastName"`
+		} `json:"name"`
+	}
+	if userJSON != "" {
+		_ = json.Unmarshal([]byte(userJSON), &user)
+	}
+
+	tx, err := c.BeginDatabaseTransaction()
+	if err != nil {
+		return nil, fmt.Errorf("BeginDatabaseTransaction: %w", err)
+	}
+	u, err := c.CreateUser(tx, id.Subject, user.Name.FirstName,
+		user.Name.LastName, id.Email, id.IsPrivateEmail, id.EmailVerified)
+	if err != nil {
+		_ = c.AbortDatabaseTransaction(tx)
+		return nil, fmt.Errorf("CreateUser: %w", err)
+	}
+	session, err := c.NewSession(tx, u, "", "", identityToken, nonce,
+		webSessionProvider)
+	if err != nil {
+		_ = c.AbortDatabaseTransaction(tx)
+		return nil, fmt.Errorf("NewSession: %w", err)
+	}
+	if err = c.CommitDatabaseTransaction(tx); err != nil {
+		return nil, fmt.Errorf("CommitDatabaseTransaction: %w", err)
+	}
+	return session, nil
+}
+
+// LogoutHandler ends the web session and sends the user to the login page.
+// Like other form handlers, it accepts only POST requests that carry the
+// session's CSRF token.
+func (c *Controller) LogoutHandler(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	cookie, err := req.Cookie(c.webSessionCookieName())
+	if err != nil {
+		http.Redirect(w, req, "login", http.StatusSeeOther)
+		return
+	}
+	submitted := req.PostFormValue(webauth.CSRFField)
+	if subtle.ConstantTimeCompare([]byte(submitted), []byte(csrfToken(cookie.Value))) != 1 {
+		http.Error(w, "invalid CSRF token", http.StatusForbidden)
+		return
+	}
+
+	tx, err := c.BeginDatabaseTransaction()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err = c.DeleteSession(req.Context(), tx, cookie.Value); err != nil {
+		_ = c.AbortDatabaseTransaction(tx)
+	} else if err = c.CommitDatabaseTransaction(tx); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     c.webSessionCookieName(),
+		Path:     "/",
+		MaxAge:   -1,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, req, "login", http.StatusSeeOther)
+}
+
+var loginTemplate = template.Must(template.New("login").Parse(loginHTML))
+
+const loginHTML = `<html>
+<head>
+	<title>Manifest Sign In</title>
+</head>
+<body>
+	<div>
+		<h3>Sign In</h3>
+		<hr>
+		<br>
+	</div>
+	{{if .Error}}<div><b>{{.Error}}</b></div><br>{{end}}
+	<div>
+		<a href="{{.AuthorizeURL}}">Sign in with Apple</a>
+	</div>
+</body>
+</html>
+`
@@ -0,0 +1,123 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/settings"
+	"github.com/jumptown-skydiving/manifest-server/pkg/webauth"
+)
+
+// testController returns a controller with default settings and no data
+// sources or database.
+func testController(t *testing.T) *Controller {
+	t.Helper()
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "config.yaml")
+	config := "options_file: " + filepath.Join(dir, "options.json") + "\n" +
+		"jumper_rules_file: " + filepath.Join(dir, "jumper_rules.json") + "\n"
+	if err := os.WriteFile(filename, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s, err := settings.NewSettingsWithFilename(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Controller{settings: s}
+}
+
+func TestCSRFToken(t *testing.T) {
+	token := csrfToken("session1")
+	if len(token) != 64 || strings.Trim(token, "0123456789abcdef") != "" {
+		t.Errorf("csrfToken() = %q, want 64 hex digits", token)
+	}
+	if got := csrfToken("session1"); got != token {
+		t.Errorf("csrfToken() = %q then %q for the same session", token, got)
+	}
+	if csrfToken("session2") == token {
+		t.Error("csrfToken() is the same for different sessions")
+	}
+	if strings.Contains(token, "session1") {
+		t.Error("csrfToken() reveals the session ID")
+	}
+}
+
+func TestLogoutHandlerCSRF(t *testing.T) {
+	c := testController(t)
+	const sessionid = "session1"
+
+	tests := []struct {
+		name     string
+		method   string
+		cookie   string
+		token    string
+		wantCode int
+	}{
+		{"GET", http.MethodGet, sessionid, csrfToken(sessionid), http.StatusMethodNotAllowed},
+		{"no session", http.MethodPost, "", csrfToken(sessionid), http.StatusSeeOther},
+		{"no token", http.MethodPost, sessionid, "", http.StatusForbidden},
+		{"another session's token", http.MethodPost, sessionid, csrfToken("session2"), http.StatusForbidden},
+		{"truncated token", http.MethodPost, sessionid, csrfToken(sessionid)[:32], http.StatusForbidden},
+		{"session ID as token", http.MethodPost, sessionid, sessionid, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := url.Values{webauth.CSRFField: {tt.token}}
+			req := httptest.NewRequest(tt.method, "/logout", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: c.webSessionCookieName(), Value: tt.cookie})
+			}
+			rec := httptest.NewRecorder()
+			c.LogoutHandler(rec, req)
+			if rec.Code != tt.wantCode {
+				t.Errorf("status %d, want %d", rec.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestWebActionRequiresPOST(t *testing.T) {
+	c := testController(t)
+	called := false
+	h := c.WebAction(func(http.ResponseWriter, *http.Request) { called = true }, "admin")
+
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodGet, "/settings", nil))
+	if rec.Code != http.StatusMethodNotAllowed || called {
+		t.Errorf("status %d and called %v for GET, want %d and not called",
+			rec.Code, called, http.StatusMethodNotAllowed)
+	}
+	if got := rec.Header().Get("Allow"); got != "POST" {
+		t.Errorf("Allow %q, want POST", got)
+	}
+}
+
+func TestLocalRedirect(t *testing.T) {
+	tests := []struct {
+		next string
+		want string
+	}{
+		{"/settings.html", "/settings.html"},
+		{"/history.html?aircraft=Otter", "/history.html?aircraft=Otter"},
+		{"", "fallback"},
+		{"settings.html", "fallback"},
+		{"//evil.example.com/", "fallback"},
+		{`/\evil.example.com/`, "fallback"},
+		{"https://evil.example.com/", "fallback"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.next, func(t *testing.T) {
+			if got := localRedirect(tt.next, "fallback"); got != tt.want {
+				t.Errorf("localRedirect(%q) = %q, want %q", tt.next, got, tt.want)
+			}
+		})
+	}
+}
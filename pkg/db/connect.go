@@ -45,9 +45,63 @@ type Session struct {
 	_  struct{}
 }
 
+// LoadRecord is the archived history of a load.
+type LoadRecord struct {
+	ID           int64 // Burble load ID
+	AircraftName string
+	LoadNumber   string
+	CallMinutes  int64 // most recently observed
+	FirstSeen    time.Time
+	LastSeen     time.Time
+	Jumpers      []LoadJumperRecord
+	CallTimes    []LoadCallTime
+}
+
+// LoadJumperRecord is the archived history of a jumper on a load. A jumper
+// whose LastSeen is before the load's LastSeen was removed from the load.
+type LoadJumperRecord struct {
+	ID          int64 // Burble jumper ID; not necessarily unique
+	Name        string
+	ShortName   string
+	RigName     string
+	GroupLeader string
+	FirstSeen   time.Time
+	LastSeen    time.Time
+}
+
+// LoadCallTime records the time at which a load's call time changed.
+type LoadCallTime struct {
+	CallMinutes int64
+	Time        time.Time
+}
+
+// LoadQuery selects archived loads. Zero values match all loads.
+type LoadQuery struct {
+	Start        time.Time // loads last seen at or after Start
+	End          time.Time // loads first seen before End
+	AircraftName string    // case-insensitive exact match
+	JumperName   string    // case-insensitive substring match
+	Limit        int       // 100 if zero, and no more than 1000
+}
+
+// FuelRequest is a request for an aircraft to be fueled. A request remains
+// active until it is completed.
+type FuelRequest struct {
+	ID              int64
+	AircraftName    string
+	Note            string // e.g. the quantity of fuel wanted
+	RequestedBy     string
+	RequestTime     time.Time
+	AcknowledgedBy  string
+	AcknowledgeTime time.Time // zero if not acknowledged
+	CompletedBy     string
+	CompleteTime    time.Time // zero if not completed
+}
+
 var (
-	ErrInvalidUserID    = errors.New("invalid user ID")
-	ErrInvalidSessionID = errors.New("invalid session ID")
+	ErrInvalidUserID        = errors.New("invalid user ID")
+	ErrInvalidSessionID     = errors.New("invalid session ID")
+	ErrInvalidFuelRequestID = errors.New("invalid fuel request ID")
 )
 
 type Connection interface {
@@ -84,6 +138,19 @@ type Connection interface {
 	AddRole(tx *sql.Tx, user *User, role string) error
 	RemoveRole(tx *sql.Tx, user *User, role string) error
 	QueryRoles(tx *sql.Tx, user *User) ([]string, error)
+
+	// Loads and fuel requests are those of the dropzone for which the
+	// connection was made, whereas users and sessions are shared by all
+	// dropzones using the database.
+	RecordLoad(tx *sql.Tx, load *LoadRecord, now time.Time) error
+	QueryLoads(tx *sql.Tx, query LoadQuery) ([]*LoadRecord, error)
+
+	// CreateFuelRequest returns the aircraft's active fuel request rather
+	// than creating another if it already has one.
+	CreateFuelRequest(tx *sql.Tx, user *User, aircraftName, note string, now time.Time) (*FuelRequest, error)
+	AcknowledgeFuelRequest(tx *sql.Tx, id int64, user *User, now time.Time) error
+	CompleteFuelRequest(tx *sql.Tx, id int64, user *User, now time.Time) error
+	QueryActiveFuelRequests(tx *sql.Tx) ([]*FuelRequest, error)
 }
 
 func Connect(settings *settings.Settings) (Connection, error) {
@@ -71,7 +71,9 @@ type sessionSQLite3 struct {
 }
 
 func connectViaSQLite3(settings *settings.Settings) (*SQLite3, error) {
-	dsn := fmt.Sprintf("file:%s?mode=rwc", settings.DatabaseFilename())
+	// Dropzones may share a database, each with its own connection, so
+	// wait for the others' transactions rather than failing.
+	dsn := fmt.Sprintf("file:%s?mode=rwc&_busy_timeout=5000", settings.DatabaseFilename())
 
 	c, err := sql.Open("sqlite3", dsn)
 	if err != nil {
@@ -96,6 +98,18 @@ func connectViaSQLite3(settings *settings.Settings) (*SQLite3, error) {
 		return nil, err
 	}
 
+	_, err = c.Exec(createLoadsTablesSQLite3)
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	_, err = c.Exec(createFuelRequestsTableSQLite3)
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+
 	db := SQLite3{
 		c:        c,
 		settings: settings,
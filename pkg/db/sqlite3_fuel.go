@@ -0,0 +1,157 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package db
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+)
+
+const createFuelRequestsTableSQLite3 = `
+CREATE TABLE IF NOT EXISTS fuel_requests (
+	id INTEGER NOT NULL PRIMARY KEY ASC AUTOINCREMENT,
+	dropzone TEXT NOT NULL,
+	aircraft_name TEXT NOT NULL,
+	note TEXT NOT NULL,
+	request_userid INTEGER REFERENCES users (id) ON DELETE SET NULL,
+	request_time TIMESTAMP NOT NULL,
+	ack_userid INTEGER REFERENCES users (id) ON DELETE SET NULL,
+	ack_time TIMESTAMP,
+	complete_userid INTEGER REFERENCES users (id) ON DELETE SET NULL,
+	complete_time TIMESTAMP);
+CREATE INDEX IF NOT EXISTS fuel_requests_dropzone_complete_time ON fuel_requests (dropzone, complete_time);
+INSERT OR IGNORE INTO roles (name) VALUES ("fuel");
+`
+
+const selectFuelRequestsSQLite3 = `
+SELECT f.id, f.aircraft_name, f.note, f.request_time, f.ack_time, f.complete_time,
+	r.given_name, r.family_name, r.email,
+	a.given_name, a.family_name, a.email,
+	c.given_name, c.family_name, c.email
+FROM fuel_requests f
+LEFT JOIN users r ON r.id = f.request_userid
+LEFT JOIN users a ON a.id = f.ack_userid
+LEFT JOIN users c ON c.id = f.complete_userid
+`
+
+// userDisplayName returns the name of a user as shown to other users.
+func userDisplayName(givenName, familyName, email sql.NullString) string {
+	name := strings.TrimSpace(givenName.String + " " + familyName.String)
+	if name == "" {
+		name = email.String
+	}
+	return name
+}
+
+func (db *SQLite3) queryFuelRequests(tx *sql.Tx, where string, args ...interface{}) ([]*FuelRequest, error) {
+	rs, err := tx.Query(selectFuelRequestsSQLite3+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rs.Close()
+
+	var requests []*FuelRequest
+	for rs.Next() {
+		var (
+			f                     FuelRequest
+			ackTime, completeTime sql.NullTime
+			names                 [9]sql.NullString
+		)
+		err = rs.Scan(&f.ID, &f.AircraftName, &f.Note, &f.RequestTime,
+			&ackTime, &completeTime,
+			&names[0], &names[1], &names[2],
+			&names[3], &names[4], &names[5],
+			&names[6], &names[7], &names[8])
+		if err != nil {
+			return nil, err
+		}
+		f.RequestedBy = userDisplayName(names[0], names[1], names[2])
+		if ackTime.Valid {
+			f.AcknowledgeTime = ackTime.Time
+			f.AcknowledgedBy = userDisplayName(names[3], names[4], names[5])
+		}
+		if completeTime.Valid {
+			f.CompleteTime = completeTime.Time
+			f.CompletedBy = userDisplayName(names[6], names[7], names[8])
+		}
+		requests = append(requests, &f)
+	}
+	return requests, rs.Err()
+}
+
+func (db *SQLite3) CreateFuelRequest(
+	tx *sql.Tx,
+	user *User,
+	aircraftName, note string,
+	now time.Time,
+) (*FuelRequest, error) {
+	ui, ok := user.db.(userSQLite3)
+	if !ok || ui.rowid <= 0 {
+		return nil, ErrInvalidUserID
+	}
+
+	// The aircraft's active request is checked for by the INSERT itself,
+	// which holds the database's write lock, so that concurrent requests
+	// cannot both create one.
+	dropzone := db.settings.DropzoneID()
+	_, err := tx.Exec("INSERT INTO fuel_requests (dropzone, aircraft_name, note, request_userid, request_time) "+
+		"SELECT $1, $2, $3, $4, $5 WHERE NOT EXISTS (SELECT 1 FROM fuel_requests "+
+		"WHERE dropzone = $1 AND aircraft_name = $2 AND complete_time IS NULL);",
+		dropzone, aircraftName, note, ui.rowid, dbTime(now))
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := db.queryFuelRequests(tx, "WHERE f.dropzone = $1 AND f.aircraft_name = $2 "+
+		"AND f.complete_time IS NULL ORDER BY f.request_time, f.id LIMIT 1;",
+		dropzone, aircraftName)
+	if err != nil {
+		return nil, err
+	}
+	if len(requests) != 1 {
+		return nil, ErrInvalidFuelRequestID
+	}
+	return requests[0], nil
+}
+
+func (db *SQLite3) updateFuelRequest(tx *sql.Tx, stmt string, args ...interface{}) error {
+	result, err := tx.Exec(stmt, args...)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrInvalidFuelRequestID
+	}
+	return nil
+}
+
+func (db *SQLite3) AcknowledgeFuelRequest(tx *sql.Tx, id int64, user *User, now time.Time) error {
+	ui, ok := user.db.(userSQLite3)
+	if !ok || ui.rowid <= 0 {
+		return ErrInvalidUserID
+	}
+	return db.updateFuelRequest(tx, "UPDATE fuel_requests SET ack_userid = $1, ack_time = $2 "+
+		"WHERE id = $3 AND dropzone = $4 AND complete_time IS NULL;",
+		ui.rowid, dbTime(now), id, db.settings.DropzoneID())
+}
+
+func (db *SQLite3) CompleteFuelRequest(tx *sql.Tx, id int64, user *User, now time.Time) error {
+	ui, ok := user.db.(userSQLite3)
+	if !ok || ui.rowid <= 0 {
+		return ErrInvalidUserID
+	}
+	return db.updateFuelRequest(tx, "UPDATE fuel_requests SET complete_userid = $1, complete_time = $2, "+
+		"ack_userid = COALESCE(ack_userid, $1), ack_time = COALESCE(ack_time, $2) "+
+		"WHERE id = $3 AND dropzone = $4 AND complete_time IS NULL;",
+		ui.rowid, dbTime(now), id, db.settings.DropzoneID())
+}
+
+func (db *SQLite3) QueryActiveFuelRequests(tx *sql.Tx) ([]*FuelRequest, error) {
+	return db.queryFuelRequests(tx, "WHERE f.dropzone = $1 AND f.complete_time IS NULL ORDER BY f.request_time, f.id;",
+		db.settings.DropzoneID())
+}
@@ -0,0 +1,84 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+// createTestUser creates a user with the given ID.
+func createTestUser(t *testing.T, db *SQLite3, userid string) *User {
+	t.Helper()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := db.CreateUser(tx, userid, "Test", "User", userid+"@example.com", false, true)
+	if err != nil {
+		_ = tx.Rollback()
+		t.Fatal(err)
+	}
+	if err = tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+// createTestFuelRequest requests fuel for aircraftName at now.
+func createTestFuelRequest(t *testing.T, db *SQLite3, user *User, aircraftName string, now time.Time) *FuelRequest {
+	t.Helper()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	request, err := db.CreateFuelRequest(tx, user, aircraftName, "", now)
+	if err != nil {
+		_ = tx.Rollback()
+		t.Fatal(err)
+	}
+	if err = tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	return request
+}
+
+func TestCreateFuelRequest(t *testing.T) {
+	start := time.Date(2023, 6, 10, 14, 0, 0, 0, time.UTC)
+	dbs := testDatabases(t, "jumptown", "otherdz")
+	db := dbs[0]
+	user := createTestUser(t, db, "pilot")
+
+	otter := createTestFuelRequest(t, db, user, "Otter", start)
+	if otter.AircraftName != "Otter" || otter.RequestedBy != "Test User" || !otter.RequestTime.Equal(start) {
+		t.Errorf("CreateFuelRequest() = %+v", otter)
+	}
+
+	// An aircraft has only one active request...
+	if got := createTestFuelRequest(t, db, user, "Otter", start.Add(time.Minute)); got.ID != otter.ID {
+		t.Errorf("second request for the Otter has ID %d, want %d", got.ID, otter.ID)
+	}
+	// ...but other aircraft and dropzones have their own.
+	if got := createTestFuelRequest(t, db, user, "King Air", start); got.ID == otter.ID {
+		t.Error("King Air given the Otter's request")
+	}
+	if got := createTestFuelRequest(t, dbs[1], user, "Otter", start); got.ID == otter.ID {
+		t.Error("another dropzone's Otter given the Otter's request")
+	}
+
+	// Once the request is complete, the aircraft may request fuel again.
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.CompleteFuelRequest(tx, otter.ID, user, start.Add(2*time.Minute)); err != nil {
+		_ = tx.Rollback()
+		t.Fatal(err)
+	}
+	if err = tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if got := createTestFuelRequest(t, db, user, "Otter", start.Add(3*time.Minute)); got.ID == otter.ID {
+		t.Error("completed request returned for a new request")
+	}
+}
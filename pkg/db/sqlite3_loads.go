@@ -0,0 +1,228 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const createLoadsTablesSQLite3 = `
+CREATE TABLE IF NOT EXISTS loads (
+	id INTEGER NOT NULL PRIMARY KEY ASC AUTOINCREMENT,
+	dropzone TEXT NOT NULL,
+	loadid INTEGER NOT NULL,
+	aircraft_name TEXT NOT NULL,
+	load_number TEXT NOT NULL,
+	call_minutes INTEGER NOT NULL,
+	first_seen TIMESTAMP NOT NULL,
+	last_seen TIMESTAMP NOT NULL,
+	UNIQUE (dropzone, loadid));
+DROP INDEX IF EXISTS loads_dropzone_loadid;
+CREATE INDEX IF NOT EXISTS loads_first_seen ON loads (first_seen);
+CREATE INDEX IF NOT EXISTS loads_aircraft_name ON loads (aircraft_name COLLATE NOCASE);
+CREATE TABLE IF NOT EXISTS load_jumpers (
+	loadid INTEGER NOT NULL REFERENCES loads (id) ON DELETE CASCADE,
+	jumperid INTEGER NOT NULL,
+	name TEXT NOT NULL,
+	short_name TEXT NOT NULL,
+	rig_name TEXT NOT NULL,
+	group_leader TEXT NOT NULL,
+	first_seen TIMESTAMP NOT NULL,
+	last_seen TIMESTAMP NOT NULL,
+	PRIMARY KEY (loadid, name));
+CREATE INDEX IF NOT EXISTS load_jumpers_name ON load_jumpers (name COLLATE NOCASE);
+CREATE TABLE IF NOT EXISTS load_call_times (
+	loadid INTEGER NOT NULL REFERENCES loads (id) ON DELETE CASCADE,
+	call_minutes INTEGER NOT NULL,
+	time TIMESTAMP NOT NULL);
+CREATE INDEX IF NOT EXISTS load_call_times_loadid ON load_call_times (loadid);
+`
+
+const (
+	// defaultLoadQueryLimit is the maximum number of loads returned by
+	// QueryLoads if the query does not specify a limit.
+	defaultLoadQueryLimit = 100
+
+	// maxLoadQueryLimit is the maximum number of loads returned by
+	// QueryLoads, whatever limit the query specifies.
+	maxLoadQueryLimit = 1000
+)
+
+// likeEscaper escapes the wildcards in a LIKE pattern, using \ as the escape
+// character, so that they match themselves.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// dbTime normalizes times stored in the database so that they compare
+// correctly as stored.
+func dbTime(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Second)
+}
+
+func (db *SQLite3) RecordLoad(tx *sql.Tx, load *LoadRecord, now time.Time) error {
+	now = dbTime(now)
+
+	var (
+		rowid       int64
+		callMinutes int64
+	)
+	dropzone := db.settings.DropzoneID()
+	r := tx.QueryRow("SELECT id, call_minutes FROM loads WHERE dropzone = $1 AND loadid = $2;",
+		dropzone, load.ID)
+	err := r.Scan(&rowid, &callMinutes)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		r = tx.QueryRow("INSERT INTO loads (dropzone, loadid, aircraft_name, load_number, call_minutes, first_seen, last_seen) "+
+			"VALUES ($1, $2, $3, $4, $5, $6, $6) RETURNING id;",
+			dropzone, load.ID, load.AircraftName, load.LoadNumber, load.CallMinutes, now)
+		if err = r.Scan(&rowid); err != nil {
+			return err
+		}
+		callMinutes = load.CallMinutes + 1 // force a call time entry
+	case err != nil:
+		return err
+	default:
+		_, err = tx.Exec("UPDATE loads SET aircraft_name = $1, load_number = $2, call_minutes = $3, last_seen = $4 WHERE id = $5;",
+			load.AircraftName, load.LoadNumber, load.CallMinutes, now, rowid)
+		if err != nil {
+			return err
+		}
+	}
+
+	if callMinutes != load.CallMinutes {
+		_, err = tx.Exec("INSERT INTO load_call_times (loadid, call_minutes, time) VALUES ($1, $2, $3);",
+			rowid, load.CallMinutes, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, j := range load.Jumpers {
+		_, err = tx.Exec("INSERT INTO load_jumpers (loadid, jumperid, name, short_name, rig_name, group_leader, first_seen, last_seen) "+
+			"VALUES ($1, $2, $3, $4, $5, $6, $7, $7) ON CONFLICT(loadid, name) DO UPDATE SET "+
+			"jumperid = $2, short_name = $4, rig_name = $5, group_leader = $6, last_seen = $7;",
+			rowid, j.ID, j.Name, j.ShortName, j.RigName, j.GroupLeader, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (db *SQLite3) QueryLoads(tx *sql.Tx, query LoadQuery) ([]*LoadRecord, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	// arg adds an argument to the statement, returning its placeholder.
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	conditions = append(conditions, "dropzone = "+arg(db.settings.DropzoneID()))
+	if !query.Start.IsZero() {
+		conditions = append(conditions, "last_seen >= "+arg(dbTime(query.Start)))
+	}
+	if !query.End.IsZero() {
+		conditions = append(conditions, "first_seen < "+arg(dbTime(query.End)))
+	}
+	if query.AircraftName != "" {
+		conditions = append(conditions, "aircraft_name = "+arg(query.AircraftName)+" COLLATE NOCASE")
+	}
+	if query.JumperName != "" {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM load_jumpers WHERE load_jumpers.loadid = loads.id AND load_jumpers.name LIKE "+
+			arg("%"+likeEscaper.Replace(query.JumperName)+"%")+` ESCAPE '\')`)
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultLoadQueryLimit
+	} else if limit > maxLoadQueryLimit {
+		limit = maxLoadQueryLimit
+	}
+
+	stmt := "SELECT id, loadid, aircraft_name, load_number, call_minutes, first_seen, last_seen FROM loads" +
+		" WHERE " + strings.Join(conditions, " AND ") +
+		" ORDER BY first_seen DESC LIMIT " + arg(limit) + ";"
+
+	rs, err := tx.Query(stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rs.Close()
+
+	var (
+		loads  []*LoadRecord
+		rowids []int64
+	)
+	for rs.Next() {
+		var (
+			l     LoadRecord
+			rowid int64
+		)
+		err = rs.Scan(&rowid, &l.ID, &l.AircraftName, &l.LoadNumber,
+			&l.CallMinutes, &l.FirstSeen, &l.LastSeen)
+		if err != nil {
+			return nil, err
+		}
+		loads = append(loads, &l)
+		rowids = append(rowids, rowid)
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	rs.Close()
+
+	for i, l := range loads {
+		if l.Jumpers, err = db.queryLoadJumpers(tx, rowids[i]); err != nil {
+			return nil, err
+		}
+		if l.CallTimes, err = db.queryLoadCallTimes(tx, rowids[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return loads, nil
+}
+
+func (db *SQLite3) queryLoadJumpers(tx *sql.Tx, rowid int64) ([]LoadJumperRecord, error) {
+	rs, err := tx.Query("SELECT jumperid, name, short_name, rig_name, group_leader, first_seen, last_seen "+
+		"FROM load_jumpers WHERE loadid = $1 ORDER BY first_seen, name;", rowid)
+	if err != nil {
+		return nil, err
+	}
+	defer rs.Close()
+
+	var jumpers []LoadJumperRecord
+	for rs.Next() {
+		var j LoadJumperRecord
+		err = rs.Scan(&j.ID, &j.Name, &j.ShortName, &j.RigName,
+			&j.GroupLeader, &j.FirstSeen, &j.LastSeen)
+		if err != nil {
+			return nil, err
+		}
+		jumpers = append(jumpers, j)
+	}
+	return jumpers, rs.Err()
+}
+
+func (db *SQLite3) queryLoadCallTimes(tx *sql.Tx, rowid int64) ([]LoadCallTime, error) {
+	rs, err := tx.Query("SELECT call_minutes, time FROM load_call_times WHERE loadid = $1 ORDER BY time;", rowid)
+	if err != nil {
+		return nil, err
+	}
+	defer rs.Close()
+
+	var callTimes []LoadCallTime
+	for rs.Next() {
+		var t LoadCallTime
+		if err = rs.Scan(&t.CallMinutes, &t.Time); err != nil {
+			return nil, err
+		}
+		callTimes = append(callTimes, t)
+	}
+	return callTimes, rs.Err()
+}
@@ -0,0 +1,227 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/settings"
+)
+
+// testDatabases returns a connection to a new database for each of the
+// dropzones, which share the database.
+func testDatabases(t *testing.T, dropzones ...string) []*SQLite3 {
+	t.Helper()
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "config.yaml")
+	config := "database:\n" +
+		"  driver: sqlite3\n" +
+		"  filename: " + filepath.Join(dir, "database.sqlite3") + "\n" +
+		"dropzones:\n"
+	for _, id := range dropzones {
+		config += "  " + id + ":\n" +
+			"    options_file: " + filepath.Join(dir, id+"-options.json") + "\n" +
+			"    jumper_rules_file: " + filepath.Join(dir, id+"-jumper_rules.json") + "\n"
+	}
+	if err := os.WriteFile(filename, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s, err := settings.NewSettingsWithFilename(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dbs []*SQLite3
+	for _, id := range dropzones {
+		d, err := s.ForDropzone(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		db, err := connectViaSQLite3(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(db.Close)
+		dbs = append(dbs, db)
+	}
+	return dbs
+}
+
+// recordTestLoad records load as seen at now.
+func recordTestLoad(t *testing.T, db *SQLite3, load LoadRecord, now time.Time) {
+	t.Helper()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.RecordLoad(tx, &load, now); err != nil {
+		_ = tx.Rollback()
+		t.Fatal(err)
+	}
+	if err = tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// queryTestLoads returns the IDs of the loads matching query.
+func queryTestLoads(t *testing.T, db *SQLite3, query LoadQuery) []int64 {
+	t.Helper()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	loads, err := db.QueryLoads(tx, query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := []int64{}
+	for _, l := range loads {
+		ids = append(ids, l.ID)
+	}
+	return ids
+}
+
+func TestQueryLoads(t *testing.T) {
+	start := time.Date(2023, 6, 10, 14, 0, 0, 0, time.UTC)
+	dbs := testDatabases(t, "jumptown", "otherdz")
+	db := dbs[0]
+
+	jumpers := func(names ...string) []LoadJumperRecord {
+		var jumpers []LoadJumperRecord
+		for _, name := range names {
+			jumpers = append(jumpers, LoadJumperRecord{Name: name})
+		}
+		return jumpers
+	}
+	load1 := LoadRecord{ID: 1, AircraftName: "Otter", LoadNumber: "1", Jumpers: jumpers("Alice Smith", "Bob_Jones")}
+	recordTestLoad(t, db, load1, start)
+	recordTestLoad(t, db, load1, start.Add(30*time.Minute))
+	recordTestLoad(t, db, LoadRecord{ID: 2, AircraftName: "King Air", LoadNumber: "1",
+		Jumpers: jumpers(`100% Carol`)}, start.Add(10*time.Minute))
+	recordTestLoad(t, db, LoadRecord{ID: 3, AircraftName: "Otter", LoadNumber: "2",
+		Jumpers: jumpers(`Dan \ Evans`)}, start.Add(20*time.Minute))
+
+	// Another dropzone's load is never found.
+	recordTestLoad(t, dbs[1], LoadRecord{ID: 4, AircraftName: "Otter", LoadNumber: "1",
+		Jumpers: jumpers("Alice Smith")}, start)
+
+	tests := []struct {
+		name  string
+		query LoadQuery
+		want  []int64
+	}{
+		{"all", LoadQuery{}, []int64{3, 2, 1}},
+		{"aircraft", LoadQuery{AircraftName: "otter"}, []int64{3, 1}},
+		{"unknown aircraft", LoadQuery{AircraftName: "Caravan"}, []int64{}},
+		{"jumper", LoadQuery{JumperName: "alice"}, []int64{1}},
+		{"jumper substring", LoadQuery{JumperName: "o"}, []int64{2, 1}},
+		{"underscore", LoadQuery{JumperName: "_"}, []int64{1}},
+		{"percent", LoadQuery{JumperName: "%"}, []int64{2}},
+		{"backslash", LoadQuery{JumperName: `\`}, []int64{3}},
+		{"wildcard not matched", LoadQuery{JumperName: "a_i"}, []int64{}},
+		{"start", LoadQuery{Start: start.Add(25 * time.Minute)}, []int64{1}},
+		{"end", LoadQuery{End: start.Add(15 * time.Minute)}, []int64{2, 1}},
+		{"start and end", LoadQuery{Start: start.Add(15 * time.Minute), End: start.Add(25 * time.Minute)}, []int64{3, 1}},
+		{"aircraft and jumper", LoadQuery{AircraftName: "Otter", JumperName: "Evans"}, []int64{3}},
+		{"limit", LoadQuery{Limit: 2}, []int64{3, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := queryTestLoads(t, db, tt.query); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("QueryLoads(%+v) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryLoadsLimit(t *testing.T) {
+	start := time.Date(2023, 6, 10, 14, 0, 0, 0, time.UTC)
+	db := testDatabases(t, "jumptown")[0]
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < maxLoadQueryLimit+5; i++ {
+		load := LoadRecord{ID: int64(i + 1), AircraftName: "Otter", LoadNumber: "1"}
+		if err = db.RecordLoad(tx, &load, start.Add(time.Duration(i)*time.Second)); err != nil {
+			_ = tx.Rollback()
+			t.Fatal(err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		limit int
+		want  int
+	}{
+		{0, defaultLoadQueryLimit},
+		{-1, defaultLoadQueryLimit},
+		{10, 10},
+		{maxLoadQueryLimit, maxLoadQueryLimit},
+		{maxLoadQueryLimit + 1, maxLoadQueryLimit},
+		{1 << 30, maxLoadQueryLimit},
+	}
+	for _, tt := range tests {
+		if got := len(queryTestLoads(t, db, LoadQuery{Limit: tt.limit})); got != tt.want {
+			t.Errorf("QueryLoads with limit %d returned %d loads, want %d", tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestRecordLoad(t *testing.T) {
+	start := time.Date(2023, 6, 10, 14, 0, 0, 0, time.UTC)
+	db := testDatabases(t, "jumptown")[0]
+
+	// The load is recorded with Alice and Bob, then its call time changes
+	// and Bob leaves, and then it is seen again unchanged.
+	alice := LoadJumperRecord{ID: 1, Name: "Alice", ShortName: "Sport"}
+	bob := LoadJumperRecord{ID: 2, Name: "Bob", ShortName: "Video"}
+	load := LoadRecord{ID: 7, AircraftName: "Otter", LoadNumber: "3", CallMinutes: 20,
+		Jumpers: []LoadJumperRecord{alice, bob}}
+	recordTestLoad(t, db, load, start)
+	load.CallMinutes = 15
+	load.Jumpers = []LoadJumperRecord{alice}
+	recordTestLoad(t, db, load, start.Add(5*time.Minute))
+	recordTestLoad(t, db, load, start.Add(6*time.Minute+500*time.Millisecond))
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	loads, err := db.QueryLoads(tx, LoadQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loads) != 1 {
+		t.Fatalf("%d loads, want 1", len(loads))
+	}
+
+	alice.FirstSeen, alice.LastSeen = start, start.Add(6*time.Minute)
+	bob.FirstSeen, bob.LastSeen = start, start
+	want := &LoadRecord{
+		ID:           7,
+		AircraftName: "Otter",
+		LoadNumber:   "3",
+		CallMinutes:  15,
+		FirstSeen:    start,
+		LastSeen:     start.Add(6 * time.Minute),
+		Jumpers:      []LoadJumperRecord{alice, bob},
+		CallTimes: []LoadCallTime{
+			{CallMinutes: 20, Time: start},
+			{CallMinutes: 15, Time: start.Add(5 * time.Minute)},
+		},
+	}
+	got := loads[0]
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("QueryLoads() = %+v, want %+v", got, want)
+	}
+}
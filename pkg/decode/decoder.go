@@ -0,0 +1,245 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package decode
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrMissing = errors.New("missing")
+	ErrType    = errors.New("unexpected type")
+	ErrInteger = errors.New("not an integer")
+)
+
+// FieldError describes a field that could not be decoded.
+type FieldError struct {
+	ID    int64       // ID of the object containing the field, if known
+	Field string      // path of the field within the object
+	Value interface{} // raw value of the field as decoded from JSON
+	Err   error
+}
+
+func (e FieldError) Error() string {
+	if e.Value == nil {
+		return fmt.Sprintf("%d: %s: %v", e.ID, e.Field, e.Err)
+	}
+	return fmt.Sprintf("%d: %s: %v (%#v)", e.ID, e.Field, e.Err, e.Value)
+}
+
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Decoder decodes fields from loosely typed JSON objects as produced by
+// encoding/json when unmarshaling into an interface{}. Rather than failing
+// or panicking on the first malformed field, it records a FieldError for
+// each and returns a zero value, so that callers can decide whether an
+// object is usable once all of its fields have been decoded.
+type Decoder struct {
+	// ID identifies the object currently being decoded in any errors
+	// that are recorded.
+	ID int64
+
+	// Prefix is prepended to field names in any errors that are
+	// recorded. It is useful for identifying nested objects.
+	Prefix string
+
+	// Errors are the errors that have been recorded.
+	Errors []FieldError
+}
+
+// Fail records an error for a field.
+func (d *Decoder) Fail(field string, value interface{}, err error) {
+	d.Errors = append(d.Errors, FieldError{
+		ID:    d.ID,
+		Field: d.Prefix + field,
+		Value: value,
+		Err:   err,
+	})
+}
+
+// Failed returns true if any errors have been recorded since mark, which is
+// a value previously returned by Mark.
+func (d *Decoder) Failed(mark int) bool {
+	return len(d.Errors) > mark
+}
+
+// Mark returns a value that may be passed to Failed to determine whether any
+// errors have been recorded since Mark was called.
+func (d *Decoder) Mark() int {
+	return len(d.Errors)
+}
+
+// ParseInt decodes a JSON value as a signed integer. Integers encoded as
+// strings are accepted, but numbers with fractional parts are not.
+func ParseInt(i interface{}) (int64, error) {
+	switch v := i.(type) {
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		return strconv.ParseInt(strings.TrimSpace(v), 0, 64)
+	case int64:
+		return v, nil
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return 0, ErrType
+		}
+		if v != math.Trunc(v) {
+			return 0, ErrInteger
+		}
+		return int64(v), nil
+	default:
+		return 0, ErrType
+	}
+}
+
+// ParseBool decodes a JSON value as a bool. Booleans encoded as strings or
+// numbers are accepted. An empty string is false.
+func ParseBool(i interface{}) (bool, error) {
+	switch v := i.(type) {
+	case bool:
+		return v, nil
+	case string:
+		if v = strings.TrimSpace(v); v == "" {
+			return false, nil
+		}
+		return strconv.ParseBool(v)
+	case int64:
+		return v != 0, nil
+	case float64:
+		return v != 0.0, nil
+	default:
+		return false, ErrType
+	}
+}
+
+// Int decodes a required integer field.
+func (d *Decoder) Int(obj map[string]interface{}, field string) int64 {
+	v, ok := obj[field]
+	if !ok || v == nil {
+		d.Fail(field, nil, ErrMissing)
+		return 0
+	}
+	x, err := ParseInt(v)
+	if err != nil {
+		d.Fail(field, v, err)
+		return 0
+	}
+	return x
+}
+
+// OptionalInt decodes an optional integer field. A missing or null field
+// decodes as zero.
+func (d *Decoder) OptionalInt(obj map[string]interface{}, field string) int64 {
+	v, ok := obj[field]
+	if !ok || v == nil {
+		return 0
+	}
+	x, err := ParseInt(v)
+	if err != nil {
+		d.Fail(field, v, err)
+		return 0
+	}
+	return x
+}
+
+// Bool decodes an optional bool field. A missing or null field decodes as
+// false.
+func (d *Decoder) Bool(obj map[string]interface{}, field string) bool {
+	v, ok := obj[field]
+	if !ok || v == nil {
+		return false
+	}
+	x, err := ParseBool(v)
+	if err != nil {
+		d.Fail(field, v, err)
+		return false
+	}
+	return x
+}
+
+func stringFromValue(v interface{}) (string, bool) {
+	switch s := v.(type) {
+	case string:
+		return s, true
+	case float64:
+		return strconv.FormatFloat(s, 'f', -1, 64), true
+	case int64:
+		return strconv.FormatInt(s, 10), true
+	case bool:
+		return strconv.FormatBool(s), true
+	}
+	return "", false
+}
+
+// String decodes a required string field. Numbers and bools are accepted
+// and converted to strings.
+func (d *Decoder) String(obj map[string]interface{}, field string) string {
+	v, ok := obj[field]
+	if !ok || v == nil {
+		d.Fail(field, nil, ErrMissing)
+		return ""
+	}
+	s, ok := stringFromValue(v)
+	if !ok {
+		d.Fail(field, v, ErrType)
+	}
+	return s
+}
+
+// OptionalString decodes an optional string field. A missing or null field
+// decodes as an empty string.
+func (d *Decoder) OptionalString(obj map[string]interface{}, field string) string {
+	v, ok := obj[field]
+	if !ok || v == nil {
+		return ""
+	}
+	s, ok := stringFromValue(v)
+	if !ok {
+		d.Fail(field, v, ErrType)
+	}
+	return s
+}
+
+// Object decodes a value as a JSON object. An empty array, which some
+// producers emit in place of an empty object, decodes as an empty object.
+func (d *Decoder) Object(v interface{}, field string) (map[string]interface{}, bool) {
+	switch o := v.(type) {
+	case map[string]interface{}:
+		return o, true
+	case []interface{}:
+		if len(o) == 0 {
+			return map[string]interface{}{}, true
+		}
+	case nil:
+		d.Fail(field, nil, ErrMissing)
+		return nil, false
+	}
+	d.Fail(field, v, ErrType)
+	return nil, false
+}
+
+// Array decodes a value as a JSON array. Null and an empty object, which
+// some producers emit in place of an empty array, decode as an empty array.
+func (d *Decoder) Array(v interface{}, field string) ([]interface{}, bool) {
+	switch a := v.(type) {
+	case []interface{}:
+		return a, true
+	case map[string]interface{}:
+		if len(a) == 0 {
+			return nil, true
+		}
+	case nil:
+		return nil, true
+	}
+	d.Fail(field, v, ErrType)
+	return nil, false
+}
@@ -0,0 +1,276 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package decode
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParseInt(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    int64
+		wantErr bool
+	}{
+		{"number", float64(42), 42, false},
+		{"integral number", float64(42.0), 42, false},
+		{"fractional number", float64(42.9), 0, true},
+		{"negative fractional number", float64(-0.5), 0, true},
+		{"int64", int64(-7), -7, false},
+		{"string", "42", 42, false},
+		{"padded string", " 42 ", 42, false},
+		{"hex string", "0x2a", 42, false},
+		{"true", true, 1, false},
+		{"false", false, 0, false},
+		{"empty string", "", 0, true},
+		{"non-numeric string", "twelve", 0, true},
+		{"NaN", math.NaN(), 0, true},
+		{"infinity", math.Inf(1), 0, true},
+		{"array", []interface{}{}, 0, true},
+		{"object", map[string]interface{}{}, 0, true},
+		{"null", nil, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseInt(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseInt(%#v) error = %v, want error %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseInt(%#v) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBool(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    bool
+		wantErr bool
+	}{
+		{"true", true, true, false},
+		{"false", false, false, false},
+		{"string true", "true", true, false},
+		{"string 1", "1", true, false},
+		{"string 0", "0", false, false},
+		{"empty string", "", false, false},
+		{"blank string", "  ", false, false},
+		{"number", float64(1), true, false},
+		{"zero", float64(0), false, false},
+		{"int64", int64(2), true, false},
+		{"non-bool string", "maybe", false, true},
+		{"array", []interface{}{}, false, true},
+		{"null", nil, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBool(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseBool(%#v) error = %v, want error %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseBool(%#v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// decodeObject unmarshals JSON the way that decoders' input is produced.
+func decodeObject(t *testing.T, data string) map[string]interface{} {
+	t.Helper()
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &obj); err != nil {
+		t.Fatal(err)
+	}
+	return obj
+}
+
+func TestDecoderFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		decode func(d *Decoder, obj map[string]interface{}) interface{}
+		want   interface{}
+		errs   []string // fields with errors
+	}{
+		{
+			name:   "int as string",
+			data:   `{"id": "12"}`,
+			decode: func(d *Decoder, obj map[string]interface{}) interface{} { return d.Int(obj, "id") },
+			want:   int64(12),
+		},
+		{
+			name:   "missing int",
+			data:   `{}`,
+			decode: func(d *Decoder, obj map[string]interface{}) interface{} { return d.Int(obj, "id") },
+			want:   int64(0),
+			errs:   []string{"id"},
+		},
+		{
+			name:   "null int",
+			data:   `{"id": null}`,
+			decode: func(d *Decoder, obj map[string]interface{}) interface{} { return d.Int(obj, "id") },
+			want:   int64(0),
+			errs:   []string{"id"},
+		},
+		{
+			name:   "missing optional int",
+			data:   `{}`,
+			decode: func(d *Decoder, obj map[string]interface{}) interface{} { return d.OptionalInt(obj, "slots") },
+			want:   int64(0),
+		},
+		{
+			name:   "malformed optional int",
+			data:   `{"slots": "many"}`,
+			decode: func(d *Decoder, obj map[string]interface{}) interface{} { return d.OptionalInt(obj, "slots") },
+			want:   int64(0),
+			errs:   []string{"slots"},
+		},
+		{
+			name:   "bool as number",
+			data:   `{"is_turning": 1}`,
+			decode: func(d *Decoder, obj map[string]interface{}) interface{} { return d.Bool(obj, "is_turning") },
+			want:   true,
+		},
+		{
+			name:   "missing bool",
+			data:   `{}`,
+			decode: func(d *Decoder, obj map[string]interface{}) interface{} { return d.Bool(obj, "is_turning") },
+			want:   false,
+		},
+		{
+			name:   "malformed bool",
+			data:   `{"is_turning": "maybe"}`,
+			decode: func(d *Decoder, obj map[string]interface{}) interface{} { return d.Bool(obj, "is_turning") },
+			want:   false,
+			errs:   []string{"is_turning"},
+		},
+		{
+			name:   "string as number",
+			data:   `{"name": 12}`,
+			decode: func(d *Decoder, obj map[string]interface{}) interface{} { return d.String(obj, "name") },
+			want:   "12",
+		},
+		{
+			name:   "missing string",
+			data:   `{}`,
+			decode: func(d *Decoder, obj map[string]interface{}) interface{} { return d.String(obj, "name") },
+			want:   "",
+			errs:   []string{"name"},
+		},
+		{
+			name:   "string as object",
+			data:   `{"name": {"first": "Alice"}}`,
+			decode: func(d *Decoder, obj map[string]interface{}) interface{} { return d.String(obj, "name") },
+			want:   "",
+			errs:   []string{"name"},
+		},
+		{
+			name:   "null optional string",
+			data:   `{"rig_name": null}`,
+			decode: func(d *Decoder, obj map[string]interface{}) interface{} { return d.OptionalString(obj, "rig_name") },
+			want:   "",
+		},
+		{
+			name: "object as empty array",
+			data: `{"load": []}`,
+			decode: func(d *Decoder, obj map[string]interface{}) interface{} {
+				o, ok := d.Object(obj["load"], "load")
+				return ok && len(o) == 0
+			},
+			want: true,
+		},
+		{
+			name: "object as non-empty array",
+			data: `{"load": [1]}`,
+			decode: func(d *Decoder, obj map[string]interface{}) interface{} {
+				_, ok := d.Object(obj["load"], "load")
+				return ok
+			},
+			want: false,
+			errs: []string{"load"},
+		},
+		{
+			name: "array as empty object",
+			data: `{"groups": {}}`,
+			decode: func(d *Decoder, obj map[string]interface{}) interface{} {
+				a, ok := d.Array(obj["groups"], "groups")
+				return ok && len(a) == 0
+			},
+			want: true,
+		},
+		{
+			name: "array as null",
+			data: `{"groups": null}`,
+			decode: func(d *Decoder, obj map[string]interface{}) interface{} {
+				a, ok := d.Array(obj["groups"], "groups")
+				return ok && len(a) == 0
+			},
+			want: true,
+		},
+		{
+			name: "array as string",
+			data: `{"groups": "none"}`,
+			decode: func(d *Decoder, obj map[string]interface{}) interface{} {
+				_, ok := d.Array(obj["groups"], "groups")
+				return ok
+			},
+			want: false,
+			errs: []string{"groups"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Decoder{}
+			got := tt.decode(d, decodeObject(t, tt.data))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+			var errs []string
+			for _, err := range d.Errors {
+				errs = append(errs, err.Field)
+			}
+			if !reflect.DeepEqual(errs, tt.errs) {
+				t.Errorf("errors in %q, want %q", errs, tt.errs)
+			}
+		})
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	d := &Decoder{ID: 7, Prefix: "groups[0]."}
+	obj := decodeObject(t, `{"id": "x", "is_public": true}`)
+
+	mark := d.Mark()
+	d.Bool(obj, "is_public")
+	if d.Failed(mark) {
+		t.Fatalf("Failed after decoding a valid field: %v", d.Errors)
+	}
+
+	d.Int(obj, "id")
+	if !d.Failed(mark) {
+		t.Fatal("not Failed after decoding an invalid field")
+	}
+	if len(d.Errors) != 1 {
+		t.Fatalf("got %d errors, want 1", len(d.Errors))
+	}
+	err := d.Errors[0]
+	if err.ID != 7 || err.Field != "groups[0].id" || err.Value != "x" {
+		t.Errorf("got error %+v, want ID 7, field groups[0].id, and value \"x\"", err)
+	}
+	if got, want := err.Error(), `7: groups[0].id: strconv.ParseInt: parsing "x": invalid syntax ("x")`; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	d.String(obj, "name")
+	if !errors.Is(d.Errors[1], ErrMissing) {
+		t.Errorf("missing field error %v is not ErrMissing", d.Errors[1])
+	}
+}
@@ -0,0 +1,254 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package jsonfeed
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/burble"
+	"github.com/jumptown-skydiving/manifest-server/pkg/settings"
+)
+
+// maxPushSize is the largest request body accepted by PushHandler.
+const maxPushSize = 4 << 20
+
+// Manifest is the JSON document read from the manifest file or pushed via
+// HTTP. Loads use the same JSON encoding as burble.Load, and are expected to
+// be ordered by call time, already grouped and marked as turning as desired.
+type Manifest struct {
+	ColumnCount int            `json:"column_count"`
+	Loads       []*burble.Load `json:"loads"`
+}
+
+type UpdateFunc func()
+
+// Controller is a manifest source that reads loads from a JSON file and/or
+// accepts loads pushed to it via HTTP. Whichever was most recently received
+// is used.
+type Controller struct {
+	settings *settings.Settings
+	filename string
+	update   UpdateFunc
+
+	lock        sync.Mutex
+	manifest    *Manifest
+	modTime     time.Time
+	columnCount int
+	loads       []*burble.Load
+	allLoads    []*burble.Load
+
+	lastRefresh time.Time
+	lastError   error
+}
+
+func NewController(
+	settings *settings.Settings,
+	update UpdateFunc,
+) *Controller {
+	return &Controller{
+		settings: settings,
+		filename: settings.ManifestFilename(),
+		update:   update,
+	}
+}
+
+func (c *Controller) decodeManifest(data []byte) (*Manifest, error) {
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	rules, err := burble.CompileRules(c.settings.JumperRules())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid jumper rule: %v\n", err)
+	}
+	for i, l := range m.Loads {
+		if l == nil {
+			return nil, fmt.Errorf("load %d is null", i)
+		}
+		l.AircraftName = c.settings.CanonicalAircraftName(l.AircraftName)
+		rules.ApplyToLoad(l)
+	}
+	return &m, nil
+}
+
+// readFile reads the manifest file if it has changed since it was last read.
+func (c *Controller) readFile() error {
+	if c.filename == "" {
+		return nil
+	}
+
+	info, err := os.Stat(c.filename)
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	modTime := c.modTime
+	c.lock.Unlock()
+	if info.ModTime().Equal(modTime) {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(c.filename)
+	if err != nil {
+		return err
+	}
+	m, err := c.decodeManifest(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", c.filename, err)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.manifest = m
+	c.modTime = info.ModTime()
+	return nil
+}
+
+// apply filters the most recently received manifest according to the current
+// display options and reports whether the resulting loads have changed.
+func (c *Controller) apply() bool {
+	displayColumns := c.settings.DisplayColumns()
+	minCallMinutes := c.settings.MinCallMinutes()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	columnCount := displayColumns
+	var loads, allLoads []*burble.Load
+	if m := c.manifest; m != nil {
+		if m.ColumnCount > 0 {
+			columnCount = m.ColumnCount
+		}
+		allLoads = m.Loads
+		for _, l := range m.Loads {
+			if int(l.CallMinutes) >= minCallMinutes {
+				loads = append(loads, l)
+				if len(loads) >= columnCount {
+					break
+				}
+			}
+		}
+	}
+
+	changed := false
+	if c.columnCount != columnCount {
+		c.columnCount = columnCount
+		changed = true
+	}
+	if !reflect.DeepEqual(c.loads, loads) {
+		c.loads = loads
+		changed = true
+	}
+	if !reflect.DeepEqual(c.allLoads, allLoads) {
+		c.allLoads = allLoads
+		changed = true
+	}
+	return changed
+}
+
+// Refresh re-reads the manifest file if it has changed.
+func (c *Controller) Refresh() (bool, error) {
+	err := c.readFile()
+	changed := c.apply()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err == nil {
+		c.lastRefresh = time.Now()
+	}
+	c.lastError = err
+
+	return changed, err
+}
+
+func (c *Controller) Loads() []*burble.Load {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.loads
+}
+
+// AllLoads returns all of the loads in the manifest, including those that
+// are not displayed because of the display options.
+func (c *Controller) AllLoads() []*burble.Load {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.allLoads
+}
+
+func (c *Controller) ColumnCount() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.columnCount
+}
+
+// Health returns the time of the last successful refresh or push and the
+// error from the most recent one, if any.
+func (c *Controller) Health() (time.Time, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lastRefresh, c.lastError
+}
+
+// Now returns the current time. The loads read or pushed are always current.
+func (c *Controller) Now() time.Time {
+	return time.Now()
+}
+
+// authorized reports whether req carries the push token. Pushes are never
+// authorized if no token is configured.
+func (c *Controller) authorized(req *http.Request) bool {
+	token := c.settings.ManifestPushToken()
+	if token == "" {
+		return false
+	}
+	got := []byte(req.Header.Get("Authorization"))
+	want := []byte("Bearer " + token)
+	return subtle.ConstantTimeCompare(got, want) == 1
+}
+
+// PushHandler accepts a Manifest pushed via an HTTP POST request bearing the
+// push token. The pushed manifest replaces whatever was previously read or
+// pushed.
+func (c *Controller) PushHandler(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost && req.Method != http.MethodPut {
+		w.Header().Set("Allow", "POST, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !c.authorized(req) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	data, err := ioutil.ReadAll(http.MaxBytesReader(w, req.Body, maxPushSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	m, err := c.decodeManifest(data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot decode pushed manifest: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c.lock.Lock()
+	c.manifest = m
+	c.lastRefresh = time.Now()
+	c.lastError = nil
+	c.lock.Unlock()
+
+	if c.apply() && c.update != nil {
+		c.update()
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
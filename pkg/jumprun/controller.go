@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/jumptown-skydiving/manifest-server/pkg/settings"
+	"github.com/jumptown-skydiving/manifest-server/pkg/webauth"
 )
 
 type UpdateFunc func()
@@ -237,8 +238,15 @@ func (c *Controller) HTML(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	page := struct {
+		Jumprun
+		CSRFToken string
+	}{
+		Jumprun:   j,
+		CSRFToken: webauth.CSRFToken(req),
+	}
 	b := &bytes.Buffer{}
-	if err := tmpl.Execute(b, &j); err != nil {
+	if err := tmpl.Execute(b, &page); err != nil {
 		http.NotFound(w, req)
 		return
 	}
@@ -249,6 +257,11 @@ func (c *Controller) HTML(w http.ResponseWriter, req *http.Request) {
 }
 
 func (c *Controller) FormHandler(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
 	contentType := req.Header.Get("content-type")
 	if strings.HasPrefix(contentType, "multipart/form-data") {
 		if err := req.ParseMultipartForm(32 << 20); err != nil {
@@ -285,7 +298,8 @@ const jumprunHTML = `<html>
 		</script>
 	</head>
 	<body>
-		<form action="/setjumprun" id="jumprun" method="post">
+		<form action="setjumprun" id="jumprun" method="post">
+			<input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
 			<div>
 				All headings are relative to magentic north. All distances are
 				specified in tenths of a mile (e.g. 1 is 1/10 mile, 5 is
@@ -357,6 +371,10 @@ const jumprunHTML = `<html>
 				<button type="submit">Submit</button>
 			</div>
 		</form>
+		<form action="logout" method="post">
+			<input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
+			<button type="submit">Sign Out</button>
+		</form>
 		<script>
 		var form = document.getElementById("jumprun");
 		form.addEventListener("submit", function (e) {
@@ -12,8 +12,10 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/jumptown-skydiving/manifest-server/pkg/settings"
+	"github.com/jumptown-skydiving/manifest-server/pkg/throttle"
 )
 
 // FahrenheitFromCelsius converts a temperature from Celsius to Fahrenheit.
@@ -133,6 +135,9 @@ type Controller struct {
 	fields      map[string]interface{}
 	skyCover    string
 	wxCondition string
+
+	// hint tracks the refresh delays requested by the server.
+	hint throttle.Hint
 }
 
 func NewController(settings *settings.Settings) *Controller {
@@ -141,6 +146,12 @@ func NewController(settings *settings.Settings) *Controller {
 	}
 }
 
+// NotBefore returns the earliest time at which the server has asked to be
+// queried again.
+func (c *Controller) NotBefore() time.Time {
+	return c.hint.NotBefore()
+}
+
 const metarURL = "https://aviationweather.gov/cgi-bin/data/dataserver.php?datasource=metars&requesttype=retrieve&format=csv&hoursBeforeNow=24&mostRecent=true"
 
 // Refresh retrieves and parses weather data.
@@ -152,6 +163,11 @@ func (c *Controller) Refresh() (bool, error) {
 	}
 	defer resp.Body.Close()
 
+	c.hint.Update(resp)
+	if err = throttle.CheckResponse(resp); err != nil {
+		return false, err
+	}
+
 	data, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return false, err
@@ -266,36 +282,28 @@ func (c *Controller) Refresh() (bool, error) {
 	return changed, nil
 }
 
-// WindSpeedMPH returns the current wind speed in MPH.
-func (c *Controller) WindSpeedMPH() float64 {
+// knots returns the value of a field that is a speed in knots.
+func (c *Controller) knots(field string) float64 {
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	var speed float64
-	switch v := c.fields["wind_speed_kt"].(type) {
+	switch v := c.fields[field].(type) {
 	case float64:
-		speed = v
+		return v
 	case int64:
-		speed = float64(v)
+		return float64(v)
 	default:
 		return 0.0
 	}
-	return MPHFromKnots(speed)
+}
+
+// WindSpeedMPH returns the current wind speed in MPH.
+func (c *Controller) WindSpeedMPH() float64 {
+	return MPHFromKnots(c.knots("wind_speed_kt"))
 }
 
 // WindGustSpeedMPH returns current wind gust speed in MPH.
 func (c *Controller) WindGustSpeedMPH() float64 {
-	c.lock.Lock()
-	defer c.lock.Unlock()
-	var gusting float64
-	switch v := c.fields["wind_gust_kt"].(type) {
-	case float64:
-		gusting = v
-	case int64:
-		gusting = float64(v)
-	default:
-		return 0.0
-	}
-	return MPHFromKnots(gusting)
+	return MPHFromKnots(c.knots("wind_gust_kt"))
 }
 
 // WindDirectionDegrees returns the current wind direction in degrees.
@@ -318,7 +326,13 @@ func (c *Controller) WindDirectionDegrees() float64 {
 
 // WindConditions returns the current wind conditions as a human-readable string.
 func (c *Controller) WindConditions() string {
-	speed := c.WindSpeedMPH()
+	return c.WindConditionsIn(DefaultUnits)
+}
+
+// WindConditionsIn returns the current wind conditions as a human-readable
+// string expressed in the specified units.
+func (c *Controller) WindConditionsIn(units Units) string {
+	speed := c.knots("wind_speed_kt")
 	if speed <= 0 {
 		return "light and variable"
 	}
@@ -326,14 +340,14 @@ func (c *Controller) WindConditions() string {
 	windDirectionDegrees := c.WindDirectionDegrees()
 	windDirection := CardinalDirection(windDirectionDegrees)
 
-	gusting := c.WindGustSpeedMPH()
+	gusting := c.knots("wind_gust_kt")
 	if gusting > 0 {
-		return fmt.Sprintf("%d MPH gusting to %d MPH from %d° (%s)",
-			int64(speed), int64(gusting),
+		return fmt.Sprintf("%s gusting to %s from %d° (%s)",
+			FormatSpeed(speed, units), FormatSpeed(gusting, units),
 			int64(windDirectionDegrees), windDirection)
 	}
-	return fmt.Sprintf("%d MPH from %d° (%s)",
-		int64(speed), int64(windDirectionDegrees), windDirection)
+	return fmt.Sprintf("%s from %d° (%s)",
+		FormatSpeed(speed, units), int64(windDirectionDegrees), windDirection)
 }
 
 // WeatherConditions returns a human-readable description of current weather
@@ -359,6 +373,12 @@ func (c *Controller) SkyCover() string {
 
 // TemperatureString returns a human-readable temperature string
 func (c *Controller) TemperatureString() string {
+	return c.TemperatureStringIn(DefaultUnits)
+}
+
+// TemperatureStringIn returns a human-readable temperature string expressed
+// in the specified units.
+func (c *Controller) TemperatureStringIn(units Units) string {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 	var temp float64
@@ -371,8 +391,7 @@ func (c *Controller) TemperatureString() string {
 		return "data error"
 	}
 
-	return fmt.Sprintf("%d℃ / %d℉",
-		int64(temp), int64(FahrenheitFromCelsius(temp)))
+	return FormatTemperature(temp, units)
 }
 
 func (c *Controller) Location() (float64, float64, bool) {
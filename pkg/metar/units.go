@@ -0,0 +1,44 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package metar
+
+import "fmt"
+
+// Units selects the units in which human-readable strings are expressed.
+type Units int
+
+const (
+	DefaultUnits  Units = iota // MPH, with temperatures in both ℃ and ℉
+	ImperialUnits              // MPH and ℉
+	MetricUnits                // km/h and ℃
+	NauticalUnits              // knots and ℃
+)
+
+// KPHFromKnots converts a speed from knots to kilometers per hour.
+func KPHFromKnots(kts float64) float64 {
+	return kts * 1.852
+}
+
+// FormatSpeed formats a speed given in knots.
+func FormatSpeed(kts float64, units Units) string {
+	switch units {
+	case MetricUnits:
+		return fmt.Sprintf("%d km/h", int64(KPHFromKnots(kts)))
+	case NauticalUnits:
+		return fmt.Sprintf("%d kts", int64(kts))
+	default:
+		return fmt.Sprintf("%d MPH", int64(MPHFromKnots(kts)))
+	}
+}
+
+// FormatTemperature formats a temperature given in Celsius.
+func FormatTemperature(c float64, units Units) string {
+	switch units {
+	case ImperialUnits:
+		return fmt.Sprintf("%d℉", int64(FahrenheitFromCelsius(c)))
+	case MetricUnits, NauticalUnits:
+		return fmt.Sprintf("%d℃", int64(c))
+	default:
+		return fmt.Sprintf("%d℃ / %d℉", int64(c), int64(FahrenheitFromCelsius(c)))
+	}
+}
@@ -0,0 +1,46 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import (
+	"github.com/jumptown-skydiving/manifest-server/pkg/announce"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func translateAnnouncement(a announce.Announcement) *Announcement {
+	return &Announcement{
+		Id:           a.ID,
+		Time:         a.Time.Unix(),
+		LoadId:       uint64(a.LoadID),
+		AircraftName: a.AircraftName,
+		LoadNumber:   a.LoadNumber,
+		CallMinutes:  int32(a.CallMinutes),
+		Jumpers:      a.Jumpers,
+		Text:         a.Text,
+	}
+}
+
+func (s *manifestServiceServer) StreamAnnouncements(
+	_ *emptypb.Empty,
+	stream ManifestService_StreamAnnouncementsServer,
+) error {
+	c := make(chan []announce.Announcement, 16)
+	id := s.app.AddAnnouncementListener(c)
+	defer s.app.RemoveAnnouncementListener(id)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-s.app.Done():
+			return nil
+		case announcements := <-c:
+			for _, a := range announcements {
+				if err := stream.Send(translateAnnouncement(a)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
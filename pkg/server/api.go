@@ -0,0 +1,294 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// apiPrefix is the path beneath which the JSON API is found.
+const apiPrefix = "/api/v1"
+
+// sseKeepaliveInterval is how often a comment is sent to Server-Sent Events
+// clients while there are no updates, so that proxies do not close idle
+// streams.
+const sseKeepaliveInterval = 15 * time.Second
+
+var apiMarshalOptions = protojson.MarshalOptions{
+	UseProtoNames: true,
+}
+
+// registerAPIContent registers the JSON API for each dropzone. Like the web
+// pages, each dropzone's API is found beneath a path named for its ID, and
+// the default dropzone's API is also found at the top level.
+func (s *WebServer) registerAPIContent() {
+	for _, id := range s.dropzones.ids {
+		ms := s.dropzones.servers[id]
+		isDefault := id == s.dropzones.defaultID
+		setContentFunc := func(path string, f WebContentFunc) {
+			if id != "" {
+				s.SetContentFunc("/"+id+apiPrefix+path, f)
+			}
+			if isDefault {
+				s.SetContentFunc(apiPrefix+path, f)
+			}
+		}
+
+		setContentFunc("/manifest", ms.apiManifestHandler(UpdateSection_SECTION_UNSPECIFIED))
+		for section := range sectionSources {
+			setContentFunc("/"+apiSectionName(section), ms.apiManifestHandler(section))
+		}
+		setContentFunc("/stream", ms.apiStreamHandler)
+
+		if id != "" {
+			s.SetWebSocketFunc("/"+id+apiPrefix+"/ws", ms.apiWebSocketHandler)
+		}
+		if isDefault {
+			s.SetWebSocketFunc(apiPrefix+"/ws", ms.apiWebSocketHandler)
+		}
+	}
+}
+
+// apiSectionName returns the name by which a section is known in the JSON
+// API, e.g. "winds_aloft" for SECTION_WINDS_ALOFT.
+func apiSectionName(section UpdateSection) string {
+	return strings.ToLower(strings.TrimPrefix(section.String(), "SECTION_"))
+}
+
+// parseAPISections parses the sections named by the "section" query
+// parameter, which may be repeated or contain comma-separated names.
+func parseAPISections(values []string) ([]UpdateSection, error) {
+	var sections []UpdateSection
+	for _, value := range values {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" {
+				continue
+			}
+			found := false
+			for section := range sectionSources {
+				if apiSectionName(section) == name {
+					sections = append(sections, section)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("unknown section %q", name)
+			}
+		}
+	}
+	return sections, nil
+}
+
+// parseAPISubscription parses a subscription from the "section", "aircraft",
+// "max_loads", and "units" query parameters.
+func parseAPISubscription(query url.Values) (*UpdateSubscription, error) {
+	sections, err := parseAPISections(query["section"])
+	if err != nil {
+		return nil, err
+	}
+	sub := &UpdateSubscription{
+		Sections: sections,
+		Aircraft: query["aircraft"],
+	}
+	if value := query.Get("max_loads"); value != "" {
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid max_loads %q", value)
+		}
+		sub.MaxLoads = int32(n)
+	}
+	if value := query.Get("units"); value != "" {
+		units, ok := Units_value["UNITS_"+strings.ToUpper(value)]
+		if !ok {
+			return nil, fmt.Errorf("unknown units %q", value)
+		}
+		sub.Units = Units(units)
+	}
+	return sub, nil
+}
+
+// sectionMessage returns the message for a section of an update. It returns
+// false if the section is not set.
+func sectionMessage(u *ManifestUpdate, section UpdateSection) (proto.Message, bool) {
+	switch section {
+	case UpdateSection_SECTION_STATUS:
+		return u.Status, u.Status != nil
+	case UpdateSection_SECTION_OPTIONS:
+		return u.Options, u.Options != nil
+	case UpdateSection_SECTION_JUMPRUN:
+		return u.Jumprun, u.Jumprun != nil
+	case UpdateSection_SECTION_WINDS_ALOFT:
+		return u.WindsAloft, u.WindsAloft != nil
+	case UpdateSection_SECTION_LOADS:
+		return u.Loads, u.Loads != nil
+	case UpdateSection_SECTION_FUEL_REQUESTS:
+		return u.FuelRequests, u.FuelRequests != nil
+	case UpdateSection_SECTION_SOURCE_HEALTH:
+		return u.SourceHealth, u.SourceHealth != nil
+	}
+	return nil, false
+}
+
+// contentETag returns the entity tag for content. It is a hash of the
+// content rather than the sequence of the update from which the content was
+// derived, so that content showing only part of the update keeps its tag
+// when other parts change.
+func contentETag(content []byte) string {
+	sum := sha256.Sum256(content)
+	return fmt.Sprintf(`"%x"`, sum[:16])
+}
+
+// etagMatches reports whether an If-None-Match header value matches etag.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// apiManifestHandler returns a handler that responds with the JSON encoding
+// of the complete current update, or of only the given section if it is not
+// SECTION_UNSPECIFIED. The update may be restricted by "section" and
+// "aircraft" query parameters, as for GetManifest.
+func (s *manifestServiceServer) apiManifestHandler(section UpdateSection) WebContentFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		query := req.URL.Query()
+		request := &GetManifestRequest{
+			Aircraft: query["aircraft"],
+		}
+		if section != UpdateSection_SECTION_UNSPECIFIED {
+			request.Sections = []UpdateSection{section}
+		} else {
+			sections, err := parseAPISections(query["section"])
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			request.Sections = sections
+		}
+
+		response, err := s.GetManifest(req.Context(), request)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		var m proto.Message = response.Update
+		if section != UpdateSection_SECTION_UNSPECIFIED {
+			var ok bool
+			if m, ok = sectionMessage(response.Update, section); !ok {
+				http.Error(w, apiSectionName(section)+" is not available",
+					http.StatusNotFound)
+				return
+			}
+		}
+
+		data, err := apiMarshalOptions.Marshal(m)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		h := w.Header()
+		etag := contentETag(data)
+		h.Set("ETag", etag)
+		h.Set("Cache-Control", "no-cache")
+		if etagMatches(req.Header.Get("If-None-Match"), etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		h.Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = w.Write(data)
+	}
+}
+
+// apiStreamHandler streams updates as Server-Sent Events. Like a
+// StreamUpdates client, an SSE client is first sent the complete current
+// update and is then sent each change, subject to the slow client policy.
+// Each event's ID is the sequence of the update.
+func (s *manifestServiceServer) apiStreamHandler(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// The web server's write timeout would end the stream, so instead
+	// the deadline is extended before each write.
+	rc := http.NewResponseController(w)
+	write := func(format string, args ...interface{}) error {
+		if err := rc.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, format, args...); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-store")
+	h.Set("X-Accel-Buffering", "no")
+	if err := write(": connected\n\n"); err != nil {
+		return
+	}
+
+	ctx := req.Context()
+	c := newUpdateClient(ctx)
+	c.peer = req.RemoteAddr
+	s.addClient(c)
+	defer s.removeClient(c)
+
+	keepalive := time.NewTicker(sseKeepaliveInterval)
+	defer keepalive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.app.Done():
+			return
+		case <-c.disconnected:
+			_ = write("event: error\ndata: client is too slow to receive updates\n\n")
+			return
+		case <-keepalive.C:
+			if err := write(": keepalive\n\n"); err != nil {
+				return
+			}
+		case <-c.ready:
+			u := c.take(s.snapshot)
+			if u == nil {
+				continue
+			}
+			data, err := apiMarshalOptions.Marshal(u)
+			if err == nil {
+				err = write("id: %d\nevent: update\ndata: %s\n\n", u.Sequence, data)
+			}
+			c.sendDone()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
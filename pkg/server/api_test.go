@@ -0,0 +1,178 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseAPISubscription(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    *UpdateSubscription
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			query: "",
+			want:  &UpdateSubscription{},
+		},
+		{
+			name:  "sections",
+			query: "section=loads,%20Winds_Aloft&section=status&section=",
+			want: &UpdateSubscription{Sections: []UpdateSection{
+				UpdateSection_SECTION_LOADS,
+				UpdateSection_SECTION_WINDS_ALOFT,
+				UpdateSection_SECTION_STATUS,
+			}},
+		},
+		{
+			name:  "aircraft and max loads",
+			query: "aircraft=Otter&aircraft=King+Air&max_loads=3",
+			want:  &UpdateSubscription{Aircraft: []string{"Otter", "King Air"}, MaxLoads: 3},
+		},
+		{
+			name:  "units",
+			query: "units=Metric",
+			want:  &UpdateSubscription{Units: Units_UNITS_METRIC},
+		},
+		{name: "unknown section", query: "section=weather", wantErr: true},
+		{name: "unspecified section", query: "section=unspecified", wantErr: true},
+		{name: "negative max loads", query: "max_loads=-1", wantErr: true},
+		{name: "malformed max loads", query: "max_loads=all", wantErr: true},
+		{name: "max loads out of range", query: "max_loads=4294967296", wantErr: true},
+		{name: "unknown units", query: "units=furlongs", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := parseAPISubscription(query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAPISubscription(%q) error = %v, want error %v", tt.query, err, tt.wantErr)
+			}
+			if err == nil && !proto.Equal(got, tt.want) {
+				t.Errorf("parseAPISubscription(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestETagMatches(t *testing.T) {
+	etag := contentETag([]byte("hello"))
+	if etag != `"2cf24dba5fb0a30e26e83b2ac5b9e29e"` {
+		t.Fatalf("contentETag() = %s, want the first half of the SHA-256 sum", etag)
+	}
+	other := contentETag([]byte("goodbye"))
+
+	tests := []struct {
+		ifNoneMatch string
+		want        bool
+	}{
+		{``, false},
+		{etag, true},
+		{`W/` + etag, true},
+		{other + `, ` + etag, true},
+		{other + `,W/` + etag, true},
+		{`*`, true},
+		{other, false},
+		{strings.Trim(etag, `"`), false},
+		{strings.ToUpper(etag), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ifNoneMatch, func(t *testing.T) {
+			if got := etagMatches(tt.ifNoneMatch, etag); got != tt.want {
+				t.Errorf("etagMatches(%q, %q) = %v, want %v", tt.ifNoneMatch, etag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIManifestHandlerETag(t *testing.T) {
+	s := testServer(t)
+	get := func(section UpdateSection, target, ifNoneMatch string) *http.Response {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		s.apiManifestHandler(section)(w, req)
+		return w.Result()
+	}
+
+	endpoints := []struct {
+		name    string
+		section UpdateSection
+		target  string
+	}{
+		{"manifest", UpdateSection_SECTION_UNSPECIFIED, "/manifest"},
+		{"section", UpdateSection_SECTION_OPTIONS, "/options"},
+		{"aircraft", UpdateSection_SECTION_LOADS, "/loads?aircraft=King+Air"},
+	}
+	// The manifest includes the sequence of the latest change, so its
+	// ETag changes with every change, but the sections do not.
+	tests := []struct {
+		name   string
+		change func(u *ManifestUpdate)
+		want   map[string]bool // whether each endpoint's ETag changes
+	}{
+		{
+			name:   "status",
+			change: func(u *ManifestUpdate) { u.Status.Winds = "gusty" },
+			want:   map[string]bool{"manifest": true},
+		},
+		{
+			name:   "options",
+			change: func(u *ManifestUpdate) { u.Options.Message = "goodbye" },
+			want:   map[string]bool{"manifest": true, "section": true},
+		},
+		{
+			name:   "another aircraft's load",
+			change: func(u *ManifestUpdate) { u.Loads.Loads[0].CallMinutes = 5 },
+			want:   map[string]bool{"manifest": true},
+		},
+		{
+			name:   "aircraft's load",
+			change: func(u *ManifestUpdate) { u.Loads.Loads[1].CallMinutes = 5 },
+			want:   map[string]bool{"manifest": true, "aircraft": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTestUpdate(s, func(*ManifestUpdate) {})
+			etags := make(map[string]string)
+			for _, e := range endpoints {
+				resp := get(e.section, e.target, "")
+				if resp.StatusCode != http.StatusOK {
+					t.Fatalf("%s: status %d, want %d", e.name, resp.StatusCode, http.StatusOK)
+				}
+				etags[e.name] = resp.Header.Get("ETag")
+			}
+
+			setTestUpdate(s, func(u *ManifestUpdate) {
+				tt.change(u)
+				u.Sequence++
+			})
+			for _, e := range endpoints {
+				resp := get(e.section, e.target, etags[e.name])
+				changed := resp.StatusCode != http.StatusNotModified
+				if changed != tt.want[e.name] {
+					t.Errorf("%s: status %d, want ETag changed %v", e.name, resp.StatusCode, tt.want[e.name])
+				}
+				if changed && resp.Header.Get("ETag") == etags[e.name] {
+					t.Errorf("%s: ETag unchanged with status %d", e.name, resp.StatusCode)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,399 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// The manifest board is a full-screen page for displays such as TVs. The
+// board itself is rendered by the server; the page only replaces it with a
+// newly rendered one whenever the update stream reports a change.
+const (
+	boardPath        = "/board"
+	boardContentPath = "/board/content"
+	boardImagePath   = "/board.png"
+)
+
+// registerBoardContent registers the manifest board for each dropzone. Like
+// the JSON API, each dropzone's board is found beneath a path named for its
+// ID, and the default dropzone's board is also found at the top level. The
+// page finds its stream relative to its own path.
+func (s *WebServer) registerBoardContent() {
+	for _, id := range s.dropzones.ids {
+		ms := s.dropzones.servers[id]
+		isDefault := id == s.dropzones.defaultID
+		setContentFunc := func(path string, f WebContentFunc) {
+			if id != "" {
+				s.SetContentFunc("/"+id+path, f)
+			}
+			if isDefault {
+				s.SetContentFunc(path, f)
+			}
+		}
+
+		setContentFunc(boardPath, ms.boardHandler(boardTemplate))
+		setContentFunc(boardContentPath, ms.boardHandler(boardTemplate.Lookup("board")))
+		setContentFunc(boardImagePath, ms.boardImageHandler)
+	}
+}
+
+// boardText is text displayed in a color.
+type boardText struct {
+	Text  string
+	Color uint32
+}
+
+// CSSColor returns the CSS color in which the text is displayed.
+func (t boardText) CSSColor() string {
+	return fmt.Sprintf("#%06x", t.Color&0xffffff)
+}
+
+type boardLoad struct {
+	Title          string
+	CallMinutes    string
+	SlotsAvailable string
+	IsFueling      bool
+	Jumpers        []boardJumper
+}
+
+type boardJumper struct {
+	boardText
+	IsGroupMember bool
+}
+
+type boardWinds struct {
+	Altitude    string
+	Heading     string
+	Speed       string
+	Temperature string
+}
+
+// boardLayout is the layout of the board requested by a display.
+type boardLayout struct {
+	columns  int     // 0 for the configured number of loads
+	scale    float64 // of the size of the text
+	aircraft []string
+}
+
+// parseBoardLayout parses the layout of the board from the "columns",
+// "scale", and "aircraft" query parameters.
+func parseBoardLayout(query url.Values) (*boardLayout, error) {
+	layout := &boardLayout{
+		scale:    1,
+		aircraft: query["aircraft"],
+	}
+	if value := query.Get("columns"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid columns %q", value)
+		}
+		layout.columns = n
+	}
+	if value := query.Get("scale"); value != "" {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || !(f > 0 && f <= 10) {
+			return nil, fmt.Errorf("invalid scale %q", value)
+		}
+		layout.scale = f
+	}
+	return layout, nil
+}
+
+type boardData struct {
+	StreamPath string
+	FontSize   string
+	Columns    int
+
+	Status  []boardText
+	Message boardText
+	Sunrise string
+	Sunset  string
+	Winds   []boardWinds
+	Loads   []boardLoad
+}
+
+// boardJumpers returns the jumpers in a load slot in the order in which they
+// are displayed. Group members' Repr strings begin with a tab so that they
+// may be indented, which the board does with style instead.
+func boardJumpers(slot *LoadSlot) []boardJumper {
+	newJumper := func(j *Jumper) boardJumper {
+		return boardJumper{
+			boardText: boardText{
+				Text:  strings.TrimPrefix(j.Repr, "\t"),
+				Color: j.Color,
+			},
+			IsGroupMember: strings.HasPrefix(j.Repr, "\t"),
+		}
+	}
+
+	if j := slot.GetJumper(); j != nil {
+		return []boardJumper{newJumper(j)}
+	}
+	g := slot.GetGroup()
+	if g == nil {
+		return nil
+	}
+	jumpers := []boardJumper{newJumper(g.Leader)}
+	for _, member := range g.Members {
+		jumpers = append(jumpers, newJumper(member))
+	}
+	return jumpers
+}
+
+// newBoardData returns the data from which the board is rendered for an
+// update, displaying no more than columns loads if columns is not 0.
+func newBoardData(u *ManifestUpdate, columns int) *boardData {
+	data := &boardData{
+		StreamPath: strings.TrimPrefix(apiPrefix, "/") + "/stream",
+	}
+
+	o := u.Options
+	if o == nil {
+		o = &Options{}
+	}
+	if o.Message != "" {
+		data.Message = boardText{
+			Text:  o.Message,
+			Color: o.MessageColor,
+		}
+	}
+	data.Sunrise = o.Sunrise
+	data.Sunset = o.Sunset
+
+	if st := u.Status; st != nil && o.DisplayWeather {
+		for _, t := range []boardText{
+			{Text: st.Winds, Color: st.WindsColor},
+			{Text: st.Clouds, Color: st.CloudsColor},
+			{Text: st.Weather, Color: st.WeatherColor},
+			{Text: st.Temperature, Color: st.TemperatureColor},
+			{Text: st.Separation, Color: st.SeparationColor},
+		} {
+			if t.Text != "" {
+				data.Status = append(data.Status, t)
+			}
+		}
+	}
+
+	if w := u.WindsAloft; w != nil && o.DisplayWinds {
+		for _, sample := range w.Samples {
+			winds := boardWinds{
+				Altitude:    fmt.Sprintf("%dK", sample.Altitude/1000),
+				Temperature: fmt.Sprintf("%d℃", sample.Temperature),
+			}
+			if sample.Variable {
+				winds.Heading = "L&V"
+			} else {
+				winds.Heading = fmt.Sprintf("%03d°", sample.Heading)
+				winds.Speed = fmt.Sprintf("%d kts", sample.Speed)
+			}
+			data.Winds = append(data.Winds, winds)
+		}
+	}
+
+	if loads := u.Loads; loads != nil {
+		data.Columns = int(loads.ColumnCount)
+		if columns > 0 {
+			data.Columns = columns
+		}
+		for _, l := range loads.Loads {
+			if len(data.Loads) >= data.Columns {
+				break
+			}
+			load := boardLoad{
+				Title:          strings.TrimSpace(l.AircraftName + " " + l.LoadNumber),
+				SlotsAvailable: l.SlotsAvailableString,
+				IsFueling:      l.IsFueling,
+			}
+			switch l.CallMinutesString {
+			case "", "NOW":
+				load.CallMinutes = l.CallMinutesString
+			default:
+				load.CallMinutes = l.CallMinutesString + " min"
+			}
+			for _, slot := range l.Slots {
+				load.Jumpers = append(load.Jumpers, boardJumpers(slot)...)
+			}
+			data.Loads = append(data.Loads, load)
+		}
+	}
+	if data.Columns < 1 {
+		data.Columns = 1
+	}
+
+	return data
+}
+
+// boardFontSize is the size of the board's text relative to the height of
+// the display, before it is scaled.
+const boardFontSize = 0.02
+
+// boardHandler returns a handler that renders the board with tmpl. The
+// layout is configured by query parameters, so that each display may be
+// configured by its URL: "columns" is the number of loads to display,
+// "scale" scales the size of the text, and "aircraft", which may be
+// repeated, restricts the loads displayed to those of the named aircraft.
+func (s *manifestServiceServer) boardHandler(tmpl *template.Template) WebContentFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		layout, err := parseBoardLayout(req.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		response, err := s.GetManifest(req.Context(), &GetManifestRequest{
+			Aircraft: layout.aircraft,
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		data := newBoardData(response.Update, layout.columns)
+		data.FontSize = fmt.Sprintf("%.2fvh", 100*boardFontSize*layout.scale)
+
+		b := &bytes.Buffer{}
+		if err := tmpl.Execute(b, data); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		h := w.Header()
+		etag := contentETag(b.Bytes())
+		h.Set("ETag", etag)
+		h.Set("Cache-Control", "no-cache")
+		if etagMatches(req.Header.Get("If-None-Match"), etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		h.Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(b.Bytes())
+	}
+}
+
+var boardTemplate = template.Must(template.New("page").Parse(boardHTML))
+
+const boardHTML = `{{define "board"}}
+	{{if .Status}}
+	<div class="status">
+		{{range .Status}}<span style="color: {{.CSSColor}}">{{.Text}}</span>{{end}}
+	</div>
+	{{end}}
+	{{if .Message.Text}}
+	<div class="message" style="color: {{.Message.CSSColor}}">{{.Message.Text}}</div>
+	{{end}}
+	{{if or .Sunrise .Sunset}}
+	<div class="sun">
+		{{if .Sunrise}}<span>{{.Sunrise}}</span>{{end}}
+		{{if .Sunset}}<span>{{.Sunset}}</span>{{end}}
+	</div>
+	{{end}}
+	<div class="main">
+		<div class="loads" style="grid-template-columns: repeat({{.Columns}}, minmax(0, 1fr))">
+			{{range .Loads}}
+			<div class="load">
+				<div class="title">{{.Title}}{{if .IsFueling}} ⛽{{end}}</div>
+				<div class="call">
+					<span>{{.CallMinutes}}</span>
+					<span>{{.SlotsAvailable}}</span>
+				</div>
+				{{range .Jumpers}}
+				<div class="jumper{{if .IsGroupMember}} member{{end}}" style="color: {{.CSSColor}}">{{.Text}}</div>
+				{{end}}
+			</div>
+			{{end}}
+		</div>
+		{{if .Winds}}
+		<table class="winds">
+			<tr><th>Alt</th><th>Dir</th><th>Speed</th><th>Temp</th></tr>
+			{{range .Winds}}
+			<tr>
+				<td>{{.Altitude}}</td>
+				<td>{{.Heading}}</td>
+				<td>{{.Speed}}</td>
+				<td>{{.Temperature}}</td>
+			</tr>
+			{{end}}
+		</table>
+		{{end}}
+	</div>
+{{end}}<!DOCTYPE html>
+<html>
+<head>
+	<meta charset="utf-8">
+	<meta name="viewport" content="width=device-width, initial-scale=1">
+	<title>Manifest</title>
+	<style>
+	html, body { margin: 0; height: 100%; overflow: hidden; }
+	body {
+		background: black;
+		color: white;
+		font-family: "Helvetica Neue", Helvetica, Arial, sans-serif;
+		font-size: {{.FontSize}};
+	}
+	#board { display: flex; flex-direction: column; height: 100%; padding: 0.5em; box-sizing: border-box; }
+	.status, .sun { display: flex; justify-content: space-around; padding-bottom: 0.3em; }
+	.message { text-align: center; font-size: 1.2em; padding-bottom: 0.3em; }
+	.main { display: flex; flex: 1; min-height: 0; gap: 1em; }
+	.loads { display: grid; flex: 1; gap: 0.5em; align-content: start; }
+	.load { overflow: hidden; }
+	.title { font-weight: bold; font-size: 1.2em; border-bottom: 1px solid #888; }
+	.call { display: flex; justify-content: space-between; padding-bottom: 0.3em; }
+	.jumper { white-space: nowrap; overflow: hidden; text-overflow: ellipsis; }
+	.member { padding-left: 1.5em; }
+	.winds { align-self: start; border-collapse: collapse; }
+	.winds th, .winds td { padding: 0 0.4em; text-align: right; }
+	</style>
+</head>
+<body>
+	<div id="board">{{template "board" .}}</div>
+	<script>
+	(function() {
+		var board = document.getElementById("board");
+		var content = "board/content" + window.location.search;
+		var busy = false, pending = false;
+
+		// Render the board again, but no more than one at a time.
+		function refresh() {
+			if (busy) {
+				pending = true;
+				return;
+			}
+			busy = true;
+			fetch(content, { cache: "no-cache" }).then(function(response) {
+				if (response.ok) {
+					return response.text().then(function(text) {
+						board.innerHTML = text;
+					});
+				}
+			}).catch(function() {
+			}).finally(function() {
+				busy = false;
+				if (pending) {
+					pending = false;
+					refresh();
+				}
+			});
+		}
+
+		// EventSource reconnects by itself, and each connection begins
+		// with the complete current update.
+		var events = new EventSource({{.StreamPath}});
+		events.addEventListener("update", refresh);
+	})();
+	</script>
+</body>
+</html>
+`
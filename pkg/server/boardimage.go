@@ -0,0 +1,432 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// Displays that can only show an image may show the board as a PNG image.
+// Each display's image is re-rendered only when what it displays changes, so
+// that displays refreshing the image frequently do not cost much.
+const (
+	defaultBoardImageWidth  = 1920
+	defaultBoardImageHeight = 1080
+	maxBoardImageWidth      = 3840
+	maxBoardImageHeight     = 2160
+
+	// maxBoardImages is how many displays' images are cached for each
+	// dropzone. The least recently requested image is discarded to make
+	// room for another.
+	maxBoardImages = 16
+
+	// maxBoardRenders is how many images may be rendered at once by all
+	// dropzones, which bounds the memory used by rendering.
+	maxBoardRenders = 2
+)
+
+// boardRenders holds a slot for each image being rendered.
+var boardRenders = make(chan struct{}, maxBoardRenders)
+
+// boardFont is the font in which the board image is rendered. It is embedded
+// so that rendering does not depend on the fonts installed on the server.
+var boardFont = func() *opentype.Font {
+	f, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		// The font is embedded, so if this fails, it always will.
+		panic(err)
+	}
+	return f
+}()
+
+// boardImageReplacer replaces characters that boardFont lacks with
+// equivalents.
+var boardImageReplacer = strings.NewReplacer("℃", "°C", "℉", "°F")
+
+// boardImage is a rendering of the board for a display. png, etag, and err
+// are set once the image has been rendered, which is when done is closed.
+type boardImage struct {
+	data     *boardData
+	lastUsed time.Time
+
+	done chan struct{}
+	png  []byte
+	etag string
+	err  error
+}
+
+// parseBoardImageSize parses the size of the image from the "width" and
+// "height" query parameters.
+func parseBoardImageSize(query url.Values) (int, int, error) {
+	width, height := defaultBoardImageWidth, defaultBoardImageHeight
+	if value := query.Get("width"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxBoardImageWidth {
+			return 0, 0, fmt.Errorf("invalid width %q", value)
+		}
+		width = n
+	}
+	if value := query.Get("height"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxBoardImageHeight {
+			return 0, 0, fmt.Errorf("invalid height %q", value)
+		}
+		height = n
+	}
+	return width, height, nil
+}
+
+// boardImageHandler responds with the board rendered as a PNG image. The
+// image is "width" by "height" pixels, and the layout is given by the same
+// query parameters as for the board page.
+func (s *manifestServiceServer) boardImageHandler(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := req.URL.Query()
+	layout, err := parseBoardLayout(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	width, height, err := parseBoardImageSize(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := s.GetManifest(req.Context(), &GetManifestRequest{
+		Aircraft: layout.aircraft,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data := newBoardData(response.Update, layout.columns)
+
+	key := fmt.Sprintf("%dx%d/%d/%g/%s", width, height, layout.columns,
+		layout.scale, strings.Join(layout.aircraft, ","))
+	img, err := s.boardImage(req.Context(), key, data, func() ([]byte, error) {
+		// Other requests may be waiting for the image, so it is
+		// rendered even if this request is canceled.
+		boardRenders <- struct{}{}
+		defer func() { <-boardRenders }()
+
+		var b bytes.Buffer
+		m := renderBoard(data, width, height, layout.scale)
+		if err := png.Encode(&b, m); err != nil {
+			return nil, err
+		}
+		return b.Bytes(), nil
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h := w.Header()
+	h.Set("ETag", img.etag)
+	h.Set("Cache-Control", "no-cache")
+	if etagMatches(req.Header.Get("If-None-Match"), img.etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	h.Set("Content-Type", "image/png")
+	h.Set("Content-Length", strconv.Itoa(len(img.png)))
+	if req.Method == http.MethodGet {
+		_, _ = w.Write(img.png)
+	}
+}
+
+// boardImage returns the cached image for key if it was rendered from data,
+// or else calls render to render it again.
+// Rendering is done without holding the lock, and requests for an image that
+// is being rendered wait for it rather than rendering it again, unless ctx is
+// done first.
+func (s *manifestServiceServer) boardImage(
+	ctx context.Context,
+	key string,
+	data *boardData,
+	render func() ([]byte, error),
+) (*boardImage, error) {
+	s.boardImageLock.Lock()
+	img := s.boardImages[key]
+	if img != nil && reflect.DeepEqual(img.data, data) {
+		img.lastUsed = time.Now()
+		s.boardImageLock.Unlock()
+
+		select {
+		case <-img.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if img.err != nil {
+			return nil, img.err
+		}
+		return img, nil
+	}
+
+	img = &boardImage{
+		data:     data,
+		lastUsed: time.Now(),
+		done:     make(chan struct{}),
+	}
+	s.boardImages[key] = img
+	if len(s.boardImages) > maxBoardImages {
+		var oldestKey string
+		for k, i := range s.boardImages {
+			if oldestKey == "" || i.lastUsed.Before(s.boardImages[oldestKey].lastUsed) {
+				oldestKey = k
+			}
+		}
+		delete(s.boardImages, oldestKey)
+	}
+	s.boardImageLock.Unlock()
+
+	img.png, img.err = render()
+	img.etag = contentETag(img.png)
+	close(img.done)
+	if img.err != nil {
+		// Don't cache the failure, so that the next request tries
+		// again.
+		s.boardImageLock.Lock()
+		if s.boardImages[key] == img {
+			delete(s.boardImages, key)
+		}
+		s.boardImageLock.Unlock()
+		return nil, img.err
+	}
+	return img, nil
+}
+
+// boardPainter draws text on a board image.
+type boardPainter struct {
+	img       *image.RGBA
+	em        int
+	face      font.Face
+	titleFace font.Face
+}
+
+func newBoardFace(size float64) font.Face {
+	face, err := opentype.NewFace(boardFont, &opentype.FaceOptions{
+		Size:    size,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		// The options are always valid, so this will never fail.
+		panic(err)
+	}
+	return face
+}
+
+func boardImageColor(c uint32) color.RGBA {
+	return color.RGBA{R: uint8(c >> 16), G: uint8(c >> 8), B: uint8(c), A: 0xff}
+}
+
+// lineHeight returns the height of a line of text in face.
+func lineHeight(face font.Face) int {
+	return face.Metrics().Height.Ceil()
+}
+
+// textWidth returns the width of text drawn in face.
+func textWidth(face font.Face, text string) int {
+	return font.MeasureString(face, text).Ceil()
+}
+
+// drawableText returns text without the characters, such as emoji, that
+// boardFont cannot draw.
+func drawableText(text string) string {
+	text = strings.Map(func(r rune) rune {
+		if r == ' ' {
+			return r
+		}
+		if i, err := boardFont.GlyphIndex(nil, r); err != nil || i == 0 {
+			return -1
+		}
+		return r
+	}, boardImageReplacer.Replace(text))
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// drawText draws text with its top left corner at (x, y), truncating it to
+// fit within maxWidth if it is not 0.
+func (p *boardPainter) drawText(face font.Face, text string, c uint32, x, y, maxWidth int) {
+	text = drawableText(text)
+	if maxWidth > 0 && textWidth(face, text) > maxWidth {
+		runes := []rune(text)
+		for len(runes) > 0 && textWidth(face, string(runes)+"…") > maxWidth {
+			runes = runes[:len(runes)-1]
+		}
+		text = string(runes) + "…"
+	}
+	d := &font.Drawer{
+		Dst:  p.img,
+		Src:  image.NewUniform(boardImageColor(c)),
+		Face: face,
+		Dot:  fixed.P(x, y+face.Metrics().Ascent.Ceil()),
+	}
+	d.DrawString(text)
+}
+
+// drawSpaced draws texts on a line, spacing them evenly across the width of
+// the image like the board page does. It returns the height of the line.
+func (p *boardPainter) drawSpaced(texts []boardText, y int) int {
+	pad := p.em / 2
+	width := p.img.Bounds().Dx() - 2*pad
+	for i, t := range texts {
+		cell := width / len(texts)
+		w := textWidth(p.face, drawableText(t.Text))
+		x := pad + i*cell + (cell-w)/2
+		if x < pad+i*cell {
+			x = pad + i*cell
+		}
+		p.drawText(p.face, t.Text, t.Color, x, y, cell)
+	}
+	return lineHeight(p.face) + p.em*3/10
+}
+
+// drawWinds draws the winds aloft table with its top right corner at
+// (right, y). It returns the width of the table.
+func (p *boardPainter) drawWinds(winds []boardWinds, right, y int) int {
+	rows := [][]string{{"Alt", "Dir", "Speed", "Temp"}}
+	for _, w := range winds {
+		rows = append(rows, []string{w.Altitude, w.Heading, w.Speed, w.Temperature})
+	}
+
+	pad := p.em * 4 / 10
+	widths := make([]int, len(rows[0]))
+	tableWidth := 0
+	for i := range widths {
+		for _, row := range rows {
+			if w := textWidth(p.face, drawableText(row[i])); w > widths[i] {
+				widths[i] = w
+			}
+		}
+		tableWidth += widths[i] + 2*pad
+	}
+
+	lh := lineHeight(p.face)
+	for _, row := range rows {
+		x := right - tableWidth
+		for i, text := range row {
+			x += pad + widths[i]
+			p.drawText(p.face, text, 0xffffff, x-textWidth(p.face, drawableText(text)), y, 0)
+			x += pad
+		}
+		y += lh
+	}
+	return tableWidth
+}
+
+// drawLoad draws a load in a column with its top left corner at (x, y).
+func (p *boardPainter) drawLoad(load *boardLoad, x, y, width, bottom int) {
+	title := load.Title
+	if load.IsFueling {
+		title += " (fueling)"
+	}
+	p.drawText(p.titleFace, title, 0xffffff, x, y, width)
+	y += lineHeight(p.titleFace)
+	draw.Draw(p.img, image.Rect(x, y, x+width, y+1),
+		image.NewUniform(color.RGBA{R: 0x88, G: 0x88, B: 0x88, A: 0xff}),
+		image.Point{}, draw.Src)
+	y += 2
+
+	lh := lineHeight(p.face)
+	slots := drawableText(load.SlotsAvailable)
+	slotsWidth := textWidth(p.face, slots)
+	p.drawText(p.face, load.CallMinutes, 0xffffff, x, y, width-slotsWidth)
+	p.drawText(p.face, slots, 0xffffff, x+width-slotsWidth, y, 0)
+	y += lh + p.em*3/10
+
+	indent := p.em * 3 / 2
+	for _, j := range load.Jumpers {
+		if y+lh > bottom {
+			break
+		}
+		if j.IsGroupMember {
+			p.drawText(p.face, j.Text, j.Color, x+indent, y, width-indent)
+		} else {
+			p.drawText(p.face, j.Text, j.Color, x, y, width)
+		}
+		y += lh
+	}
+}
+
+// renderBoard renders the board as an image laid out like the board page.
+func renderBoard(data *boardData, width, height int, scale float64) image.Image {
+	size := float64(height) * boardFontSize * scale
+	if size < 6 {
+		size = 6
+	}
+	p := &boardPainter{
+		img:       image.NewRGBA(image.Rect(0, 0, width, height)),
+		em:        int(size),
+		face:      newBoardFace(size),
+		titleFace: newBoardFace(size * 1.2),
+	}
+	defer p.face.Close()
+	defer p.titleFace.Close()
+	draw.Draw(p.img, p.img.Bounds(), image.Black, image.Point{}, draw.Src)
+
+	pad := p.em / 2
+	y := pad
+	if len(data.Status) > 0 {
+		y += p.drawSpaced(data.Status, y)
+	}
+	if m := data.Message; m.Text != "" {
+		w := textWidth(p.titleFace, drawableText(m.Text))
+		x := (width - w) / 2
+		if x < pad {
+			x = pad
+		}
+		p.drawText(p.titleFace, m.Text, m.Color, x, y, width-2*pad)
+		y += lineHeight(p.titleFace) + p.em*3/10
+	}
+	var sun []boardText
+	for _, text := range []string{data.Sunrise, data.Sunset} {
+		if text != "" {
+			sun = append(sun, boardText{Text: text, Color: 0xffffff})
+		}
+	}
+	if len(sun) > 0 {
+		y += p.drawSpaced(sun, y)
+	}
+
+	right := width - pad
+	if len(data.Winds) > 0 {
+		right -= p.drawWinds(data.Winds, right, y) + p.em
+	}
+
+	gap := p.em / 2
+	columnWidth := (right - pad - gap*(data.Columns-1)) / data.Columns
+	if columnWidth < 1 {
+		return p.img
+	}
+	for i := range data.Loads {
+		x := pad + i*(columnWidth+gap)
+		p.drawLoad(&data.Loads[i], x, y, columnWidth, height-pad)
+	}
+
+	return p.img
+}
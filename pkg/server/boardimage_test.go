@@ -0,0 +1,240 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/core"
+	"github.com/jumptown-skydiving/manifest-server/pkg/settings"
+)
+
+// testServer returns a server, which is not started, whose current update
+// is testUpdate().
+func testServer(t *testing.T) *manifestServiceServer {
+	t.Helper()
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "config.yaml")
+	config := "options_file: " + filepath.Join(dir, "options.json") + "\n" +
+		"jumper_rules_file: " + filepath.Join(dir, "jumper_rules.json") + "\n" +
+		"database:\n" +
+		"  driver: sqlite3\n" +
+		"  filename: " + filepath.Join(dir, "database.sqlite3") + "\n"
+	if err := os.WriteFile(filename, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s, err := settings.NewSettingsWithFilename(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := core.NewController(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := newManifestServiceServer(c)
+	server.lastUpdate = testUpdate()
+	return server
+}
+
+// setTestUpdate replaces s's current update with testUpdate() changed by f.
+func setTestUpdate(s *manifestServiceServer, f func(u *ManifestUpdate)) {
+	u := testUpdate()
+	f(u)
+	s.lock.Lock()
+	s.lastUpdate = u
+	s.lock.Unlock()
+}
+
+// getBoardImage requests the board image with the given If-None-Match
+// header.
+func getBoardImage(t *testing.T, s *manifestServiceServer, ifNoneMatch string) *http.Response {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, boardImagePath+"?width=320&height=180", nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	w := httptest.NewRecorder()
+	s.boardImageHandler(w, req)
+	return w.Result()
+}
+
+func TestBoardImageHandler(t *testing.T) {
+	s := testServer(t)
+
+	resp := getBoardImage(t, s, "")
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "image/png" {
+		t.Fatalf("status %d with content type %q, want a PNG image",
+			resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("no ETag")
+	}
+	if resp = getBoardImage(t, s, etag); resp.StatusCode != http.StatusNotModified {
+		t.Errorf("status %d for the same ETag, want %d", resp.StatusCode, http.StatusNotModified)
+	}
+
+	// A change that the board does not show leaves the image and its tag
+	// alone...
+	setTestUpdate(s, func(u *ManifestUpdate) { u.Sequence = 2 })
+	if resp = getBoardImage(t, s, etag); resp.StatusCode != http.StatusNotModified {
+		t.Errorf("status %d after an unseen change, want %d", resp.StatusCode, http.StatusNotModified)
+	}
+
+	// ...but one that it shows changes them.
+	setTestUpdate(s, func(u *ManifestUpdate) {
+		u.Options.Message = "goodbye"
+		u.Options.MessageColor = 0xffffff
+		u.Sequence = 3
+	})
+	resp = getBoardImage(t, s, etag)
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status %d after a change, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if got := resp.Header.Get("ETag"); got == etag {
+		t.Errorf("ETag %s unchanged after a change", got)
+	}
+}
+
+func TestBoardImageCache(t *testing.T) {
+	s := &manifestServiceServer{boardImages: make(map[string]*boardImage)}
+	ctx := context.Background()
+
+	renders := 0
+	render := func(png string, err error) func() ([]byte, error) {
+		return func() ([]byte, error) {
+			renders++
+			return []byte(png), err
+		}
+	}
+	data := func(message string) *boardData {
+		return &boardData{Columns: 5, Message: boardText{Text: message}}
+	}
+
+	tests := []struct {
+		name        string
+		key         string
+		message     string
+		renderErr   error
+		wantRenders int
+	}{
+		{"first", "a", "hello", nil, 1},
+		{"unchanged", "a", "hello", nil, 1},
+		{"another display", "b", "hello", nil, 2},
+		{"changed", "a", "goodbye", nil, 3},
+		{"render fails", "a", "again", errors.New("failed"), 4},
+		{"failure not cached", "a", "again", nil, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := s.boardImage(ctx, tt.key, data(tt.message), render(tt.message, tt.renderErr))
+			if renders != tt.wantRenders {
+				t.Errorf("%d renders, want %d", renders, tt.wantRenders)
+			}
+			if tt.renderErr != nil {
+				if err != tt.renderErr {
+					t.Errorf("boardImage() error %v, want %v", err, tt.renderErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := contentETag([]byte(tt.message)); string(img.png) != tt.message || img.etag != want {
+				t.Errorf("boardImage() = %q tagged %s, want %q tagged %s",
+					img.png, img.etag, tt.message, want)
+			}
+		})
+	}
+}
+
+func TestBoardImageEviction(t *testing.T) {
+	s := &manifestServiceServer{boardImages: make(map[string]*boardImage)}
+	ctx := context.Background()
+	render := func() ([]byte, error) { return nil, nil }
+
+	start := time.Now().Add(-time.Hour)
+	for i := 0; i < maxBoardImages; i++ {
+		key := fmt.Sprint(i)
+		if _, err := s.boardImage(ctx, key, &boardData{}, render); err != nil {
+			t.Fatal(err)
+		}
+		s.boardImages[key].lastUsed = start.Add(time.Duration(i) * time.Minute)
+	}
+
+	// Using the oldest image makes the next oldest the least recently
+	// used.
+	if _, err := s.boardImage(ctx, "0", &boardData{}, render); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.boardImage(ctx, "new", &boardData{}, render); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.boardImages) != maxBoardImages {
+		t.Errorf("%d images cached, want %d", len(s.boardImages), maxBoardImages)
+	}
+	for _, key := range []string{"0", "2", "new"} {
+		if s.boardImages[key] == nil {
+			t.Errorf("image %s evicted", key)
+		}
+	}
+	if s.boardImages["1"] != nil {
+		t.Error("least recently used image not evicted")
+	}
+}
+
+func TestBoardImageWaiters(t *testing.T) {
+	s := &manifestServiceServer{boardImages: make(map[string]*boardImage)}
+	data := &boardData{Message: boardText{Text: "hello"}}
+
+	rendering := make(chan struct{})
+	finish := make(chan struct{})
+	rendered := make(chan *boardImage)
+	go func() {
+		img, err := s.boardImage(context.Background(), "a", data, func() ([]byte, error) {
+			close(rendering)
+			<-finish
+			return []byte("png"), nil
+		})
+		if err != nil {
+			t.Error(err)
+		}
+		rendered <- img
+	}()
+	<-rendering
+
+	render := func() ([]byte, error) {
+		t.Error("image rendered again while rendering")
+		return nil, nil
+	}
+
+	// A waiter that gives up does not affect the image...
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.boardImage(ctx, "a", data, render); !errors.Is(err, context.Canceled) {
+		t.Errorf("boardImage() error %v after giving up, want %v", err, context.Canceled)
+	}
+
+	// ...which the others get once it is rendered.
+	waiting := make(chan *boardImage)
+	go func() {
+		img, err := s.boardImage(context.Background(), "a", data, render)
+		if err != nil {
+			t.Error(err)
+		}
+		waiting <- img
+	}()
+	close(finish)
+	want := <-rendered
+	if got := <-waiting; got != want || string(got.png) != "png" || got.etag != contentETag([]byte("png")) {
+		t.Errorf("waiter got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,230 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/proto"
+)
+
+// updateClient is a StreamUpdates client. Updates for a client are never
+// queued: an update that arrives while another is pending is merged into it,
+// so that a client that cannot keep up receives the net result of the
+// updates it missed rather than holding up every other client.
+type updateClient struct {
+	id        uint64
+	peer      string
+	connected time.Time
+
+	// ready receives a value when there is something to send, and
+	// disconnected is closed if the client is disconnected for being
+	// too slow.
+	ready        chan struct{}
+	disconnected chan struct{}
+
+	lock         sync.Mutex
+	pending      *ManifestUpdate
+	pendingCount int // number of updates merged into pending
+	pendingSince time.Time
+	resync       bool // send a complete update instead of pending
+	sending      bool
+	sendStart    time.Time
+	lastSend     time.Time
+	sent         uint64
+	coalesced    uint64
+	dropped      uint64
+	isClosed     bool
+}
+
+func newUpdateClient(ctx context.Context) *updateClient {
+	c := &updateClient{
+		connected:    time.Now(),
+		ready:        make(chan struct{}, 1),
+		disconnected: make(chan struct{}),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		c.peer = p.Addr.String()
+	}
+	return c
+}
+
+func (c *updateClient) signal() {
+	select {
+	case c.ready <- struct{}{}:
+	default:
+	}
+}
+
+// isSlow returns true if the client has taken longer than timeout to accept
+// an update. The caller must hold c.lock.
+func (c *updateClient) isSlow(now time.Time, timeout time.Duration) bool {
+	return (c.sending && now.Sub(c.sendStart) > timeout) ||
+		(c.pending != nil && now.Sub(c.pendingSince) > timeout)
+}
+
+// enqueue makes u pending for the client, applying policy if the client is
+// slow. It never blocks.
+func (c *updateClient) enqueue(u *ManifestUpdate, policy string, timeout time.Duration) {
+	now := time.Now()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.isClosed {
+		return
+	}
+
+	if c.isSlow(now, timeout) {
+		switch policy {
+		case "disconnect":
+			c.isClosed = true
+			close(c.disconnected)
+			return
+		case "drop":
+			c.dropped += uint64(c.pendingCount) + 1
+			c.pending = nil
+			c.pendingCount = 0
+			c.resync = true
+			c.signal()
+			return
+		}
+	}
+	if c.resync {
+		// A complete update will be sent instead
+		c.dropped++
+		return
+	}
+
+	if c.pending == nil {
+		c.pending = proto.Clone(u).(*ManifestUpdate)
+		c.pendingCount = 1
+		c.pendingSince = now
+	} else {
+		c.pending.merge(proto.Clone(u).(*ManifestUpdate))
+		c.pendingCount++
+		c.coalesced++
+	}
+	c.signal()
+}
+
+// take returns the update to send to the client, or nil if there is none.
+// snapshot is called for a complete update if the client must be
+// resynchronized. sendDone must be called after the update is sent.
+func (c *updateClient) take(snapshot func() *ManifestUpdate) *ManifestUpdate {
+	c.lock.Lock()
+	u, resync := c.pending, c.resync
+	c.pending = nil
+	c.pendingCount = 0
+	c.resync = false
+	if u != nil || resync {
+		c.sending = true
+		c.sendStart = time.Now()
+	}
+	c.lock.Unlock()
+
+	if resync {
+		return snapshot()
+	}
+	return u
+}
+
+func (c *updateClient) sendDone() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.sending = false
+	c.lastSend = time.Now()
+	c.sent++
+}
+
+func (c *updateClient) close() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.isClosed = true
+}
+
+// stats returns the client's statistics for ListUpdateClients.
+func (c *updateClient) stats(timeout time.Duration) *UpdateClient {
+	now := time.Now()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return &UpdateClient{
+		Id:            c.id,
+		Peer:          c.peer,
+		ConnectedTime: c.connected.Unix(),
+		LastSendTime:  unixSeconds(c.lastSend),
+		QueueDepth:    int32(c.pendingCount),
+		Sent:          c.sent,
+		Coalesced:     c.coalesced,
+		Dropped:       c.dropped,
+		IsSending:     c.sending,
+		IsSlow:        c.isSlow(now, timeout),
+	}
+}
+
+// merge merges the sections that are set in y into x, which then includes
+// the changes through y's sequence. x remains a snapshot if it was one.
+//
+// We cannot use proto.Merge here because we attribute meaning to nil on
+// optional fields, but proto.Merge ignores nil when merging in, not clearing
+// the field in the destination. This is what we want at the top-level, but
+// not the lower levels.
+func (x *ManifestUpdate) merge(y *ManifestUpdate) {
+	if y.Status != nil {
+		x.Status = y.Status
+	}
+	if y.Options != nil {
+		x.Options = y.Options
+	}
+	if y.Jumprun != nil {
+		x.Jumprun = y.Jumprun
+	}
+	if y.WindsAloft != nil {
+		x.WindsAloft = y.WindsAloft
+	}
+	if y.Loads != nil {
+		x.Loads = y.Loads
+	}
+	if y.FuelRequests != nil {
+		x.FuelRequests = y.FuelRequests
+	}
+	if y.SourceHealth != nil {
+		x.SourceHealth = y.SourceHealth
+	}
+	x.Sequence = y.Sequence
+	x.Epoch = y.Epoch
+}
+
+// snapshot returns a copy of the complete current update.
+func (s *manifestServiceServer) snapshot() *ManifestUpdate {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return proto.Clone(s.lastUpdate).(*ManifestUpdate)
+}
+
+func (s *manifestServiceServer) ListUpdateClients(
+	ctx context.Context,
+	req *ListUpdateClientsRequest,
+) (*ListUpdateClientsResponse, error) {
+	if _, err := s.sessionUser(ctx, req.SessionId, "admin"); err != nil {
+		return &ListUpdateClientsResponse{
+			ErrorMessage: err.Error(),
+		}, nil
+	}
+
+	timeout := s.app.Settings().SlowClientTimeout()
+	response := &ListUpdateClientsResponse{}
+	s.lock.Lock()
+	for _, c := range s.clients {
+		response.Clients = append(response.Clients, c.stats(timeout))
+	}
+	s.lock.Unlock()
+	sort.Slice(response.Clients, func(i, j int) bool {
+		return response.Clients[i].Id < response.Clients[j].Id
+	})
+	return response, nil
+}
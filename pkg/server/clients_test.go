@@ -0,0 +1,200 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const testSlowClientTimeout = 10 * time.Second
+
+// testSnapshot returns a complete update as of sequence.
+func testSnapshot(sequence uint64) func() *ManifestUpdate {
+	return func() *ManifestUpdate {
+		return &ManifestUpdate{
+			Status:     &Status{Winds: "snapshot"},
+			Loads:      &Loads{},
+			Sequence:   sequence,
+			IsSnapshot: true,
+		}
+	}
+}
+
+// isReady returns true if c has signalled that it is ready.
+func isReady(c *updateClient) bool {
+	select {
+	case <-c.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+// makeSlow makes c's pending update or send older than the slow client
+// timeout.
+func makeSlow(c *updateClient) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.pendingSince = c.pendingSince.Add(-2 * testSlowClientTimeout)
+	c.sendStart = c.sendStart.Add(-2 * testSlowClientTimeout)
+}
+
+func TestUpdateClientMerge(t *testing.T) {
+	c := newUpdateClient(context.Background())
+	if isReady(c) {
+		t.Fatal("ready before any updates")
+	}
+
+	u1 := &ManifestUpdate{Status: &Status{Winds: "calm"}, Loads: &Loads{ColumnCount: 1}, Sequence: 1}
+	u2 := &ManifestUpdate{Options: &Options{Message: "hello"}, Sequence: 2}
+	u3 := &ManifestUpdate{Loads: &Loads{ColumnCount: 3}, Sequence: 3}
+	for _, u := range []*ManifestUpdate{u1, u2, u3} {
+		c.enqueue(u, "coalesce", testSlowClientTimeout)
+	}
+	// Updates are copied when they are enqueued.
+	u1.Status.Winds = "changed"
+
+	if !isReady(c) {
+		t.Fatal("not ready after updates")
+	}
+	if isReady(c) {
+		t.Error("ready signalled more than once")
+	}
+	stats := c.stats(testSlowClientTimeout)
+	if stats.QueueDepth != 3 || stats.Coalesced != 2 || stats.IsSlow {
+		t.Errorf("stats %v, want 3 queued and 2 coalesced", stats)
+	}
+
+	want := &ManifestUpdate{
+		Status:   &Status{Winds: "calm"},
+		Options:  &Options{Message: "hello"},
+		Loads:    &Loads{ColumnCount: 3},
+		Sequence: 3,
+	}
+	got := c.take(testSnapshot(3))
+	if !proto.Equal(got, want) {
+		t.Errorf("take() = %v, want %v", got, want)
+	}
+	c.sendDone()
+	if got = c.take(testSnapshot(3)); got != nil {
+		t.Errorf("take() = %v again, want nil", got)
+	}
+	if stats = c.stats(testSlowClientTimeout); stats.QueueDepth != 0 || stats.Sent != 1 || stats.IsSending {
+		t.Errorf("stats %v, want 1 sent and none queued", stats)
+	}
+}
+
+func TestUpdateClientSlow(t *testing.T) {
+	update := func(sequence uint64) *ManifestUpdate {
+		return &ManifestUpdate{Loads: &Loads{ColumnCount: int32(sequence)}, Sequence: sequence}
+	}
+
+	tests := []struct {
+		name    string
+		policy  string
+		sending bool // slow to accept a send rather than to take an update
+
+		want             *ManifestUpdate // taken after the slow update
+		wantDropped      uint64
+		wantDisconnected bool
+	}{
+		{
+			name:   "coalesce",
+			policy: "coalesce",
+			want:   &ManifestUpdate{Status: &Status{Winds: "calm"}, Loads: &Loads{ColumnCount: 3}, Sequence: 3},
+		},
+		{
+			name:        "drop pending",
+			policy:      "drop",
+			want:        testSnapshot(3)(),
+			wantDropped: 3,
+		},
+		{
+			name:        "drop while sending",
+			policy:      "drop",
+			sending:     true,
+			want:        testSnapshot(3)(),
+			wantDropped: 2,
+		},
+		{
+			name:             "disconnect",
+			policy:           "disconnect",
+			wantDisconnected: true,
+		},
+		{
+			name:             "disconnect while sending",
+			policy:           "disconnect",
+			sending:          true,
+			wantDisconnected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newUpdateClient(context.Background())
+			c.enqueue(&ManifestUpdate{Status: &Status{Winds: "calm"}, Sequence: 1}, tt.policy, testSlowClientTimeout)
+			if tt.sending {
+				// The update is taken, but not sent.
+				c.take(testSnapshot(1))
+			}
+			makeSlow(c)
+			if !c.stats(testSlowClientTimeout).IsSlow {
+				t.Fatal("client not slow")
+			}
+
+			// The policy applies to the update that finds the
+			// client slow and to any that follow.
+			c.enqueue(update(2), tt.policy, testSlowClientTimeout)
+			c.enqueue(update(3), tt.policy, testSlowClientTimeout)
+
+			select {
+			case <-c.disconnected:
+				// The update that followed was ignored rather
+				// than disconnecting the client again.
+				if !tt.wantDisconnected {
+					t.Fatal("client disconnected")
+				}
+				return
+			default:
+				if tt.wantDisconnected {
+					t.Fatal("client not disconnected")
+				}
+			}
+
+			if tt.sending {
+				c.sendDone()
+			}
+			if !isReady(c) {
+				t.Error("not ready after updates")
+			}
+			if got := c.take(testSnapshot(3)); !proto.Equal(got, tt.want) {
+				t.Errorf("take() = %v, want %v", got, tt.want)
+			}
+			c.sendDone()
+			if got := c.stats(testSlowClientTimeout).Dropped; got != tt.wantDropped {
+				t.Errorf("%d updates dropped, want %d", got, tt.wantDropped)
+			}
+
+			// Once caught up, the client is sent updates again.
+			c.enqueue(update(4), tt.policy, testSlowClientTimeout)
+			if got := c.take(testSnapshot(4)); !proto.Equal(got, update(4)) {
+				t.Errorf("take() = %v after catching up, want %v", got, update(4))
+			}
+		})
+	}
+}
+
+func TestUpdateClientClosed(t *testing.T) {
+	c := newUpdateClient(context.Background())
+	c.close()
+	c.enqueue(&ManifestUpdate{Sequence: 1}, "coalesce", testSlowClientTimeout)
+	if isReady(c) {
+		t.Error("closed client ready")
+	}
+	if got := c.take(testSnapshot(1)); got != nil {
+		t.Errorf("take() = %v from closed client, want nil", got)
+	}
+}
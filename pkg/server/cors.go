@@ -0,0 +1,48 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import "net/http"
+
+// corsExposedHeaders are the response headers that cross-origin pages may
+// read. gRPC-Web clients need the gRPC status headers, which are sent as
+// headers rather than trailers when a call fails immediately.
+const corsExposedHeaders = "Grpc-Status, Grpc-Message, Grpc-Status-Details-Bin, ETag"
+
+// isAllowedOrigin reports whether pages from origin may make cross-origin
+// requests.
+func (s *WebServer) isAllowedOrigin(origin string) bool {
+	for _, allowed := range s.corsOrigins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// handleCORS sets the CORS headers for a request from an allowed origin. It
+// returns true if the request is a preflight request, which it answers.
+func (s *WebServer) handleCORS(w http.ResponseWriter, req *http.Request) bool {
+	origin := req.Header.Get("Origin")
+	if origin == "" || !s.isAllowedOrigin(origin) {
+		return false
+	}
+
+	h := w.Header()
+	h.Set("Access-Control-Allow-Origin", origin)
+	h.Add("Vary", "Origin")
+
+	method := req.Header.Get("Access-Control-Request-Method")
+	if req.Method != http.MethodOptions || method == "" {
+		h.Set("Access-Control-Expose-Headers", corsExposedHeaders)
+		return false
+	}
+
+	h.Set("Access-Control-Allow-Methods", "GET, HEAD, POST")
+	if headers := req.Header.Get("Access-Control-Request-Headers"); headers != "" {
+		h.Set("Access-Control-Allow-Headers", headers)
+	}
+	h.Set("Access-Control-Max-Age", "600")
+	w.WriteHeader(http.StatusNoContent)
+	return true
+}
@@ -0,0 +1,252 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/core"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// dropzoneMetadataKey is the gRPC request metadata key that clients use to
+// select a dropzone. Requests without it are served by the default dropzone.
+const dropzoneMetadataKey = "dropzone"
+
+// dropzoneRouter is the ManifestServiceServer registered with gRPC. It
+// dispatches each call to the manifestServiceServer for the dropzone that
+// the call selects.
+type dropzoneRouter struct {
+	UnimplementedManifestServiceServer
+
+	ids       []string
+	defaultID string
+	servers   map[string]*manifestServiceServer
+}
+
+// newDropzoneRouter creates a router for the dropzones served by controllers.
+// The first controller is the default dropzone.
+func newDropzoneRouter(controllers []*core.Controller) *dropzoneRouter {
+	r := &dropzoneRouter{
+		servers: make(map[string]*manifestServiceServer),
+	}
+	for _, c := range controllers {
+		id := c.Settings().DropzoneID()
+		if len(r.ids) == 0 {
+			r.defaultID = id
+		}
+		r.ids = append(r.ids, id)
+		r.servers[id] = newManifestServiceServer(c)
+	}
+	return r
+}
+
+func (r *dropzoneRouter) Start() {
+	for _, s := range r.servers {
+		s.Start()
+	}
+}
+
+func (r *dropzoneRouter) Stop() {
+	for _, s := range r.servers {
+		s.Stop()
+	}
+}
+
+func (r *dropzoneRouter) server(ctx context.Context) (*manifestServiceServer, error) {
+	id := r.defaultID
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(dropzoneMetadataKey); len(values) > 0 && values[0] != "" {
+			id = strings.ToLower(values[0])
+		}
+	}
+	if s, ok := r.servers[id]; ok {
+		return s, nil
+	}
+	return nil, status.Errorf(codes.NotFound, "unknown dropzone %q", id)
+}
+
+func (r *dropzoneRouter) ListDropzones(
+	ctx context.Context,
+	_ *emptypb.Empty,
+) (*ListDropzonesResponse, error) {
+	resp := &ListDropzonesResponse{}
+	for _, id := range r.ids {
+		resp.Dropzones = append(resp.Dropzones, &Dropzone{
+			Id:        id,
+			Name:      r.servers[id].app.Settings().DropzoneName(),
+			IsDefault: id == r.defaultID,
+		})
+	}
+	return resp, nil
+}
+
+func (r *dropzoneRouter) StreamUpdates(
+	req *emptypb.Empty,
+	stream ManifestService_StreamUpdatesServer,
+) error {
+	s, err := r.server(stream.Context())
+	if err != nil {
+		return err
+	}
+	return s.StreamUpdates(req, stream)
+}
+
+func (r *dropzoneRouter) SubscribeUpdates(
+	req *UpdateSubscription,
+	stream ManifestService_SubscribeUpdatesServer,
+) error {
+	s, err := r.server(stream.Context())
+	if err != nil {
+		return err
+	}
+	return s.SubscribeUpdates(req, stream)
+}
+
+func (r *dropzoneRouter) SignInWithApple(
+	ctx context.Context,
+	req *SignInWithAppleRequest,
+) (*SignInResponse, error) {
+	s, err := r.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.SignInWithApple(ctx, req)
+}
+
+func (r *dropzoneRouter) SignOut(
+	ctx context.Context,
+	req *SignOutRequest,
+) (*SignOutResponse, error) {
+	s, err := r.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.SignOut(ctx, req)
+}
+
+func (r *dropzoneRouter) VerifySessionID(
+	ctx context.Context,
+	req *VerifySessionRequest,
+) (*SignInResponse, error) {
+	s, err := r.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.VerifySessionID(ctx, req)
+}
+
+func (r *dropzoneRouter) ToggleFuelRequested(
+	ctx context.Context,
+	req *ToggleFuelRequestedRequest,
+) (*ToggleFuelRequestedResponse, error) {
+	s, err := r.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.ToggleFuelRequested(ctx, req)
+}
+
+func (r *dropzoneRouter) RestartServer(
+	ctx context.Context,
+	req *RestartServerRequest,
+) (*RestartServerResponse, error) {
+	s, err := r.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.RestartServer(ctx, req)
+}
+
+func (r *dropzoneRouter) QueryLoadHistory(
+	ctx context.Context,
+	req *LoadHistoryRequest,
+) (*LoadHistoryResponse, error) {
+	s, err := r.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.QueryLoadHistory(ctx, req)
+}
+
+func (r *dropzoneRouter) StreamEvents(
+	req *emptypb.Empty,
+	stream ManifestService_StreamEventsServer,
+) error {
+	s, err := r.server(stream.Context())
+	if err != nil {
+		return err
+	}
+	return s.StreamEvents(req, stream)
+}
+
+func (r *dropzoneRouter) StreamAnnouncements(
+	req *emptypb.Empty,
+	stream ManifestService_StreamAnnouncementsServer,
+) error {
+	s, err := r.server(stream.Context())
+	if err != nil {
+		return err
+	}
+	return s.StreamAnnouncements(req, stream)
+}
+
+func (r *dropzoneRouter) RequestFuel(
+	ctx context.Context,
+	req *RequestFuelRequest,
+) (*FuelRequestResponse, error) {
+	s, err := r.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.RequestFuel(ctx, req)
+}
+
+func (r *dropzoneRouter) AcknowledgeFuelRequest(
+	ctx context.Context,
+	req *UpdateFuelRequestRequest,
+) (*FuelRequestResponse, error) {
+	s, err := r.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.AcknowledgeFuelRequest(ctx, req)
+}
+
+func (r *dropzoneRouter) CompleteFuelRequest(
+	ctx context.Context,
+	req *UpdateFuelRequestRequest,
+) (*FuelRequestResponse, error) {
+	s, err := r.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.CompleteFuelRequest(ctx, req)
+}
+
+func (r *dropzoneRouter) ListUpdateClients(
+	ctx context.Context,
+	req *ListUpdateClientsRequest,
+) (*ListUpdateClientsResponse, error) {
+	s, err := r.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.ListUpdateClients(ctx, req)
+}
+
+func (r *dropzoneRouter) GetManifest(
+	ctx context.Context,
+	req *GetManifestRequest,
+) (*GetManifestResponse, error) {
+	s, err := r.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetManifest(ctx, req)
+}
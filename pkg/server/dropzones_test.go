@@ -0,0 +1,54 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestDropzoneRouterServer(t *testing.T) {
+	jumptown, otherdz := &manifestServiceServer{}, &manifestServiceServer{}
+	r := &dropzoneRouter{
+		ids:       []string{"jumptown", "otherdz"},
+		defaultID: "jumptown",
+		servers: map[string]*manifestServiceServer{
+			"jumptown": jumptown,
+			"otherdz":  otherdz,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		metadata []string // key/value pairs, or nil for no metadata
+		want     *manifestServiceServer
+		wantCode codes.Code
+	}{
+		{name: "no metadata", want: jumptown},
+		{name: "other metadata", metadata: []string{"authorization", "x"}, want: jumptown},
+		{name: "empty dropzone", metadata: []string{dropzoneMetadataKey, ""}, want: jumptown},
+		{name: "dropzone", metadata: []string{dropzoneMetadataKey, "otherdz"}, want: otherdz},
+		{name: "dropzone in upper case", metadata: []string{dropzoneMetadataKey, "OtherDZ"}, want: otherdz},
+		{name: "default dropzone", metadata: []string{dropzoneMetadataKey, "jumptown"}, want: jumptown},
+		{name: "unknown dropzone", metadata: []string{dropzoneMetadataKey, "skydivechicago"}, wantCode: codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.metadata != nil {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(tt.metadata...))
+			}
+			got, err := r.server(ctx)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("server() error %v, want code %v", err, tt.wantCode)
+			}
+			if got != tt.want {
+				t.Errorf("server() = %p, want %p", got, tt.want)
+			}
+		})
+	}
+}
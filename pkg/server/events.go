@@ -0,0 +1,61 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import (
+	"github.com/jumptown-skydiving/manifest-server/pkg/burble"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var eventTypes = map[burble.EventType]ManifestEventType{
+	burble.JumperAddedEvent:   ManifestEventType_JUMPER_ADDED,
+	burble.JumperRemovedEvent: ManifestEventType_JUMPER_REMOVED,
+	burble.JumperMovedEvent:   ManifestEventType_JUMPER_MOVED,
+	burble.LoadCreatedEvent:   ManifestEventType_LOAD_CREATED,
+	burble.LoadDepartedEvent:  ManifestEventType_LOAD_DEPARTED,
+	burble.LoadRemovedEvent:   ManifestEventType_LOAD_REMOVED,
+	burble.CallTimeEvent:      ManifestEventType_CALL_TIME,
+	burble.SlotsOpenedEvent:   ManifestEventType_SLOTS_OPENED,
+}
+
+func translateEvent(e burble.Event) *ManifestEvent {
+	return &ManifestEvent{
+		Type:             eventTypes[e.Type],
+		Time:             e.Time.Unix(),
+		LoadId:           uint64(e.LoadID),
+		AircraftName:     e.AircraftName,
+		LoadNumber:       e.LoadNumber,
+		JumperName:       e.JumperName,
+		FromLoadId:       uint64(e.FromLoadID),
+		FromAircraftName: e.FromAircraftName,
+		FromLoadNumber:   e.FromLoadNumber,
+		CallMinutes:      int32(e.CallMinutes),
+		SlotsAvailable:   int32(e.SlotsAvailable),
+		Description:      e.String(),
+	}
+}
+
+func (s *manifestServiceServer) StreamEvents(
+	_ *emptypb.Empty,
+	stream ManifestService_StreamEventsServer,
+) error {
+	c := make(chan []burble.Event, 16)
+	id := s.app.AddEventListener(c)
+	defer s.app.RemoveEventListener(id)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-s.app.Done():
+			return nil
+		case events := <-c:
+			for _, e := range events {
+				if err := stream.Send(translateEvent(e)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
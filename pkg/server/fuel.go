@@ -0,0 +1,114 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/db"
+)
+
+var errPermissionDenied = errors.New("Permission Denied")
+
+// sessionUser returns the user signed in to a session, provided that the
+// user has at least one of the specified roles.
+func (s *manifestServiceServer) sessionUser(
+	ctx context.Context,
+	sessionID string,
+	roles ...string,
+) (*db.User, error) {
+	user, userRoles, err := s.app.SessionUser(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, userRole := range userRoles {
+		for _, role := range roles {
+			if userRole == role {
+				return user, nil
+			}
+		}
+	}
+	return nil, errPermissionDenied
+}
+
+func translateFuelRequest(r *db.FuelRequest) *FuelRequest {
+	f := &FuelRequest{
+		Id:             uint64(r.ID),
+		AircraftName:   r.AircraftName,
+		Note:           r.Note,
+		RequestedBy:    r.RequestedBy,
+		RequestTime:    r.RequestTime.Unix(),
+		AcknowledgedBy: r.AcknowledgedBy,
+		CompletedBy:    r.CompletedBy,
+	}
+	if !r.AcknowledgeTime.IsZero() {
+		f.AcknowledgeTime = r.AcknowledgeTime.Unix()
+	}
+	if !r.CompleteTime.IsZero() {
+		f.CompleteTime = r.CompleteTime.Unix()
+	}
+	return f
+}
+
+func (s *manifestServiceServer) RequestFuel(
+	ctx context.Context,
+	req *RequestFuelRequest,
+) (*FuelRequestResponse, error) {
+	user, err := s.sessionUser(ctx, req.SessionId, "admin", "pilot")
+	if err != nil {
+		return &FuelRequestResponse{
+			ErrorMessage: err.Error(),
+		}, nil
+	}
+
+	r, err := s.app.RequestFuel(user, req.AircraftName, req.Note)
+	if err != nil {
+		return &FuelRequestResponse{
+			ErrorMessage: fmt.Sprintf("RequestFuel: %v", err),
+		}, nil
+	}
+	return &FuelRequestResponse{
+		Request: translateFuelRequest(r),
+	}, nil
+}
+
+func (s *manifestServiceServer) AcknowledgeFuelRequest(
+	ctx context.Context,
+	req *UpdateFuelRequestRequest,
+) (*FuelRequestResponse, error) {
+	user, err := s.sessionUser(ctx, req.SessionId, "admin", "fuel")
+	if err != nil {
+		return &FuelRequestResponse{
+			ErrorMessage: err.Error(),
+		}, nil
+	}
+
+	if err = s.app.AcknowledgeFuelRequest(user, int64(req.Id)); err != nil {
+		return &FuelRequestResponse{
+			ErrorMessage: fmt.Sprintf("AcknowledgeFuelRequest: %v", err),
+		}, nil
+	}
+	return &FuelRequestResponse{}, nil
+}
+
+func (s *manifestServiceServer) CompleteFuelRequest(
+	ctx context.Context,
+	req *UpdateFuelRequestRequest,
+) (*FuelRequestResponse, error) {
+	user, err := s.sessionUser(ctx, req.SessionId, "admin", "fuel")
+	if err != nil {
+		return &FuelRequestResponse{
+			ErrorMessage: err.Error(),
+		}, nil
+	}
+
+	if err = s.app.CompleteFuelRequest(user, int64(req.Id)); err != nil {
+		return &FuelRequestResponse{
+			ErrorMessage: fmt.Sprintf("CompleteFuelRequest: %v", err),
+		}, nil
+	}
+	return &FuelRequestResponse{}, nil
+}
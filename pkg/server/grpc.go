@@ -8,53 +8,56 @@ import (
 	"fmt"
 	"os"
 	"strconv"
-	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/jumptown-skydiving/manifest-server/pkg/burble"
 	"github.com/jumptown-skydiving/manifest-server/pkg/core"
 	"github.com/jumptown-skydiving/manifest-server/pkg/db"
-	"github.com/jumptown-skydiving/manifest-server/pkg/settings"
 	"github.com/orangematt/siwa"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-type addClientResponse struct {
-	id uint64
-}
-
-type addClientRequest struct {
-	reply   chan addClientResponse
-	updates chan *ManifestUpdate
-}
-
-type removeClientResponse struct{}
-
-type removeClientRequest struct {
-	reply chan removeClientResponse
-	id    uint64
-}
-
 type manifestServiceServer struct {
 	UnimplementedManifestServiceServer
 
-	app     *core.Controller
-	options settings.Options
-	wg      sync.WaitGroup
-	cancel  context.CancelFunc
-
-	addClientChan    chan addClientRequest
-	removeClientChan chan removeClientRequest
+	app    *core.Controller
+	cancel context.CancelFunc
+
+	// epoch identifies this server instance, so that sequence numbers
+	// from before a restart are not mistaken for current ones.
+	epoch uint64
+
+	// lock protects lastUpdate, updateTimes, replay, and clients.
+	// lastUpdate is the complete current update, which is modified only by
+	// the update worker. Its sequence is that of the latest change, and
+	// replay remembers the most recent changes. updateTimes records when
+	// each section of lastUpdate last changed.
+	lock        sync.Mutex
+	lastUpdate  *ManifestUpdate
+	updateTimes map[UpdateSection]time.Time
+	replay      replayBuffer
+	clients     map[uint64]*updateClient
+	clientID    uint64
+
+	// boardImages caches the board images rendered for displays by their
+	// sizes and layouts.
+	boardImageLock sync.Mutex
+	boardImages    map[string]*boardImage
 }
 
 func newManifestServiceServer(controller *core.Controller) *manifestServiceServer {
 	return &manifestServiceServer{
-		app:              controller,
-		addClientChan:    make(chan addClientRequest, 16),
-		removeClientChan: make(chan removeClientRequest, 16),
+		app:         controller,
+		epoch:       uint64(time.Now().UnixNano()),
+		updateTimes: make(map[UpdateSection]time.Time),
+		clients:     make(map[uint64]*updateClient),
+		boardImages: make(map[string]*boardImage),
 	}
 }
 
@@ -67,30 +70,14 @@ func (s *manifestServiceServer) translateJumper(j *burble.Jumper, leader *Jumper
 	if leader != nil && (j.IsInstructor || j.IsVideographer) {
 		color = leader.Color
 	} else {
-		switch {
-		case j.IsTandem:
-			color = 0xffff00 // yellow
-			if leader == nil {
-				prefix = "Tandem"
-				shortName = ""
-			}
-		case j.IsStudent || strings.HasSuffix(j.ShortName, " + Gear"):
-			color = 0x00ff00 // green
-			if strings.HasSuffix(j.ShortName, " H/P") {
-				prefix = "H&P"
-			}
-		case strings.HasPrefix(j.ShortName, "3-5k") || strings.HasPrefix(j.ShortName, "3.5k"):
-			if j.IsPondSwoop {
-				color = 0x00ffff // cyan
-			} else {
-				color = 0xff00ff // magenta
-			}
-			prefix = "H&P"
-		case j.IsPondSwoop:
-			color = 0x00ffff // cyan
-		default:
+		color = j.Color
+		if color == 0 {
 			color = 0xffffff // white
 		}
+		prefix = j.Prefix
+		if j.HideShortName {
+			shortName = ""
+		}
 	}
 
 	var repr string
@@ -105,8 +92,8 @@ func (s *manifestServiceServer) translateJumper(j *burble.Jumper, leader *Jumper
 	} else {
 		repr = fmt.Sprintf("%s%s", j.Name, shortName)
 	}
-	if j.IsPondSwoop {
-		repr = "🏄" + repr
+	if j.Icon != "" {
+		repr = j.Icon + repr
 	}
 	if j.IsTurning && load.IsTurning {
 		repr = "♻️ " + repr
@@ -128,29 +115,59 @@ func (s *manifestServiceServer) translateJumper(j *burble.Jumper, leader *Jumper
 			if j.IsInstructor {
 				t = JumperType_AFF_INSTRUCTOR
 			}
-		case JumperType_COACH_STUDENT:
+		case JumperType_COACH_STUDENT, JumperType_HOP_AND_POP_STUDENT:
 			if j.IsInstructor {
 				t = JumperType_COACH
 			}
+		case JumperType_IAD_STUDENT:
+			if j.IsInstructor {
+				t = JumperType_IAD_INSTRUCTOR
+			}
 		}
 	} else {
 		switch {
 		case j.IsTandem:
 			t = JumperType_TANDEM_STUDENT
 		case j.IsStudent:
-			// TODO how to distinguish between AFF / Coach?
-			t = JumperType_AFF_STUDENT
+			switch j.StudentProgram {
+			case burble.CoachStudentProgram:
+				t = JumperType_COACH_STUDENT
+			case burble.IADStudentProgram:
+				t = JumperType_IAD_STUDENT
+			case burble.HopAndPopStudentProgram:
+				t = JumperType_HOP_AND_POP_STUDENT
+			default:
+				// Students whose program is unknown are most
+				// likely AFF students.
+				t = JumperType_AFF_STUDENT
+			}
+		}
+	}
+
+	var program StudentProgram
+	if j.IsStudent {
+		switch j.StudentProgram {
+		case burble.AFFStudentProgram:
+			program = StudentProgram_PROGRAM_AFF
+		case burble.CoachStudentProgram:
+			program = StudentProgram_PROGRAM_COACH
+		case burble.IADStudentProgram:
+			program = StudentProgram_PROGRAM_IAD
+		case burble.HopAndPopStudentProgram:
+			program = StudentProgram_PROGRAM_HOP_AND_POP
 		}
 	}
 
 	return &Jumper{
-		Id:        uint64(j.ID),
-		Type:      t,
-		Name:      j.Name,
-		ShortName: j.ShortName,
-		Color:     color,
-		Repr:      repr,
-		RigName:   j.RigName,
+		Id:             uint64(j.ID),
+		Type:           t,
+		Name:           j.Name,
+		ShortName:      j.ShortName,
+		Color:          color,
+		Repr:           repr,
+		RigName:        j.RigName,
+		StudentProgram: program,
+		AffLevel:       int32(j.AFFLevel),
 	}
 }
 
@@ -177,21 +194,20 @@ func (s *manifestServiceServer) slotFromJumper(j *burble.Jumper, load *burble.Lo
 	}
 }
 
-func (s *manifestServiceServer) constructUpdate(source core.DataSource) *ManifestUpdate {
+// constructUpdate constructs the sections of an update that depend on the
+// given data sources, restricted by f.
+func (s *manifestServiceServer) constructUpdate(source core.DataSource, f *updateFilter) *ManifestUpdate {
 	u := &ManifestUpdate{}
+	units := f.unitsOrDefault()
 
-	const sunriseSources = core.PreSunriseDataSource | core.SunriseDataSource
-	const sunsetSources = core.PreSunsetDataSource | core.SunsetDataSource
-	const optionsSources = core.OptionsDataSource | sunriseSources | sunsetSources
-	if source&optionsSources != 0 {
-		s.options = s.app.Settings().Options()
-		o := s.options
+	if source&optionsSources != 0 && f.wants(UpdateSection_SECTION_OPTIONS) {
+		o := s.app.Settings().Options()
 		u.Options = &Options{
 			DisplayWeather: o.DisplayWeather,
 			DisplayWinds:   o.DisplayWinds,
 			Message:        o.Message,
 			MessageColor:   0xffffff,
-			FuelRequested:  o.FuelRequested,
+			FuelRequested:  o.FuelRequested || len(s.app.FuelRequests()) > 0,
 		}
 		if source&sunriseSources != 0 {
 			u.Options.Sunrise = s.app.SunriseMessage()
@@ -201,42 +217,51 @@ func (s *manifestServiceServer) constructUpdate(source core.DataSource) *Manifes
 		}
 	}
 
-	const statusSources = core.METARDataSource | core.WindsAloftDataSource
-	if source&statusSources != 0 {
+	if source&statusSources != 0 && f.wants(UpdateSection_SECTION_STATUS) {
 		var (
 			separationColor  uint32
 			separationString string
 		)
 		if s.app.WindsAloftSource() != nil {
-			separationColor, separationString = s.app.SeparationStrings()
+			separationColor, separationString = s.app.SeparationStringsIn(units)
 		} else {
 			separationColor = 0xffffff
 		}
 
 		var winds, clouds, weather, temperature string
 		if m := s.app.METARSource(); m != nil {
-			winds = m.WindConditions()
+			winds = m.WindConditionsIn(units)
 			clouds = m.SkyCover()
 			weather = m.WeatherConditions()
-			temperature = m.TemperatureString()
+			temperature = m.TemperatureStringIn(units)
+		}
+
+		// Grey out weather information that is out of date rather
+		// than presenting it as current.
+		now := time.Now()
+		weatherColor := uint32(0xffffff)
+		if s.isStale("metar", now) {
+			weatherColor = staleColor
+		}
+		if s.isStale("winds", now) {
+			separationColor = staleColor
 		}
 
 		u.Status = &Status{
 			Winds:            winds,
-			WindsColor:       0xffffff,
+			WindsColor:       weatherColor,
 			Clouds:           clouds,
-			CloudsColor:      0xffffff,
+			CloudsColor:      weatherColor,
 			Weather:          weather,
-			WeatherColor:     0xffffff,
+			WeatherColor:     weatherColor,
 			Separation:       separationString,
 			SeparationColor:  separationColor,
 			Temperature:      temperature,
-			TemperatureColor: 0xffffff,
+			TemperatureColor: weatherColor,
 		}
 	}
 
-	const jumprunSources = core.JumprunDataSource
-	if source&jumprunSources != 0 {
+	if source&jumprunSources != 0 && f.wants(UpdateSection_SECTION_JUMPRUN) {
 		j := s.app.Jumprun().Jumprun()
 		u.Jumprun = &Jumprun{
 			Origin: &JumprunOrigin{
@@ -266,8 +291,7 @@ func (s *manifestServiceServer) constructUpdate(source core.DataSource) *Manifes
 		}
 	}
 
-	const windsAloftSources = core.WindsAloftDataSource
-	if source&windsAloftSources != 0 {
+	if source&windsAloftSources != 0 && f.wants(UpdateSection_SECTION_WINDS_ALOFT) {
 		w := s.app.WindsAloftSource()
 		u.WindsAloft = &WindsAloft{}
 		for _, sample := range w.Samples() {
@@ -282,13 +306,50 @@ func (s *manifestServiceServer) constructUpdate(source core.DataSource) *Manifes
 		}
 	}
 
-	const loadsSources = core.BurbleDataSource | core.OptionsDataSource
-	if source&loadsSources != 0 {
-		b := s.app.BurbleSource()
+	if source&fuelSources != 0 && f.wants(UpdateSection_SECTION_FUEL_REQUESTS) {
+		settings := s.app.Settings()
+		u.FuelRequests = &FuelRequests{}
+		for _, r := range s.app.FuelRequests() {
+			if !f.wantsAircraft(settings.CanonicalAircraftName(r.AircraftName)) {
+				continue
+			}
+			u.FuelRequests.Requests = append(u.FuelRequests.Requests,
+				translateFuelRequest(r))
+		}
+	}
+
+	if source&healthSources != 0 && f.wants(UpdateSection_SECTION_SOURCE_HEALTH) {
+		now := time.Now()
+		u.SourceHealth = &SourceHealth{}
+		for _, h := range s.app.SourceHealth() {
+			u.SourceHealth.Sources = append(u.SourceHealth.Sources,
+				translateSourceHealth(h, now))
+		}
+	}
+
+	if source&loadsSources != 0 && f.wants(UpdateSection_SECTION_LOADS) {
+		b := s.app.ManifestSource()
 		u.Loads = &Loads{
-			ColumnCount: int32(b.ColumnCount()),
+			ColumnCount: int32(f.limitColumns(b.ColumnCount())),
+		}
+		for _, a := range s.app.Settings().Aircraft() {
+			if !f.wantsAircraft(a.Name) {
+				continue
+			}
+			u.Loads.Aircraft = append(u.Loads.Aircraft, &Aircraft{
+				Name:            a.Name,
+				Aliases:         a.Aliases,
+				TailNumber:      a.TailNumber,
+				IcaoHex:         a.ICAOHex,
+				Capacity:        int32(a.Capacity),
+				JumprunAirspeed: int32(a.JumprunAirspeed),
+				ClimbRate:       int32(a.ClimbRate),
+				ExitAltitude:    int32(a.ExitAltitude),
+				Color:           a.Color,
+				Order:           int32(a.Order),
+			})
 		}
-		for _, l := range b.Loads() {
+		for _, l := range f.limitLoads(b.Loads()) {
 			var callMinutes string
 			if !l.IsNoTime {
 				if l.CallMinutes == 0 {
@@ -371,22 +432,21 @@ func (x *ManifestUpdate) diff(y *ManifestUpdate) bool {
 	if proto.Equal(x.Loads, y.Loads) {
 		x.Loads = nil
 	}
+	if proto.Equal(x.FuelRequests, y.FuelRequests) {
+		x.FuelRequests = nil
+	}
+	if proto.Equal(x.SourceHealth, y.SourceHealth) {
+		x.SourceHealth = nil
+	}
 	return x.Status != nil || x.Options != nil || x.Jumprun != nil ||
-		x.WindsAloft != nil || x.Loads != nil
+		x.WindsAloft != nil || x.Loads != nil || x.FuelRequests != nil ||
+		x.SourceHealth != nil
 }
 
-func (s *manifestServiceServer) processUpdates(ctx context.Context) {
-	c := make(chan core.DataSource, 128)
-	id := s.app.AddListener(c)
-	defer func() {
-		s.app.RemoveListener(id)
-	}()
-
-	clientID := uint64(0)
-	clients := make(map[uint64]chan *ManifestUpdate)
-
-	// Create and send the initial baseline ManifestUpdate
-	source := core.BurbleDataSource | core.OptionsDataSource
+// baselineSources returns the data sources that make up a complete update.
+func (s *manifestServiceServer) baselineSources() core.DataSource {
+	source := core.BurbleDataSource | core.OptionsDataSource | core.FuelDataSource |
+		core.HealthDataSource
 	if s.app.Jumprun() != nil {
 		source |= core.JumprunDataSource
 	}
@@ -396,63 +456,46 @@ func (s *manifestServiceServer) processUpdates(ctx context.Context) {
 	if s.app.WindsAloftSource() != nil {
 		source |= core.WindsAloftDataSource
 	}
-	lastUpdate := s.constructUpdate(source)
+	return source
+}
+
+// publishUpdate sends the changes to source since the last update to every
+// client.
+func (s *manifestServiceServer) publishUpdate(source core.DataSource) {
+	// Only the update worker modifies lastUpdate, so it may be read
+	// without holding the lock.
+	u := s.constructUpdate(source, nil)
+	if !u.diff(s.lastUpdate) {
+		return
+	}
+
+	settings := s.app.Settings()
+	policy := settings.SlowClientPolicy()
+	timeout := settings.SlowClientTimeout()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	u.Sequence = s.lastUpdate.Sequence + 1
+	u.Epoch = s.epoch
+	s.replay.push(u.Sequence, u.sources(), settings.ReplayBufferSize())
+	s.lastUpdate.merge(u)
+	s.setUpdateTimes(u, time.Now())
+	for _, client := range s.clients {
+		client.enqueue(u, policy, timeout)
+	}
+}
 
+// processUpdates publishes updates as sub reports changes until the
+// controller is done.
+func (s *manifestServiceServer) processUpdates(sub *core.Subscription) {
 	for {
 		select {
-		case <-ctx.Done():
+		case <-s.app.Done():
 			return
 
-		case req := <-s.addClientChan:
-			clientID++
-			clients[clientID] = req.updates
-			req.reply <- addClientResponse{
-				id: clientID,
-			}
-			update := proto.Clone(lastUpdate).(*ManifestUpdate)
-			req.updates <- update
-
-		case req := <-s.removeClientChan:
-			delete(clients, req.id)
-			req.reply <- removeClientResponse{}
-
-		case source = <-c:
-		drain:
-			for {
-				select {
-				case s := <-c:
-					source |= s
-				default:
-					break drain
-				}
-			}
-			if u := s.constructUpdate(source); u.diff(lastUpdate) {
-				for _, client := range clients {
-					update := proto.Clone(u).(*ManifestUpdate)
-					client <- update
-				}
-				// We cannot use proto.Merge here because we
-				// attribute meaning to nil on optional fields,
-				// but proto.Merge ignores nil when merging in,
-				// not clearing the field in the destination.
-				// This is what we want at the top-level, but
-				// not the lower levels.
-				//proto.Merge(lastUpdate, u)
-				if u.Status != nil {
-					lastUpdate.Status = u.Status
-				}
-				if u.Options != nil {
-					lastUpdate.Options = u.Options
-				}
-				if u.Jumprun != nil {
-					lastUpdate.Jumprun = u.Jumprun
-				}
-				if u.WindsAloft != nil {
-					lastUpdate.WindsAloft = u.WindsAloft
-				}
-				if u.Loads != nil {
-					lastUpdate.Loads = u.Loads
-				}
+		case <-sub.Ready():
+			if source := sub.Take(); source != 0 {
+				s.publishUpdate(source)
 			}
 		}
 	}
@@ -462,44 +505,62 @@ func (s *manifestServiceServer) Start() {
 	ctx, cancel := context.WithCancel(context.Background())
 	s.cancel = cancel
 
-	s.wg.Add(1)
-	go func() {
-		defer s.wg.Done()
-		s.processUpdates(ctx)
-	}()
+	// Subscribe before creating the baseline update so that no changes
+	// are missed.
+	sub := s.app.Subscribe(ctx, core.AllDataSources)
+	s.lastUpdate = s.constructUpdate(s.baselineSources(), nil)
+	s.lastUpdate.Epoch = s.epoch
+	s.lastUpdate.IsSnapshot = true
+	s.setUpdateTimes(s.lastUpdate, time.Now())
+
+	// Updates are processed by a worker supervised by the controller, so
+	// that a panic constructing one restarts the worker rather than
+	// killing the process.
+	s.app.Go("Update Stream", func() {
+		if sub == nil {
+			// The worker failed, so changes may have been missed
+			// until it subscribes again.
+			sub = s.app.Subscribe(ctx, core.AllDataSources)
+			s.publishUpdate(s.baselineSources())
+		}
+		defer func() {
+			sub.Unsubscribe()
+			sub = nil
+		}()
+		s.processUpdates(sub)
+	})
 }
 
+// Stop stops the server once its controller is done.
 func (s *manifestServiceServer) Stop() {
 	s.cancel()
-	s.wg.Wait()
 }
 
-func (s *manifestServiceServer) addClient(c chan *ManifestUpdate) uint64 {
-	request := addClientRequest{
-		reply:   make(chan addClientResponse),
-		updates: c,
-	}
-	s.addClientChan <- request
-	response := <-request.reply
-	return response.id
+// addClient adds a StreamUpdates client, whose first update is the complete
+// current update.
+func (s *manifestServiceServer) addClient(c *updateClient) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.clientID++
+	c.id = s.clientID
+	s.clients[c.id] = c
+	c.enqueue(s.lastUpdate, "coalesce", 0)
 }
 
-func (s *manifestServiceServer) removeClient(id uint64) {
-	request := removeClientRequest{
-		reply: make(chan removeClientResponse),
-		id:    id,
-	}
-	s.removeClientChan <- request
-	<-request.reply
+func (s *manifestServiceServer) removeClient(c *updateClient) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.clients, c.id)
+	c.close()
 }
 
 func (s *manifestServiceServer) StreamUpdates(
 	_ *emptypb.Empty,
 	stream ManifestService_StreamUpdatesServer,
 ) error {
-	c := make(chan *ManifestUpdate, 16)
-	id := s.addClient(c)
-	defer s.removeClient(id)
+	c := newUpdateClient(stream.Context())
+	s.addClient(c)
+	defer s.removeClient(c)
 
 	for {
 		select {
@@ -507,8 +568,17 @@ func (s *manifestServiceServer) StreamUpdates(
 			return nil
 		case <-s.app.Done():
 			return nil
-		case u := <-c:
-			if err := stream.Send(u); err != nil {
+		case <-c.disconnected:
+			return status.Error(codes.ResourceExhausted,
+				"client is too slow to receive updates")
+		case <-c.ready:
+			u := c.take(s.snapshot)
+			if u == nil {
+				continue
+			}
+			err := stream.Send(u)
+			c.sendDone()
+			if err != nil {
 				return err
 			}
 		}
@@ -701,7 +771,7 @@ func (s *manifestServiceServer) ToggleFuelRequested(
 			ErrorMessage: errorMessage,
 		}, nil
 	} else {
-		s.app.WakeListeners(core.OptionsDataSource)
+		s.app.Publish(core.OptionsDataSource)
 		return &ToggleFuelRequestedResponse{}, nil
 	}
 }
@@ -0,0 +1,152 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// gRPC-Web is gRPC framed so that browsers can speak it over HTTP/1.1 or
+// HTTP/2 without access to trailers: the response's trailers are sent as a
+// final frame in the body. With the "-text" content types, request and
+// response bodies are also base64 encoded.
+const (
+	grpcWebContentType     = "application/grpc-web"
+	grpcWebTextContentType = "application/grpc-web-text"
+
+	// grpcWebTrailerFlag marks the frame containing trailers.
+	grpcWebTrailerFlag = 0x80
+)
+
+// isGRPCWebRequest reports whether req is a gRPC-Web request.
+func isGRPCWebRequest(req *http.Request) bool {
+	return req.Method == http.MethodPost &&
+		strings.HasPrefix(req.Header.Get("Content-Type"), grpcWebContentType)
+}
+
+// isGRPCRequest reports whether req is a native gRPC request.
+func isGRPCRequest(req *http.Request) bool {
+	return req.ProtoMajor == 2 && req.Method == http.MethodPost &&
+		strings.HasPrefix(req.Header.Get("Content-Type"), "application/grpc") &&
+		!isGRPCWebRequest(req)
+}
+
+// grpcWebResponseWriter translates the response that the gRPC server writes
+// for a native gRPC request into a gRPC-Web response.
+type grpcWebResponseWriter struct {
+	w           http.ResponseWriter
+	header      http.Header
+	contentType string
+	isText      bool
+	wroteHeader bool
+}
+
+// WriteHeader writes the headers that the gRPC server has set so far, less
+// its trailer declarations.
+func (g *grpcWebResponseWriter) WriteHeader(code int) {
+	if g.wroteHeader {
+		return
+	}
+	g.wroteHeader = true
+
+	h := g.w.Header()
+	for k, vv := range g.header {
+		if k == "Trailer" || strings.HasPrefix(k, http.TrailerPrefix) {
+			continue
+		}
+		h[k] = vv
+	}
+	h.Set("Content-Type", g.contentType)
+	h.Del("Content-Length")
+	g.w.WriteHeader(code)
+}
+
+func (g *grpcWebResponseWriter) Header() http.Header {
+	return g.header
+}
+
+func (g *grpcWebResponseWriter) Write(b []byte) (int, error) {
+	g.WriteHeader(http.StatusOK)
+	if g.isText {
+		_, err := io.WriteString(g.w, base64.StdEncoding.EncodeToString(b))
+		return len(b), err
+	}
+	return g.w.Write(b)
+}
+
+func (g *grpcWebResponseWriter) Flush() {
+	g.WriteHeader(http.StatusOK)
+	if f, ok := g.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// writeTrailers writes the trailers that the gRPC server has set, both
+// declared and undeclared, as the final frame of the body.
+func (g *grpcWebResponseWriter) writeTrailers() {
+	var buf bytes.Buffer
+	writeTrailer := func(k string, vv []string) {
+		for _, v := range vv {
+			fmt.Fprintf(&buf, "%s: %s\r\n", strings.ToLower(k), v)
+		}
+	}
+	for _, declared := range g.header["Trailer"] {
+		for _, k := range strings.Split(declared, ",") {
+			k = http.CanonicalHeaderKey(strings.TrimSpace(k))
+			writeTrailer(k, g.header[k])
+		}
+	}
+	for k, vv := range g.header {
+		if strings.HasPrefix(k, http.TrailerPrefix) {
+			writeTrailer(strings.TrimPrefix(k, http.TrailerPrefix), vv)
+		}
+	}
+
+	frame := make([]byte, 5, 5+buf.Len())
+	frame[0] = grpcWebTrailerFlag
+	binary.BigEndian.PutUint32(frame[1:], uint32(buf.Len()))
+	frame = append(frame, buf.Bytes()...)
+	_, _ = g.Write(frame)
+	g.Flush()
+}
+
+// serveGRPCWeb serves a gRPC-Web request by presenting it to the gRPC server
+// as a native gRPC request.
+func (s *WebServer) serveGRPCWeb(w http.ResponseWriter, req *http.Request) {
+	contentType := req.Header.Get("Content-Type")
+	isText := strings.HasPrefix(contentType, grpcWebTextContentType)
+	subtype := "+proto"
+	if i := strings.IndexAny(contentType, "+;"); i >= 0 && contentType[i] == '+' {
+		subtype = contentType[i:]
+		if j := strings.IndexByte(subtype, ';'); j >= 0 {
+			subtype = subtype[:j]
+		}
+	}
+
+	r := req.Clone(req.Context())
+	r.ProtoMajor = 2
+	r.ProtoMinor = 0
+	r.Header.Set("Content-Type", "application/grpc"+subtype)
+	r.Header.Del("Content-Length")
+	if isText {
+		r.Body = io.NopCloser(base64.NewDecoder(base64.StdEncoding, req.Body))
+	}
+
+	g := &grpcWebResponseWriter{
+		w:           w,
+		header:      make(http.Header),
+		contentType: grpcWebContentType + subtype,
+		isText:      isText,
+	}
+	if isText {
+		g.contentType = grpcWebTextContentType + subtype
+	}
+	s.grpcServer.ServeHTTP(g, r)
+	g.writeTrailers()
+}
@@ -0,0 +1,222 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+// grpcWebFrame is a frame of a gRPC-Web response body.
+type grpcWebFrame struct {
+	flags byte
+	data  []byte
+}
+
+// readGRPCWebFrames splits a binary gRPC-Web response body into frames.
+func readGRPCWebFrames(t *testing.T, body []byte) []grpcWebFrame {
+	t.Helper()
+	var frames []grpcWebFrame
+	for len(body) > 0 {
+		if len(body) < 5 {
+			t.Fatalf("truncated frame header %x", body)
+		}
+		n := binary.BigEndian.Uint32(body[1:5])
+		if uint32(len(body)-5) < n {
+			t.Fatalf("frame of %d bytes has only %d", n, len(body)-5)
+		}
+		frames = append(frames, grpcWebFrame{flags: body[0], data: body[5 : 5+n]})
+		body = body[5+n:]
+	}
+	return frames
+}
+
+// parseGRPCWebTrailers parses the trailers in a gRPC-Web trailer frame.
+func parseGRPCWebTrailers(t *testing.T, data []byte) map[string][]string {
+	t.Helper()
+	trailers := make(map[string][]string)
+	for _, line := range strings.Split(string(data), "\r\n") {
+		if line == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(line, ": ")
+		if !ok {
+			t.Fatalf("malformed trailer %q", line)
+		}
+		trailers[k] = append(trailers[k], v)
+	}
+	return trailers
+}
+
+func TestGRPCWebTrailers(t *testing.T) {
+	message := []byte{0, 0, 0, 0, 1, 0x2a} // one data frame; 6 bytes so that its base64 is unpadded
+
+	tests := []struct {
+		name   string
+		isText bool
+		header http.Header // set by the gRPC server after writing message
+		want   map[string][]string
+	}{
+		{
+			name:   "declared trailers",
+			header: http.Header{"Trailer": {"Grpc-Status, Grpc-Message"}, "Grpc-Status": {"0"}, "Grpc-Message": {""}},
+			want:   map[string][]string{"grpc-status": {"0"}, "grpc-message": {""}},
+		},
+		{
+			name: "undeclared trailers",
+			header: http.Header{
+				http.TrailerPrefix + "Grpc-Status":  {"5"},
+				http.TrailerPrefix + "Grpc-Message": {"not found"},
+			},
+			want: map[string][]string{"grpc-status": {"5"}, "grpc-message": {"not found"}},
+		},
+		{
+			name:   "base64 encoded",
+			isText: true,
+			header: http.Header{"Trailer": {"Grpc-Status"}, "Grpc-Status": {"0"}, "X-Extra": {"a", "b"}},
+			want:   map[string][]string{"grpc-status": {"0"}},
+		},
+		{
+			name: "no trailers",
+			want: map[string][]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			g := &grpcWebResponseWriter{
+				w:           rec,
+				header:      make(http.Header),
+				contentType: grpcWebContentType + "+proto",
+				isText:      tt.isText,
+			}
+			g.Header().Set("Trailer", "Grpc-Status")
+			if _, err := g.Write(message); err != nil {
+				t.Fatal(err)
+			}
+			for k, vv := range tt.header {
+				g.Header()[k] = vv
+			}
+			g.writeTrailers()
+
+			if got := rec.Header().Get("Content-Type"); got != g.contentType {
+				t.Errorf("Content-Type %q, want %q", got, g.contentType)
+			}
+			if got := rec.Header().Values("Trailer"); len(got) != 0 {
+				t.Errorf("trailers declared in the response headers: %q", got)
+			}
+
+			body := rec.Body.Bytes()
+			if tt.isText {
+				var err error
+				if body, err = base64.StdEncoding.DecodeString(string(body)); err != nil {
+					t.Fatalf("body is not base64: %v", err)
+				}
+			}
+			frames := readGRPCWebFrames(t, body)
+			if len(frames) != 2 {
+				t.Fatalf("%d frames, want 2", len(frames))
+			}
+			if frames[0].flags != 0 || !bytes.Equal(frames[0].data, message[5:]) {
+				t.Errorf("data frame %+v, want the message", frames[0])
+			}
+			if frames[1].flags != grpcWebTrailerFlag {
+				t.Errorf("trailer frame flags %#x, want %#x", frames[1].flags, grpcWebTrailerFlag)
+			}
+			if got := parseGRPCWebTrailers(t, frames[1].data); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("trailers %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServeGRPCWeb(t *testing.T) {
+	s := &WebServer{grpcServer: grpc.NewServer()}
+
+	tests := []struct {
+		name        string
+		contentType string
+		wantType    string
+	}{
+		{"binary", "application/grpc-web", "application/grpc-web+proto"},
+		{"binary with subtype", "application/grpc-web+proto", "application/grpc-web+proto"},
+		{"text", "application/grpc-web-text", "application/grpc-web-text+proto"},
+		{"text with parameters", "application/grpc-web-text+proto; charset=utf-8", "application/grpc-web-text+proto"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// An empty message for a method that is not registered.
+			body := "\x00\x00\x00\x00\x00"
+			if strings.HasPrefix(tt.contentType, grpcWebTextContentType) {
+				body = base64.StdEncoding.EncodeToString([]byte(body))
+			}
+			req := httptest.NewRequest(http.MethodPost, "/test.Service/Missing", strings.NewReader(body))
+			req.Header.Set("Content-Type", tt.contentType)
+			if !isGRPCWebRequest(req) || isGRPCRequest(req) {
+				t.Fatal("not recognized as a gRPC-Web request")
+			}
+
+			rec := httptest.NewRecorder()
+			s.serveGRPCWeb(rec, req)
+
+			if got := rec.Header().Get("Content-Type"); got != tt.wantType {
+				t.Errorf("Content-Type %q, want %q", got, tt.wantType)
+			}
+			respBody := rec.Body.Bytes()
+			if tt.wantType == "application/grpc-web-text+proto" {
+				var err error
+				if respBody, err = base64.StdEncoding.DecodeString(string(respBody)); err != nil {
+					t.Fatalf("body is not base64: %v", err)
+				}
+			}
+			frames := readGRPCWebFrames(t, respBody)
+			if len(frames) == 0 || frames[len(frames)-1].flags != grpcWebTrailerFlag {
+				t.Fatalf("frames %+v do not end with trailers", frames)
+			}
+			trailers := parseGRPCWebTrailers(t, frames[len(frames)-1].data)
+			if got := trailers["grpc-status"]; len(got) != 1 || got[0] != "12" {
+				t.Errorf("grpc-status %q, want Unimplemented (12)", got)
+			}
+		})
+	}
+}
+
+func TestIsGRPCRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		protoMajor  int
+		contentType string
+		grpc        bool
+		grpcWeb     bool
+	}{
+		{"gRPC", http.MethodPost, 2, "application/grpc", true, false},
+		{"gRPC with subtype", http.MethodPost, 2, "application/grpc+proto", true, false},
+		{"gRPC over HTTP/1.1", http.MethodPost, 1, "application/grpc", false, false},
+		{"gRPC-Web", http.MethodPost, 1, "application/grpc-web+proto", false, true},
+		{"gRPC-Web over HTTP/2", http.MethodPost, 2, "application/grpc-web-text", false, true},
+		{"GET", http.MethodGet, 2, "application/grpc", false, false},
+		{"JSON", http.MethodPost, 2, "application/json", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/", nil)
+			req.ProtoMajor = tt.protoMajor
+			req.Header.Set("Content-Type", tt.contentType)
+			if got := isGRPCRequest(req); got != tt.grpc {
+				t.Errorf("isGRPCRequest() = %v, want %v", got, tt.grpc)
+			}
+			if got := isGRPCWebRequest(req); got != tt.grpcWeb {
+				t.Errorf("isGRPCWebRequest() = %v, want %v", got, tt.grpcWeb)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import (
+	"time"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/core"
+)
+
+// staleColor is used for weather information that is out of date.
+const staleColor = 0x808080
+
+func unixSeconds(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func translateSourceHealth(h core.SourceHealth, now time.Time) *DataSourceHealth {
+	return &DataSourceHealth{
+		Id:                  h.ID,
+		Name:                h.Name,
+		LastAttempt:         unixSeconds(h.LastAttempt),
+		LastSuccess:         unixSeconds(h.LastSuccess),
+		LastError:           h.LastError,
+		ConsecutiveFailures: int32(h.ConsecutiveFailures),
+		NextRefresh:         unixSeconds(h.NextRefresh),
+		DataAge:             int64(h.DataAge(now) / time.Second),
+		StaleAfter:          int64(h.StaleAfter / time.Second),
+		IsStale:             h.IsStale(now),
+		DecodeErrors:        h.DecodeErrors,
+	}
+}
+
+// isStale returns true if the data from the source with the given ID is
+// stale.
+func (s *manifestServiceServer) isStale(id string, now time.Time) bool {
+	h, ok := s.app.LookupSourceHealth(id)
+	return ok && h.IsStale(now)
+}
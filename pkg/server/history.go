@@ -0,0 +1,76 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/db"
+)
+
+func unixTime(t int64) time.Time {
+	if t == 0 {
+		return time.Time{}
+	}
+	return time.Unix(t, 0)
+}
+
+func (s *manifestServiceServer) QueryLoadHistory(
+	ctx context.Context,
+	req *LoadHistoryRequest,
+) (*LoadHistoryResponse, error) {
+	// The history reveals who jumped when, so only admins may search it.
+	if _, err := s.sessionUser(ctx, req.SessionId, "admin"); err != nil {
+		return &LoadHistoryResponse{
+			ErrorMessage: err.Error(),
+		}, nil
+	}
+
+	query := db.LoadQuery{
+		Start:        unixTime(req.StartTime),
+		End:          unixTime(req.EndTime),
+		AircraftName: req.AircraftName,
+		JumperName:   req.JumperName,
+		Limit:        int(req.Limit),
+	}
+	records, err := s.app.QueryLoadHistory(query)
+	if err != nil {
+		return &LoadHistoryResponse{
+			ErrorMessage: fmt.Sprintf("QueryLoadHistory: %v", err),
+		}, nil
+	}
+
+	resp := &LoadHistoryResponse{}
+	for _, r := range records {
+		l := &LoadHistory{
+			Id:           uint64(r.ID),
+			AircraftName: r.AircraftName,
+			LoadNumber:   r.LoadNumber,
+			CallMinutes:  int32(r.CallMinutes),
+			FirstSeen:    r.FirstSeen.Unix(),
+			LastSeen:     r.LastSeen.Unix(),
+		}
+		for _, j := range r.Jumpers {
+			l.Jumpers = append(l.Jumpers, &LoadHistoryJumper{
+				Id:          uint64(j.ID),
+				Name:        j.Name,
+				ShortName:   j.ShortName,
+				RigName:     j.RigName,
+				GroupLeader: j.GroupLeader,
+				FirstSeen:   j.FirstSeen.Unix(),
+				LastSeen:    j.LastSeen.Unix(),
+			})
+		}
+		for _, t := range r.CallTimes {
+			l.CallTimes = append(l.CallTimes, &LoadHistoryCallTime{
+				CallMinutes: int32(t.CallMinutes),
+				Time:        t.Time.Unix(),
+			})
+		}
+		resp.Loads = append(resp.Loads, l)
+	}
+
+	return resp, nil
+}
@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -15,6 +16,9 @@ import (
 
 	"github.com/jumptown-skydiving/manifest-server/pkg/core"
 
+	"github.com/gorilla/websocket"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
@@ -31,6 +35,7 @@ type WebContent struct {
 	Content     []byte
 	ContentType string
 	ModifyTime  time.Time
+	IsWebSocket bool
 }
 
 type WebServer struct {
@@ -41,26 +46,45 @@ type WebServer struct {
 	certFile string
 	keyFile  string
 
-	app *core.Controller
+	apps []*core.Controller
 
+	// grpcServer serves gRPC on its own listener if grpcServerAddress
+	// is set, and gRPC and gRPC-Web on the HTTPS listener, or on the
+	// HTTP listener if it serves cleartext HTTP/2.
 	grpcServer        *grpc.Server
 	grpcServerAddress string
-	grpcServiceServer *manifestServiceServer
+
+	// corsOrigins are the origins whose pages may make cross-origin
+	// requests, including gRPC-Web requests. "*" allows any origin.
+	corsOrigins []string
+
+	// dropzones serves both gRPC and the JSON API.
+	dropzones *dropzoneRouter
 
 	lock    sync.Mutex
 	content map[string]WebContent
 }
 
+// NewWebServer creates a web server for the dropzones served by controllers.
+// The first controller is the default dropzone. If h2c is true and there is
+// no certificate, the HTTP listener serves cleartext HTTP/2 so that native
+// gRPC may be served on it from behind a TLS-terminating proxy.
 func NewWebServer(
-	controller *core.Controller,
+	controllers []*core.Controller,
 	httpAddress, httpsAddress, grpcAddress, certFile, keyFile string,
+	h2cEnabled bool,
+	corsOrigins []string,
 ) (*WebServer, error) {
+	if len(controllers) == 0 {
+		return nil, errors.New("no dropzones to serve")
+	}
 	s := &WebServer{
-		app:               controller,
+		apps:              controllers,
 		certFile:          certFile,
 		keyFile:           keyFile,
 		content:           make(map[string]WebContent),
 		grpcServerAddress: grpcAddress,
+		corsOrigins:       corsOrigins,
 	}
 	if s.keyFile == "" {
 		s.keyFile = s.certFile
@@ -73,9 +97,15 @@ func NewWebServer(
 	}
 
 	if certFile != "" {
-		// Redirect HTTP requests to HTTPS
+		// Redirect HTTP requests to HTTPS, except for WebSocket
+		// connections, because browsers do not follow redirects for
+		// them.
 		s.httpServer = &http.Server{
 			Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if websocket.IsWebSocketUpgrade(req) && s.isWebSocket(req.URL.Path) {
+					s.requestHandler(w, req)
+					return
+				}
 				w.Header().Set("Connection", "close")
 				// FIXME: this should resolve httpsAddress if it's not default
 				url := fmt.Sprintf("https://%s%s", req.Host, req.URL)
@@ -111,7 +141,7 @@ func NewWebServer(
 			},
 		}
 		s.httpsServer = &http.Server{
-			Handler:      http.HandlerFunc(s.requestHandler),
+			Handler:      http.HandlerFunc(s.serveHTTP),
 			Addr:         httpsAddress,
 			TLSConfig:    c,
 			ReadTimeout:  readTimeout,
@@ -126,25 +156,31 @@ func NewWebServer(
 			s.grpcServer = grpc.NewServer(grpc.Creds(creds))
 		}
 	} else {
+		var handler http.Handler = http.HandlerFunc(s.serveHTTP)
+		if h2cEnabled {
+			handler = h2c.NewHandler(handler, &http2.Server{})
+		}
 		s.httpServer = &http.Server{
-			Handler:      http.HandlerFunc(s.requestHandler),
+			Handler:      handler,
 			Addr:         httpAddress,
 			ReadTimeout:  readTimeout,
 			WriteTimeout: writeTimeout,
 		}
-		if s.grpcServerAddress != "" {
-			s.grpcServer = grpc.NewServer()
-		}
 	}
-	if s.grpcServer != nil {
-		s.grpcServiceServer = newManifestServiceServer(controller)
-		RegisterManifestServiceServer(s.grpcServer, s.grpcServiceServer)
+	if s.grpcServer == nil {
+		s.grpcServer = grpc.NewServer()
 	}
+	s.dropzones = newDropzoneRouter(controllers)
+	RegisterManifestServiceServer(s.grpcServer, s.dropzones)
+	s.registerAPIContent()
+	s.registerBoardContent()
 
 	return s, nil
 }
 
 func (s *WebServer) Start() error {
+	s.dropzones.Start()
+
 	if s.httpsServer != nil {
 		l, err := net.Listen("tcp", s.httpsServer.Addr)
 		if err != nil {
@@ -171,14 +207,12 @@ func (s *WebServer) Start() error {
 		}()
 	}
 
-	if s.grpcServer != nil {
+	if s.grpcServerAddress != "" {
 		l, err := net.Listen("tcp", s.grpcServerAddress)
 		if err != nil {
 			return err
 		}
 
-		s.grpcServiceServer.Start()
-
 		s.wg.Add(1)
 		go func() {
 			defer s.wg.Done()
@@ -197,10 +231,8 @@ func (s *WebServer) Close() {
 	if s.httpsServer != nil {
 		_ = s.httpsServer.Shutdown(ctx)
 	}
-	if s.grpcServer != nil {
-		s.grpcServer.GracefulStop()
-		s.grpcServiceServer.Stop()
-	}
+	s.grpcServer.GracefulStop()
+	s.dropzones.Stop()
 	s.wg.Wait()
 }
 
@@ -214,6 +246,27 @@ func (s *WebServer) SetContentFunc(path string, f WebContentFunc) {
 	}
 }
 
+// SetWebSocketFunc is like SetContentFunc, but for a WebSocket endpoint,
+// which is also served over HTTP when HTTPS is enabled.
+func (s *WebServer) SetWebSocketFunc(path string, f WebContentFunc) {
+	path = strings.TrimPrefix(path, "/")
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.content[path] = WebContent{
+		Func:        f,
+		IsWebSocket: true,
+	}
+}
+
+func (s *WebServer) isWebSocket(path string) bool {
+	path = strings.TrimPrefix(path, "/")
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.content[path].IsWebSocket
+}
+
 func (s *WebServer) SetContent(path string, content []byte, contentType string) {
 	s.SetContentWithTime(path, content, contentType, time.Now())
 }
@@ -246,6 +299,31 @@ func (s *WebServer) ContentModifyTime(path string) (time.Time, bool) {
 	return time.Now(), false
 }
 
+// serveHTTP routes requests to the gRPC server or to the web content by
+// their content types.
+func (s *WebServer) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	if s.handleCORS(w, req) {
+		return
+	}
+
+	isGRPC, isGRPCWeb := isGRPCRequest(req), isGRPCWebRequest(req)
+	if isGRPC || isGRPCWeb {
+		// gRPC streams are long-lived, so the web server's timeouts
+		// must not apply to them.
+		rc := http.NewResponseController(w)
+		_ = rc.SetReadDeadline(time.Time{})
+		_ = rc.SetWriteDeadline(time.Time{})
+	}
+	switch {
+	case isGRPC:
+		s.grpcServer.ServeHTTP(w, req)
+	case isGRPCWeb:
+		s.serveGRPCWeb(w, req)
+	default:
+		s.requestHandler(w, req)
+	}
+}
+
 func (s *WebServer) requestHandler(w http.ResponseWriter, req *http.Request) {
 	h := w.Header()
 	path := strings.TrimPrefix(req.URL.Path, "/")
@@ -0,0 +1,53 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// setUpdateTimes records that the sections set in u changed at t. The caller
+// must hold s.lock.
+func (s *manifestServiceServer) setUpdateTimes(u *ManifestUpdate, t time.Time) {
+	for _, section := range u.sections() {
+		s.updateTimes[section] = t
+	}
+}
+
+// GetManifest returns the complete current update that is maintained for
+// StreamUpdates clients, restricted to the requested sections and aircraft.
+func (s *manifestServiceServer) GetManifest(
+	ctx context.Context,
+	req *GetManifestRequest,
+) (*GetManifestResponse, error) {
+	settings := s.app.Settings()
+	f := newUpdateFilter(&UpdateSubscription{
+		Sections: req.Sections,
+		Aircraft: req.Aircraft,
+	}, settings)
+
+	var latest time.Time
+	response := &GetManifestResponse{}
+	s.lock.Lock()
+	response.Update = proto.Clone(s.lastUpdate).(*ManifestUpdate)
+	for _, section := range response.Update.sections() {
+		t := s.updateTimes[section]
+		if t.After(latest) {
+			latest = t
+		}
+		if f.wants(section) {
+			response.SectionTimes = append(response.SectionTimes, &SectionTime{
+				Section: section,
+				Time:    t.Unix(),
+			})
+		}
+	}
+	s.lock.Unlock()
+	response.Time = latest.Unix()
+
+	f.restrict(response.Update, settings)
+	return response, nil
+}
@@ -0,0 +1,63 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import "github.com/jumptown-skydiving/manifest-server/pkg/core"
+
+// replayEntry records which data sources changed in a numbered change.
+type replayEntry struct {
+	sequence uint64
+	sources  core.DataSource
+}
+
+// replayBuffer remembers the most recent changes so that a client that
+// reconnects can be sent only what it missed. Rather than the updates
+// themselves, the data sources that changed are remembered, because the
+// updates sent to each SubscribeUpdates client differ.
+type replayBuffer struct {
+	entries []replayEntry
+}
+
+// push records a change, discarding the oldest changes so that no more than
+// size are remembered.
+func (b *replayBuffer) push(sequence uint64, sources core.DataSource, size int) {
+	b.entries = append(b.entries, replayEntry{
+		sequence: sequence,
+		sources:  sources,
+	})
+	if n := len(b.entries) - size; n > 0 {
+		b.entries = append(b.entries[:0], b.entries[n:]...)
+	}
+}
+
+// since returns the data sources that have changed after sequence, which
+// must be no later than current, the sequence of the latest change. It
+// returns false if changes after sequence are no longer remembered.
+func (b *replayBuffer) since(sequence, current uint64) (core.DataSource, bool) {
+	if sequence > current {
+		return 0, false
+	}
+	if sequence == current {
+		return 0, true
+	}
+	if len(b.entries) == 0 || b.entries[0].sequence > sequence+1 {
+		return 0, false
+	}
+	var sources core.DataSource
+	for _, e := range b.entries {
+		if e.sequence > sequence {
+			sources |= e.sources
+		}
+	}
+	return sources, true
+}
+
+// sources returns the data sources from which the sections that are set in
+// the update are constructed.
+func (x *ManifestUpdate) sources() core.DataSource {
+	var sources core.DataSource
+	for _, section := range x.sections() {
+		sources |= sectionSources[section]
+	}
+	return sources
+}
@@ -0,0 +1,117 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import (
+	"testing"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/core"
+)
+
+func TestReplayBuffer(t *testing.T) {
+	const size = 3
+
+	// Changes 1 through 5 are pushed, so only 3 through 5 are remembered.
+	var b replayBuffer
+	changes := []core.DataSource{
+		core.BurbleDataSource,
+		core.METARDataSource,
+		core.WindsAloftDataSource,
+		core.BurbleDataSource,
+		core.OptionsDataSource,
+	}
+	for i, sources := range changes {
+		b.push(uint64(i+1), sources, size)
+	}
+	if len(b.entries) != size {
+		t.Fatalf("%d entries remembered, want %d", len(b.entries), size)
+	}
+
+	tests := []struct {
+		name     string
+		sequence uint64
+		current  uint64
+		want     core.DataSource
+		wantOK   bool
+	}{
+		{"up to date", 5, 5, 0, true},
+		{"missed one", 4, 5, core.OptionsDataSource, true},
+		{"missed several", 3, 5, core.BurbleDataSource | core.OptionsDataSource, true},
+		{"missed all remembered", 2, 5,
+			core.WindsAloftDataSource | core.BurbleDataSource | core.OptionsDataSource, true},
+		{"missed one forgotten", 1, 5, 0, false},
+		{"missed everything", 0, 5, 0, false},
+		{"from the future", 6, 5, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := b.since(tt.sequence, tt.current)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("since(%d, %d) = %#x, %v, want %#x, %v",
+					tt.sequence, tt.current, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestReplayBufferEmpty(t *testing.T) {
+	var b replayBuffer
+	if _, ok := b.since(0, 0); !ok {
+		t.Error("since(0, 0) is not ok before any changes")
+	}
+	if _, ok := b.since(0, 1); ok {
+		t.Error("since(0, 1) is ok with nothing remembered")
+	}
+}
+
+func TestReplayBufferRollover(t *testing.T) {
+	const size = 4
+	var b replayBuffer
+	for sequence := uint64(1); sequence <= 100; sequence++ {
+		b.push(sequence, core.BurbleDataSource, size)
+		if len(b.entries) > size {
+			t.Fatalf("%d entries remembered after change %d, want at most %d",
+				len(b.entries), sequence, size)
+		}
+		oldest := uint64(1)
+		if sequence > size {
+			oldest = sequence - size + 1
+		}
+		if b.entries[0].sequence != oldest {
+			t.Fatalf("oldest change %d after change %d, want %d",
+				b.entries[0].sequence, sequence, oldest)
+		}
+		if _, ok := b.since(oldest-1, sequence); !ok {
+			t.Errorf("since(%d, %d) is not ok", oldest-1, sequence)
+		}
+		if oldest > 1 {
+			if _, ok := b.since(oldest-2, sequence); ok {
+				t.Errorf("since(%d, %d) is ok after change %d was forgotten",
+					oldest-2, sequence, oldest-1)
+			}
+		}
+	}
+	if cap(b.entries) > 2*size {
+		t.Errorf("capacity grew to %d", cap(b.entries))
+	}
+}
+
+func TestManifestUpdateSources(t *testing.T) {
+	tests := []struct {
+		name   string
+		update *ManifestUpdate
+		want   core.DataSource
+	}{
+		{"empty", &ManifestUpdate{}, 0},
+		{"loads", &ManifestUpdate{Loads: &Loads{}}, loadsSources},
+		{"jumprun and fuel", &ManifestUpdate{Jumprun: &Jumprun{}, FuelRequests: &FuelRequests{}},
+			jumprunSources | fuelSources},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.update.sources(); got != tt.want {
+				t.Errorf("sources() = %#x, want %#x", got, tt.want)
+			}
+		})
+	}
+}
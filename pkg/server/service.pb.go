@@ -24,37 +24,46 @@ const (
 type JumperType int32
 
 const (
-	JumperType_EXPERIENCED       JumperType = 0
-	JumperType_AFF_STUDENT       JumperType = 1
-	JumperType_COACH_STUDENT     JumperType = 2
-	JumperType_TANDEM_STUDENT    JumperType = 3
-	JumperType_AFF_INSTRUCTOR    JumperType = 4
-	JumperType_COACH             JumperType = 5
-	JumperType_TANDEM_INSTRUCTOR JumperType = 6
-	JumperType_VIDEOGRAPHER      JumperType = 7
+	JumperType_EXPERIENCED         JumperType = 0
+	JumperType_AFF_STUDENT         JumperType = 1
+	JumperType_COACH_STUDENT       JumperType = 2
+	JumperType_TANDEM_STUDENT      JumperType = 3
+	JumperType_AFF_INSTRUCTOR      JumperType = 4
+	JumperType_COACH               JumperType = 5
+	JumperType_TANDEM_INSTRUCTOR   JumperType = 6
+	JumperType_VIDEOGRAPHER        JumperType = 7
+	JumperType_IAD_STUDENT         JumperType = 8
+	JumperType_IAD_INSTRUCTOR      JumperType = 9
+	JumperType_HOP_AND_POP_STUDENT JumperType = 10
 )
 
 // Enum value maps for JumperType.
 var (
 	JumperType_name = map[int32]string{
-		0: "EXPERIENCED",
-		1: "AFF_STUDENT",
-		2: "COACH_STUDENT",
-		3: "TANDEM_STUDENT",
-		4: "AFF_INSTRUCTOR",
-		5: "COACH",
-		6: "TANDEM_INSTRUCTOR",
-		7: "VIDEOGRAPHER",
+		0:  "EXPERIENCED",
+		1:  "AFF_STUDENT",
+		2:  "COACH_STUDENT",
+		3:  "TANDEM_STUDENT",
+		4:  "AFF_INSTRUCTOR",
+		5:  "COACH",
+		6:  "TANDEM_INSTRUCTOR",
+		7:  "VIDEOGRAPHER",
+		8:  "IAD_STUDENT",
+		9:  "IAD_INSTRUCTOR",
+		10: "HOP_AND_POP_STUDENT",
 	}
 	JumperType_value = map[string]int32{
-		"EXPERIENCED":       0,
-		"AFF_STUDENT":       1,
-		"COACH_STUDENT":     2,
-		"TANDEM_STUDENT":    3,
-		"AFF_INSTRUCTOR":    4,
-		"COACH":             5,
-		"TANDEM_INSTRUCTOR": 6,
-		"VIDEOGRAPHER":      7,
+		"EXPERIENCED":         0,
+		"AFF_STUDENT":         1,
+		"COACH_STUDENT":       2,
+		"TANDEM_STUDENT":      3,
+		"AFF_INSTRUCTOR":      4,
+		"COACH":               5,
+		"TANDEM_INSTRUCTOR":   6,
+		"VIDEOGRAPHER":        7,
+		"IAD_STUDENT":         8,
+		"IAD_INSTRUCTOR":      9,
+		"HOP_AND_POP_STUDENT": 10,
 	}
 )
 
@@ -85,6 +94,241 @@ func (JumperType) EnumDescriptor() ([]byte, []int) {
 	return file_pkg_server_service_proto_rawDescGZIP(), []int{0}
 }
 
+type StudentProgram int32
+
+const (
+	StudentProgram_PROGRAM_UNKNOWN     StudentProgram = 0
+	StudentProgram_PROGRAM_AFF         StudentProgram = 1
+	StudentProgram_PROGRAM_COACH       StudentProgram = 2
+	StudentProgram_PROGRAM_IAD         StudentProgram = 3 // instructor assisted deployment or static line
+	StudentProgram_PROGRAM_HOP_AND_POP StudentProgram = 4
+)
+
+// Enum value maps for StudentProgram.
+var (
+	StudentProgram_name = map[int32]string{
+		0: "PROGRAM_UNKNOWN",
+		1: "PROGRAM_AFF",
+		2: "PROGRAM_COACH",
+		3: "PROGRAM_IAD",
+		4: "PROGRAM_HOP_AND_POP",
+	}
+	StudentProgram_value = map[string]int32{
+		"PROGRAM_UNKNOWN":     0,
+		"PROGRAM_AFF":         1,
+		"PROGRAM_COACH":       2,
+		"PROGRAM_IAD":         3,
+		"PROGRAM_HOP_AND_POP": 4,
+	}
+)
+
+func (x StudentProgram) Enum() *StudentProgram {
+	p := new(StudentProgram)
+	*p = x
+	return p
+}
+
+func (x StudentProgram) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StudentProgram) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_server_service_proto_enumTypes[1].Descriptor()
+}
+
+func (StudentProgram) Type() protoreflect.EnumType {
+	return &file_pkg_server_service_proto_enumTypes[1]
+}
+
+func (x StudentProgram) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StudentProgram.Descriptor instead.
+func (StudentProgram) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{1}
+}
+
+type ManifestEventType int32
+
+const (
+	ManifestEventType_JUMPER_ADDED   ManifestEventType = 0
+	ManifestEventType_JUMPER_REMOVED ManifestEventType = 1
+	ManifestEventType_JUMPER_MOVED   ManifestEventType = 2
+	ManifestEventType_LOAD_CREATED   ManifestEventType = 3
+	ManifestEventType_LOAD_DEPARTED  ManifestEventType = 4 // left the manifest after its call
+	ManifestEventType_LOAD_REMOVED   ManifestEventType = 5 // left the manifest before its call
+	ManifestEventType_CALL_TIME      ManifestEventType = 6 // call_minutes is the threshold crossed
+	ManifestEventType_SLOTS_OPENED   ManifestEventType = 7
+)
+
+// Enum value maps for ManifestEventType.
+var (
+	ManifestEventType_name = map[int32]string{
+		0: "JUMPER_ADDED",
+		1: "JUMPER_REMOVED",
+		2: "JUMPER_MOVED",
+		3: "LOAD_CREATED",
+		4: "LOAD_DEPARTED",
+		5: "LOAD_REMOVED",
+		6: "CALL_TIME",
+		7: "SLOTS_OPENED",
+	}
+	ManifestEventType_value = map[string]int32{
+		"JUMPER_ADDED":   0,
+		"JUMPER_REMOVED": 1,
+		"JUMPER_MOVED":   2,
+		"LOAD_CREATED":   3,
+		"LOAD_DEPARTED":  4,
+		"LOAD_REMOVED":   5,
+		"CALL_TIME":      6,
+		"SLOTS_OPENED":   7,
+	}
+)
+
+func (x ManifestEventType) Enum() *ManifestEventType {
+	p := new(ManifestEventType)
+	*p = x
+	return p
+}
+
+func (x ManifestEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ManifestEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_server_service_proto_enumTypes[2].Descriptor()
+}
+
+func (ManifestEventType) Type() protoreflect.EnumType {
+	return &file_pkg_server_service_proto_enumTypes[2]
+}
+
+func (x ManifestEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ManifestEventType.Descriptor instead.
+func (ManifestEventType) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{2}
+}
+
+type UpdateSection int32
+
+const (
+	UpdateSection_SECTION_UNSPECIFIED   UpdateSection = 0
+	UpdateSection_SECTION_STATUS        UpdateSection = 1
+	UpdateSection_SECTION_OPTIONS       UpdateSection = 2
+	UpdateSection_SECTION_JUMPRUN       UpdateSection = 3
+	UpdateSection_SECTION_WINDS_ALOFT   UpdateSection = 4
+	UpdateSection_SECTION_LOADS         UpdateSection = 5
+	UpdateSection_SECTION_FUEL_REQUESTS UpdateSection = 6
+	UpdateSection_SECTION_SOURCE_HEALTH UpdateSection = 7
+)
+
+// Enum value maps for UpdateSection.
+var (
+	UpdateSection_name = map[int32]string{
+		0: "SECTION_UNSPECIFIED",
+		1: "SECTION_STATUS",
+		2: "SECTION_OPTIONS",
+		3: "SECTION_JUMPRUN",
+		4: "SECTION_WINDS_ALOFT",
+		5: "SECTION_LOADS",
+		6: "SECTION_FUEL_REQUESTS",
+		7: "SECTION_SOURCE_HEALTH",
+	}
+	UpdateSection_value = map[string]int32{
+		"SECTION_UNSPECIFIED":   0,
+		"SECTION_STATUS":        1,
+		"SECTION_OPTIONS":       2,
+		"SECTION_JUMPRUN":       3,
+		"SECTION_WINDS_ALOFT":   4,
+		"SECTION_LOADS":         5,
+		"SECTION_FUEL_REQUESTS": 6,
+		"SECTION_SOURCE_HEALTH": 7,
+	}
+)
+
+func (x UpdateSection) Enum() *UpdateSection {
+	p := new(UpdateSection)
+	*p = x
+	return p
+}
+
+func (x UpdateSection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UpdateSection) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_server_service_proto_enumTypes[3].Descriptor()
+}
+
+func (UpdateSection) Type() protoreflect.EnumType {
+	return &file_pkg_server_service_proto_enumTypes[3]
+}
+
+func (x UpdateSection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UpdateSection.Descriptor instead.
+func (UpdateSection) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{3}
+}
+
+type Units int32
+
+const (
+	Units_UNITS_DEFAULT  Units = 0 // MPH, with temperatures in both Celsius and Fahrenheit
+	Units_UNITS_IMPERIAL Units = 1 // MPH and Fahrenheit
+	Units_UNITS_METRIC   Units = 2 // km/h and Celsius
+	Units_UNITS_NAUTICAL Units = 3 // knots and Celsius
+)
+
+// Enum value maps for Units.
+var (
+	Units_name = map[int32]string{
+		0: "UNITS_DEFAULT",
+		1: "UNITS_IMPERIAL",
+		2: "UNITS_METRIC",
+		3: "UNITS_NAUTICAL",
+	}
+	Units_value = map[string]int32{
+		"UNITS_DEFAULT":  0,
+		"UNITS_IMPERIAL": 1,
+		"UNITS_METRIC":   2,
+		"UNITS_NAUTICAL": 3,
+	}
+)
+
+func (x Units) Enum() *Units {
+	p := new(Units)
+	*p = x
+	return p
+}
+
+func (x Units) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Units) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_server_service_proto_enumTypes[4].Descriptor()
+}
+
+func (Units) Type() protoreflect.EnumType {
+	return &file_pkg_server_service_proto_enumTypes[4]
+}
+
+func (x Units) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Units.Descriptor instead.
+func (Units) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{4}
+}
+
 type Status struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -216,7 +460,7 @@ type Options struct {
 	MessageColor     uint32 `protobuf:"varint,6,opt,name=messageColor,proto3" json:"messageColor,omitempty"`
 	Sunrise          string `protobuf:"bytes,7,opt,name=sunrise,proto3" json:"sunrise,omitempty"`
 	Sunset           string `protobuf:"bytes,8,opt,name=sunset,proto3" json:"sunset,omitempty"`
-	FuelRequested    bool   `protobuf:"varint,9,opt,name=fuelRequested,proto3" json:"fuelRequested,omitempty"`
+	FuelRequested    bool   `protobuf:"varint,9,opt,name=fuelRequested,proto3" json:"fuelRequested,omitempty"` // true if any fuel request is active
 }
 
 func (x *Options) Reset() {
@@ -698,14 +942,16 @@ type Jumper struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id        uint64     `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Type      JumperType `protobuf:"varint,2,opt,name=type,proto3,enum=manifest.JumperType" json:"type,omitempty"`
-	Name      string     `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Nickname  string     `protobuf:"bytes,4,opt,name=nickname,proto3" json:"nickname,omitempty"`
-	ShortName string     `protobuf:"bytes,5,opt,name=short_name,json=shortName,proto3" json:"short_name,omitempty"`
-	Color     uint32     `protobuf:"varint,6,opt,name=color,proto3" json:"color,omitempty"`
-	Repr      string     `protobuf:"bytes,7,opt,name=repr,proto3" json:"repr,omitempty"`
-	RigName   string     `protobuf:"bytes,8,opt,name=rig_name,json=rigName,proto3" json:"rig_name,omitempty"`
+	Id             uint64         `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type           JumperType     `protobuf:"varint,2,opt,name=type,proto3,enum=manifest.JumperType" json:"type,omitempty"`
+	Name           string         `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Nickname       string         `protobuf:"bytes,4,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	ShortName      string         `protobuf:"bytes,5,opt,name=short_name,json=shortName,proto3" json:"short_name,omitempty"`
+	Color          uint32         `protobuf:"varint,6,opt,name=color,proto3" json:"color,omitempty"`
+	Repr           string         `protobuf:"bytes,7,opt,name=repr,proto3" json:"repr,omitempty"`
+	RigName        string         `protobuf:"bytes,8,opt,name=rig_name,json=rigName,proto3" json:"rig_name,omitempty"`
+	StudentProgram StudentProgram `protobuf:"varint,9,opt,name=student_program,json=studentProgram,proto3,enum=manifest.StudentProgram" json:"student_program,omitempty"`
+	AffLevel       int32          `protobuf:"varint,10,opt,name=aff_level,json=affLevel,proto3" json:"aff_level,omitempty"` // 0 if unknown or not an AFF student
 }
 
 func (x *Jumper) Reset() {
@@ -796,6 +1042,20 @@ func (x *Jumper) GetRigName() string {
 	return ""
 }
 
+func (x *Jumper) GetStudentProgram() StudentProgram {
+	if x != nil {
+		return x.StudentProgram
+	}
+	return StudentProgram_PROGRAM_UNKNOWN
+}
+
+func (x *Jumper) GetAffLevel() int32 {
+	if x != nil {
+		return x.AffLevel
+	}
+	return 0
+}
+
 type JumperGroup struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1058,17 +1318,25 @@ func (x *Load) GetSlots() []*LoadSlot {
 	return nil
 }
 
-type Loads struct {
+type Aircraft struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ColumnCount int32   `protobuf:"varint,1,opt,name=column_count,json=columnCount,proto3" json:"column_count,omitempty"`
-	Loads       []*Load `protobuf:"bytes,2,rep,name=loads,proto3" json:"loads,omitempty"`
+	Name            string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Aliases         []string `protobuf:"bytes,2,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	TailNumber      string   `protobuf:"bytes,3,opt,name=tail_number,json=tailNumber,proto3" json:"tail_number,omitempty"`
+	IcaoHex         string   `protobuf:"bytes,4,opt,name=icao_hex,json=icaoHex,proto3" json:"icao_hex,omitempty"`
+	Capacity        int32    `protobuf:"varint,5,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	JumprunAirspeed int32    `protobuf:"varint,6,opt,name=jumprun_airspeed,json=jumprunAirspeed,proto3" json:"jumprun_airspeed,omitempty"` // knots
+	ClimbRate       int32    `protobuf:"varint,7,opt,name=climb_rate,json=climbRate,proto3" json:"climb_rate,omitempty"`                   // feet per minute
+	ExitAltitude    int32    `protobuf:"varint,8,opt,name=exit_altitude,json=exitAltitude,proto3" json:"exit_altitude,omitempty"`          // feet
+	Color           uint32   `protobuf:"varint,9,opt,name=color,proto3" json:"color,omitempty"`
+	Order           int32    `protobuf:"varint,10,opt,name=order,proto3" json:"order,omitempty"` // display order; lower values display first
 }
 
-func (x *Loads) Reset() {
-	*x = Loads{}
+func (x *Aircraft) Reset() {
+	*x = Aircraft{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_server_service_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1076,13 +1344,13 @@ func (x *Loads) Reset() {
 	}
 }
 
-func (x *Loads) String() string {
+func (x *Aircraft) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Loads) ProtoMessage() {}
+func (*Aircraft) ProtoMessage() {}
 
-func (x *Loads) ProtoReflect() protoreflect.Message {
+func (x *Aircraft) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_server_service_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1094,39 +1362,93 @@ func (x *Loads) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Loads.ProtoReflect.Descriptor instead.
-func (*Loads) Descriptor() ([]byte, []int) {
+// Deprecated: Use Aircraft.ProtoReflect.Descriptor instead.
+func (*Aircraft) Descriptor() ([]byte, []int) {
 	return file_pkg_server_service_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *Loads) GetColumnCount() int32 {
+func (x *Aircraft) GetName() string {
 	if x != nil {
-		return x.ColumnCount
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *Loads) GetLoads() []*Load {
+func (x *Aircraft) GetAliases() []string {
 	if x != nil {
-		return x.Loads
+		return x.Aliases
 	}
 	return nil
 }
 
-type ManifestUpdate struct {
+func (x *Aircraft) GetTailNumber() string {
+	if x != nil {
+		return x.TailNumber
+	}
+	return ""
+}
+
+func (x *Aircraft) GetIcaoHex() string {
+	if x != nil {
+		return x.IcaoHex
+	}
+	return ""
+}
+
+func (x *Aircraft) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *Aircraft) GetJumprunAirspeed() int32 {
+	if x != nil {
+		return x.JumprunAirspeed
+	}
+	return 0
+}
+
+func (x *Aircraft) GetClimbRate() int32 {
+	if x != nil {
+		return x.ClimbRate
+	}
+	return 0
+}
+
+func (x *Aircraft) GetExitAltitude() int32 {
+	if x != nil {
+		return x.ExitAltitude
+	}
+	return 0
+}
+
+func (x *Aircraft) GetColor() uint32 {
+	if x != nil {
+		return x.Color
+	}
+	return 0
+}
+
+func (x *Aircraft) GetOrder() int32 {
+	if x != nil {
+		return x.Order
+	}
+	return 0
+}
+
+type Loads struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status     *Status     `protobuf:"bytes,1,opt,name=status,proto3,oneof" json:"status,omitempty"`
-	Options    *Options    `protobuf:"bytes,2,opt,name=options,proto3,oneof" json:"options,omitempty"`
-	Jumprun    *Jumprun    `protobuf:"bytes,3,opt,name=jumprun,proto3,oneof" json:"jumprun,omitempty"`
-	WindsAloft *WindsAloft `protobuf:"bytes,4,opt,name=winds_aloft,json=windsAloft,proto3,oneof" json:"winds_aloft,omitempty"`
-	Loads      *Loads      `protobuf:"bytes,5,opt,name=loads,proto3,oneof" json:"loads,omitempty"`
+	ColumnCount int32       `protobuf:"varint,1,opt,name=column_count,json=columnCount,proto3" json:"column_count,omitempty"`
+	Loads       []*Load     `protobuf:"bytes,2,rep,name=loads,proto3" json:"loads,omitempty"`
+	Aircraft    []*Aircraft `protobuf:"bytes,3,rep,name=aircraft,proto3" json:"aircraft,omitempty"` // registered aircraft in display order
 }
 
-func (x *ManifestUpdate) Reset() {
-	*x = ManifestUpdate{}
+func (x *Loads) Reset() {
+	*x = Loads{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_server_service_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1134,13 +1456,13 @@ func (x *ManifestUpdate) Reset() {
 	}
 }
 
-func (x *ManifestUpdate) String() string {
+func (x *Loads) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ManifestUpdate) ProtoMessage() {}
+func (*Loads) ProtoMessage() {}
 
-func (x *ManifestUpdate) ProtoReflect() protoreflect.Message {
+func (x *Loads) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_server_service_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1152,61 +1474,50 @@ func (x *ManifestUpdate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ManifestUpdate.ProtoReflect.Descriptor instead.
-func (*ManifestUpdate) Descriptor() ([]byte, []int) {
+// Deprecated: Use Loads.ProtoReflect.Descriptor instead.
+func (*Loads) Descriptor() ([]byte, []int) {
 	return file_pkg_server_service_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ManifestUpdate) GetStatus() *Status {
-	if x != nil {
-		return x.Status
-	}
-	return nil
-}
-
-func (x *ManifestUpdate) GetOptions() *Options {
-	if x != nil {
-		return x.Options
-	}
-	return nil
-}
-
-func (x *ManifestUpdate) GetJumprun() *Jumprun {
+func (x *Loads) GetColumnCount() int32 {
 	if x != nil {
-		return x.Jumprun
+		return x.ColumnCount
 	}
-	return nil
+	return 0
 }
 
-func (x *ManifestUpdate) GetWindsAloft() *WindsAloft {
+func (x *Loads) GetLoads() []*Load {
 	if x != nil {
-		return x.WindsAloft
+		return x.Loads
 	}
 	return nil
 }
 
-func (x *ManifestUpdate) GetLoads() *Loads {
+func (x *Loads) GetAircraft() []*Aircraft {
 	if x != nil {
-		return x.Loads
+		return x.Aircraft
 	}
 	return nil
 }
 
-type SignInWithAppleRequest struct {
+type FuelRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BundleId          string `protobuf:"bytes,1,opt,name=bundle_id,json=bundleId,proto3" json:"bundle_id,omitempty"`
-	Nonce             string `protobuf:"bytes,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
-	IdentityToken     string `protobuf:"bytes,3,opt,name=identity_token,json=identityToken,proto3" json:"identity_token,omitempty"`
-	AuthorizationCode string `protobuf:"bytes,4,opt,name=authorization_code,json=authorizationCode,proto3" json:"authorization_code,omitempty"`
-	GivenName         string `protobuf:"bytes,5,opt,name=given_name,json=givenName,proto3" json:"given_name,omitempty"`
-	FamilyName        string `protobuf:"bytes,6,opt,name=family_name,json=familyName,proto3" json:"family_name,omitempty"`
+	Id              uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	AircraftName    string `protobuf:"bytes,2,opt,name=aircraft_name,json=aircraftName,proto3" json:"aircraft_name,omitempty"`
+	Note            string `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	RequestedBy     string `protobuf:"bytes,4,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"`
+	RequestTime     int64  `protobuf:"varint,5,opt,name=request_time,json=requestTime,proto3" json:"request_time,omitempty"` // Unix time
+	AcknowledgedBy  string `protobuf:"bytes,6,opt,name=acknowledged_by,json=acknowledgedBy,proto3" json:"acknowledged_by,omitempty"`
+	AcknowledgeTime int64  `protobuf:"varint,7,opt,name=acknowledge_time,json=acknowledgeTime,proto3" json:"acknowledge_time,omitempty"` // Unix time; 0 if not acknowledged
+	CompletedBy     string `protobuf:"bytes,8,opt,name=completed_by,json=completedBy,proto3" json:"completed_by,omitempty"`
+	CompleteTime    int64  `protobuf:"varint,9,opt,name=complete_time,json=completeTime,proto3" json:"complete_time,omitempty"` // Unix time; 0 if not completed
 }
 
-func (x *SignInWithAppleRequest) Reset() {
-	*x = SignInWithAppleRequest{}
+func (x *FuelRequest) Reset() {
+	*x = FuelRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_server_service_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1214,13 +1525,13 @@ func (x *SignInWithAppleRequest) Reset() {
 	}
 }
 
-func (x *SignInWithAppleRequest) String() string {
+func (x *FuelRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignInWithAppleRequest) ProtoMessage() {}
+func (*FuelRequest) ProtoMessage() {}
 
-func (x *SignInWithAppleRequest) ProtoReflect() protoreflect.Message {
+func (x *FuelRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_server_service_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1232,68 +1543,84 @@ func (x *SignInWithAppleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignInWithAppleRequest.ProtoReflect.Descriptor instead.
-func (*SignInWithAppleRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use FuelRequest.ProtoReflect.Descriptor instead.
+func (*FuelRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_server_service_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *SignInWithAppleRequest) GetBundleId() string {
+func (x *FuelRequest) GetId() uint64 {
 	if x != nil {
-		return x.BundleId
+		return x.Id
 	}
-	return ""
+	return 0
 }
 
-func (x *SignInWithAppleRequest) GetNonce() string {
+func (x *FuelRequest) GetAircraftName() string {
 	if x != nil {
-		return x.Nonce
+		return x.AircraftName
 	}
 	return ""
 }
 
-func (x *SignInWithAppleRequest) GetIdentityToken() string {
+func (x *FuelRequest) GetNote() string {
 	if x != nil {
-		return x.IdentityToken
+		return x.Note
 	}
 	return ""
 }
 
-func (x *SignInWithAppleRequest) GetAuthorizationCode() string {
+func (x *FuelRequest) GetRequestedBy() string {
 	if x != nil {
-		return x.AuthorizationCode
+		return x.RequestedBy
 	}
 	return ""
 }
 
-func (x *SignInWithAppleRequest) GetGivenName() string {
+func (x *FuelRequest) GetRequestTime() int64 {
 	if x != nil {
-		return x.GivenName
+		return x.RequestTime
+	}
+	return 0
+}
+
+func (x *FuelRequest) GetAcknowledgedBy() string {
+	if x != nil {
+		return x.AcknowledgedBy
 	}
 	return ""
 }
 
-func (x *SignInWithAppleRequest) GetFamilyName() string {
+func (x *FuelRequest) GetAcknowledgeTime() int64 {
 	if x != nil {
-		return x.FamilyName
+		return x.AcknowledgeTime
+	}
+	return 0
+}
+
+func (x *FuelRequest) GetCompletedBy() string {
+	if x != nil {
+		return x.CompletedBy
 	}
 	return ""
 }
 
-type SignInResponse struct {
+func (x *FuelRequest) GetCompleteTime() int64 {
+	if x != nil {
+		return x.CompleteTime
+	}
+	return 0
+}
+
+type FuelRequests struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SessionId         string   `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	SessionExpiration int64    `protobuf:"varint,2,opt,name=session_expiration,json=sessionExpiration,proto3" json:"session_expiration,omitempty"`
-	IsValid           bool     `protobuf:"varint,3,opt,name=is_valid,json=isValid,proto3" json:"is_valid,omitempty"`
-	Roles             []string `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles,omitempty"`
-	ErrorMessage      string   `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
-	SessionDeleted    bool     `protobuf:"varint,6,opt,name=session_deleted,json=sessionDeleted,proto3" json:"session_deleted,omitempty"`
+	Requests []*FuelRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"` // active requests, oldest first
 }
 
-func (x *SignInResponse) Reset() {
-	*x = SignInResponse{}
+func (x *FuelRequests) Reset() {
+	*x = FuelRequests{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_server_service_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1301,13 +1628,13 @@ func (x *SignInResponse) Reset() {
 	}
 }
 
-func (x *SignInResponse) String() string {
+func (x *FuelRequests) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignInResponse) ProtoMessage() {}
+func (*FuelRequests) ProtoMessage() {}
 
-func (x *SignInResponse) ProtoReflect() protoreflect.Message {
+func (x *FuelRequests) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_server_service_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1319,78 +1646,1890 @@ func (x *SignInResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignInResponse.ProtoReflect.Descriptor instead.
-func (*SignInResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use FuelRequests.ProtoReflect.Descriptor instead.
+func (*FuelRequests) Descriptor() ([]byte, []int) {
 	return file_pkg_server_service_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *SignInResponse) GetSessionId() string {
+func (x *FuelRequests) GetRequests() []*FuelRequest {
 	if x != nil {
-		return x.SessionId
+		return x.Requests
+	}
+	return nil
+}
+
+type DataSourceHealth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                  string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // "manifest", "metar", or "winds"
+	Name                string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	LastAttempt         int64    `protobuf:"varint,3,opt,name=last_attempt,json=lastAttempt,proto3" json:"last_attempt,omitempty"` // Unix time; 0 if never attempted
+	LastSuccess         int64    `protobuf:"varint,4,opt,name=last_success,json=lastSuccess,proto3" json:"last_success,omitempty"` // Unix time; 0 if never successful
+	LastError           string   `protobuf:"bytes,5,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`        // empty if the last attempt succeeded
+	ConsecutiveFailures int32    `protobuf:"varint,6,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	NextRefresh         int64    `protobuf:"varint,7,opt,name=next_refresh,json=nextRefresh,proto3" json:"next_refresh,omitempty"` // Unix time
+	DataAge             int64    `protobuf:"varint,8,opt,name=data_age,json=dataAge,proto3" json:"data_age,omitempty"`             // seconds since last_success
+	StaleAfter          int64    `protobuf:"varint,9,opt,name=stale_after,json=staleAfter,proto3" json:"stale_after,omitempty"`    // seconds after last_success that data is stale
+	IsStale             bool     `protobuf:"varint,10,opt,name=is_stale,json=isStale,proto3" json:"is_stale,omitempty"`
+	DecodeErrors        []string `protobuf:"bytes,11,rep,name=decode_errors,json=decodeErrors,proto3" json:"decode_errors,omitempty"` // fields of the latest data that could not be decoded
+}
+
+func (x *DataSourceHealth) Reset() {
+	*x = DataSourceHealth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataSourceHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataSourceHealth) ProtoMessage() {}
+
+func (x *DataSourceHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataSourceHealth.ProtoReflect.Descriptor instead.
+func (*DataSourceHealth) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DataSourceHealth) GetId() string {
+	if x != nil {
+		return x.Id
 	}
 	return ""
 }
 
-func (x *SignInResponse) GetSessionExpiration() int64 {
+func (x *DataSourceHealth) GetName() string {
 	if x != nil {
-		return x.SessionExpiration
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DataSourceHealth) GetLastAttempt() int64 {
+	if x != nil {
+		return x.LastAttempt
 	}
 	return 0
 }
 
-func (x *SignInResponse) GetIsValid() bool {
+func (x *DataSourceHealth) GetLastSuccess() int64 {
 	if x != nil {
-		return x.IsValid
+		return x.LastSuccess
+	}
+	return 0
+}
+
+func (x *DataSourceHealth) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *DataSourceHealth) GetConsecutiveFailures() int32 {
+	if x != nil {
+		return x.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (x *DataSourceHealth) GetNextRefresh() int64 {
+	if x != nil {
+		return x.NextRefresh
+	}
+	return 0
+}
+
+func (x *DataSourceHealth) GetDataAge() int64 {
+	if x != nil {
+		return x.DataAge
+	}
+	return 0
+}
+
+func (x *DataSourceHealth) GetStaleAfter() int64 {
+	if x != nil {
+		return x.StaleAfter
+	}
+	return 0
+}
+
+func (x *DataSourceHealth) GetIsStale() bool {
+	if x != nil {
+		return x.IsStale
 	}
 	return false
 }
 
-func (x *SignInResponse) GetRoles() []string {
+func (x *DataSourceHealth) GetDecodeErrors() []string {
 	if x != nil {
-		return x.Roles
+		return x.DecodeErrors
 	}
 	return nil
 }
 
-func (x *SignInResponse) GetErrorMessage() string {
+type SourceHealth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sources []*DataSourceHealth `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources,omitempty"`
+}
+
+func (x *SourceHealth) Reset() {
+	*x = SourceHealth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SourceHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SourceHealth) ProtoMessage() {}
+
+func (x *SourceHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SourceHealth.ProtoReflect.Descriptor instead.
+func (*SourceHealth) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SourceHealth) GetSources() []*DataSourceHealth {
 	if x != nil {
-		return x.ErrorMessage
+		return x.Sources
 	}
-	return ""
+	return nil
 }
 
-func (x *SignInResponse) GetSessionDeleted() bool {
+type ManifestUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status       *Status       `protobuf:"bytes,1,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	Options      *Options      `protobuf:"bytes,2,opt,name=options,proto3,oneof" json:"options,omitempty"`
+	Jumprun      *Jumprun      `protobuf:"bytes,3,opt,name=jumprun,proto3,oneof" json:"jumprun,omitempty"`
+	WindsAloft   *WindsAloft   `protobuf:"bytes,4,opt,name=winds_aloft,json=windsAloft,proto3,oneof" json:"winds_aloft,omitempty"`
+	Loads        *Loads        `protobuf:"bytes,5,opt,name=loads,proto3,oneof" json:"loads,omitempty"`
+	FuelRequests *FuelRequests `protobuf:"bytes,6,opt,name=fuel_requests,json=fuelRequests,proto3,oneof" json:"fuel_requests,omitempty"`
+	SourceHealth *SourceHealth `protobuf:"bytes,7,opt,name=source_health,json=sourceHealth,proto3,oneof" json:"source_health,omitempty"`
+	Sequence     uint64        `protobuf:"varint,8,opt,name=sequence,proto3" json:"sequence,omitempty"`                        // the latest change included; 0 for none
+	Epoch        uint64        `protobuf:"varint,9,opt,name=epoch,proto3" json:"epoch,omitempty"`                              // identifies the server instance that numbered the changes
+	IsSnapshot   bool          `protobuf:"varint,10,opt,name=is_snapshot,json=isSnapshot,proto3" json:"is_snapshot,omitempty"` // a complete update that replaces the client's state
+}
+
+func (x *ManifestUpdate) Reset() {
+	*x = ManifestUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ManifestUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManifestUpdate) ProtoMessage() {}
+
+func (x *ManifestUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManifestUpdate.ProtoReflect.Descriptor instead.
+func (*ManifestUpdate) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ManifestUpdate) GetStatus() *Status {
 	if x != nil {
-		return x.SessionDeleted
+		return x.Status
+	}
+	return nil
+}
+
+func (x *ManifestUpdate) GetOptions() *Options {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *ManifestUpdate) GetJumprun() *Jumprun {
+	if x != nil {
+		return x.Jumprun
+	}
+	return nil
+}
+
+func (x *ManifestUpdate) GetWindsAloft() *WindsAloft {
+	if x != nil {
+		return x.WindsAloft
+	}
+	return nil
+}
+
+func (x *ManifestUpdate) GetLoads() *Loads {
+	if x != nil {
+		return x.Loads
+	}
+	return nil
+}
+
+func (x *ManifestUpdate) GetFuelRequests() *FuelRequests {
+	if x != nil {
+		return x.FuelRequests
+	}
+	return nil
+}
+
+func (x *ManifestUpdate) GetSourceHealth() *SourceHealth {
+	if x != nil {
+		return x.SourceHealth
+	}
+	return nil
+}
+
+func (x *ManifestUpdate) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *ManifestUpdate) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *ManifestUpdate) GetIsSnapshot() bool {
+	if x != nil {
+		return x.IsSnapshot
 	}
 	return false
 }
 
-type SignOutRequest struct {
+type SignInWithAppleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BundleId          string `protobuf:"bytes,1,opt,name=bundle_id,json=bundleId,proto3" json:"bundle_id,omitempty"`
+	Nonce             string `protobuf:"bytes,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	IdentityToken     string `protobuf:"bytes,3,opt,name=identity_token,json=identityToken,proto3" json:"identity_token,omitempty"`
+	AuthorizationCode string `protobuf:"bytes,4,opt,name=authorization_code,json=authorizationCode,proto3" json:"authorization_code,omitempty"`
+	GivenName         string `protobuf:"bytes,5,opt,name=given_name,json=givenName,proto3" json:"given_name,omitempty"`
+	FamilyName        string `protobuf:"bytes,6,opt,name=family_name,json=familyName,proto3" json:"family_name,omitempty"`
+}
+
+func (x *SignInWithAppleRequest) Reset() {
+	*x = SignInWithAppleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignInWithAppleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignInWithAppleRequest) ProtoMessage() {}
+
+func (x *SignInWithAppleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignInWithAppleRequest.ProtoReflect.Descriptor instead.
+func (*SignInWithAppleRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SignInWithAppleRequest) GetBundleId() string {
+	if x != nil {
+		return x.BundleId
+	}
+	return ""
+}
+
+func (x *SignInWithAppleRequest) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *SignInWithAppleRequest) GetIdentityToken() string {
+	if x != nil {
+		return x.IdentityToken
+	}
+	return ""
+}
+
+func (x *SignInWithAppleRequest) GetAuthorizationCode() string {
+	if x != nil {
+		return x.AuthorizationCode
+	}
+	return ""
+}
+
+func (x *SignInWithAppleRequest) GetGivenName() string {
+	if x != nil {
+		return x.GivenName
+	}
+	return ""
+}
+
+func (x *SignInWithAppleRequest) GetFamilyName() string {
+	if x != nil {
+		return x.FamilyName
+	}
+	return ""
+}
+
+type SignInResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId         string   `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	SessionExpiration int64    `protobuf:"varint,2,opt,name=session_expiration,json=sessionExpiration,proto3" json:"session_expiration,omitempty"`
+	IsValid           bool     `protobuf:"varint,3,opt,name=is_valid,json=isValid,proto3" json:"is_valid,omitempty"`
+	Roles             []string `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles,omitempty"`
+	ErrorMessage      string   `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	SessionDeleted    bool     `protobuf:"varint,6,opt,name=session_deleted,json=sessionDeleted,proto3" json:"session_deleted,omitempty"`
+}
+
+func (x *SignInResponse) Reset() {
+	*x = SignInResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignInResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignInResponse) ProtoMessage() {}
+
+func (x *SignInResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignInResponse.ProtoReflect.Descriptor instead.
+func (*SignInResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SignInResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SignInResponse) GetSessionExpiration() int64 {
+	if x != nil {
+		return x.SessionExpiration
+	}
+	return 0
+}
+
+func (x *SignInResponse) GetIsValid() bool {
+	if x != nil {
+		return x.IsValid
+	}
+	return false
+}
+
+func (x *SignInResponse) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *SignInResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *SignInResponse) GetSessionDeleted() bool {
+	if x != nil {
+		return x.SessionDeleted
+	}
+	return false
+}
+
+type SignOutRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+}
+
+func (x *SignOutRequest) Reset() {
+	*x = SignOutRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignOutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignOutRequest) ProtoMessage() {}
+
+func (x *SignOutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignOutRequest.ProtoReflect.Descriptor instead.
+func (*SignOutRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SignOutRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type SignOutResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+}
+
+func (x *SignOutResponse) Reset() {
+	*x = SignOutResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignOutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignOutResponse) ProtoMessage() {}
+
+func (x *SignOutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignOutResponse.ProtoReflect.Descriptor instead.
+func (*SignOutResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SignOutResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type VerifySessionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+}
+
+func (x *VerifySessionRequest) Reset() {
+	*x = VerifySessionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifySessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifySessionRequest) ProtoMessage() {}
+
+func (x *VerifySessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifySessionRequest.ProtoReflect.Descriptor instead.
+func (*VerifySessionRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *VerifySessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type ToggleFuelRequestedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+}
+
+func (x *ToggleFuelRequestedRequest) Reset() {
+	*x = ToggleFuelRequestedRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ToggleFuelRequestedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToggleFuelRequestedRequest) ProtoMessage() {}
+
+func (x *ToggleFuelRequestedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToggleFuelRequestedRequest.ProtoReflect.Descriptor instead.
+func (*ToggleFuelRequestedRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ToggleFuelRequestedRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type ToggleFuelRequestedResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ErrorMessage string `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+}
+
+func (x *ToggleFuelRequestedResponse) Reset() {
+	*x = ToggleFuelRequestedResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ToggleFuelRequestedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToggleFuelRequestedResponse) ProtoMessage() {}
+
+func (x *ToggleFuelRequestedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToggleFuelRequestedResponse.ProtoReflect.Descriptor instead.
+func (*ToggleFuelRequestedResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ToggleFuelRequestedResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type RequestFuelRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId    string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	AircraftName string `protobuf:"bytes,2,opt,name=aircraft_name,json=aircraftName,proto3" json:"aircraft_name,omitempty"`
+	Note         string `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+}
+
+func (x *RequestFuelRequest) Reset() {
+	*x = RequestFuelRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestFuelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestFuelRequest) ProtoMessage() {}
+
+func (x *RequestFuelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestFuelRequest.ProtoReflect.Descriptor instead.
+func (*RequestFuelRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RequestFuelRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *RequestFuelRequest) GetAircraftName() string {
+	if x != nil {
+		return x.AircraftName
+	}
+	return ""
+}
+
+func (x *RequestFuelRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type UpdateFuelRequestRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Id        uint64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *UpdateFuelRequestRequest) Reset() {
+	*x = UpdateFuelRequestRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateFuelRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFuelRequestRequest) ProtoMessage() {}
+
+func (x *UpdateFuelRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFuelRequestRequest.ProtoReflect.Descriptor instead.
+func (*UpdateFuelRequestRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UpdateFuelRequestRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *UpdateFuelRequestRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type FuelRequestResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ErrorMessage string       `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Request      *FuelRequest `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"` // RequestFuel only
+}
+
+func (x *FuelRequestResponse) Reset() {
+	*x = FuelRequestResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FuelRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FuelRequestResponse) ProtoMessage() {}
+
+func (x *FuelRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FuelRequestResponse.ProtoReflect.Descriptor instead.
+func (*FuelRequestResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *FuelRequestResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *FuelRequestResponse) GetRequest() *FuelRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+type RestartServerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+}
+
+func (x *RestartServerRequest) Reset() {
+	*x = RestartServerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestartServerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestartServerRequest) ProtoMessage() {}
+
+func (x *RestartServerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestartServerRequest.ProtoReflect.Descriptor instead.
+func (*RestartServerRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RestartServerRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type RestartServerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ErrorMessage string `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+}
+
+func (x *RestartServerResponse) Reset() {
+	*x = RestartServerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestartServerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestartServerResponse) ProtoMessage() {}
+
+func (x *RestartServerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestartServerResponse.ProtoReflect.Descriptor instead.
+func (*RestartServerResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RestartServerResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type LoadHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartTime    int64  `protobuf:"varint,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // Unix time; loads last seen at or after
+	EndTime      int64  `protobuf:"varint,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // Unix time; loads first seen before
+	AircraftName string `protobuf:"bytes,3,opt,name=aircraft_name,json=aircraftName,proto3" json:"aircraft_name,omitempty"`
+	JumperName   string `protobuf:"bytes,4,opt,name=jumper_name,json=jumperName,proto3" json:"jumper_name,omitempty"` // matches any part of a jumper's name
+	Limit        int32  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	SessionId    string `protobuf:"bytes,6,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // of a user with the admin role
+}
+
+func (x *LoadHistoryRequest) Reset() {
+	*x = LoadHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoadHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadHistoryRequest) ProtoMessage() {}
+
+func (x *LoadHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadHistoryRequest.ProtoReflect.Descriptor instead.
+func (*LoadHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *LoadHistoryRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *LoadHistoryRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *LoadHistoryRequest) GetAircraftName() string {
+	if x != nil {
+		return x.AircraftName
+	}
+	return ""
+}
+
+func (x *LoadHistoryRequest) GetJumperName() string {
+	if x != nil {
+		return x.JumperName
+	}
+	return ""
+}
+
+func (x *LoadHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *LoadHistoryRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type LoadHistoryJumper struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ShortName   string `protobuf:"bytes,3,opt,name=short_name,json=shortName,proto3" json:"short_name,omitempty"`
+	RigName     string `protobuf:"bytes,4,opt,name=rig_name,json=rigName,proto3" json:"rig_name,omitempty"`
+	GroupLeader string `protobuf:"bytes,5,opt,name=group_leader,json=groupLeader,proto3" json:"group_leader,omitempty"`
+	FirstSeen   int64  `protobuf:"varint,6,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	LastSeen    int64  `protobuf:"varint,7,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+}
+
+func (x *LoadHistoryJumper) Reset() {
+	*x = LoadHistoryJumper{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoadHistoryJumper) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadHistoryJumper) ProtoMessage() {}
+
+func (x *LoadHistoryJumper) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadHistoryJumper.ProtoReflect.Descriptor instead.
+func (*LoadHistoryJumper) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *LoadHistoryJumper) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *LoadHistoryJumper) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LoadHistoryJumper) GetShortName() string {
+	if x != nil {
+		return x.ShortName
+	}
+	return ""
+}
+
+func (x *LoadHistoryJumper) GetRigName() string {
+	if x != nil {
+		return x.RigName
+	}
+	return ""
+}
+
+func (x *LoadHistoryJumper) GetGroupLeader() string {
+	if x != nil {
+		return x.GroupLeader
+	}
+	return ""
+}
+
+func (x *LoadHistoryJumper) GetFirstSeen() int64 {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return 0
+}
+
+func (x *LoadHistoryJumper) GetLastSeen() int64 {
+	if x != nil {
+		return x.LastSeen
+	}
+	return 0
+}
+
+type LoadHistoryCallTime struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CallMinutes int32 `protobuf:"varint,1,opt,name=call_minutes,json=callMinutes,proto3" json:"call_minutes,omitempty"`
+	Time        int64 `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *LoadHistoryCallTime) Reset() {
+	*x = LoadHistoryCallTime{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoadHistoryCallTime) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadHistoryCallTime) ProtoMessage() {}
+
+func (x *LoadHistoryCallTime) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadHistoryCallTime.ProtoReflect.Descriptor instead.
+func (*LoadHistoryCallTime) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *LoadHistoryCallTime) GetCallMinutes() int32 {
+	if x != nil {
+		return x.CallMinutes
+	}
+	return 0
+}
+
+func (x *LoadHistoryCallTime) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type LoadHistory struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	AircraftName string                 `protobuf:"bytes,2,opt,name=aircraft_name,json=aircraftName,proto3" json:"aircraft_name,omitempty"`
+	LoadNumber   string                 `protobuf:"bytes,3,opt,name=load_number,json=loadNumber,proto3" json:"load_number,omitempty"`
+	CallMinutes  int32                  `protobuf:"varint,4,opt,name=call_minutes,json=callMinutes,proto3" json:"call_minutes,omitempty"`
+	FirstSeen    int64                  `protobuf:"varint,5,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	LastSeen     int64                  `protobuf:"varint,6,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	Jumpers      []*LoadHistoryJumper   `protobuf:"bytes,7,rep,name=jumpers,proto3" json:"jumpers,omitempty"`
+	CallTimes    []*LoadHistoryCallTime `protobuf:"bytes,8,rep,name=call_times,json=callTimes,proto3" json:"call_times,omitempty"`
+}
+
+func (x *LoadHistory) Reset() {
+	*x = LoadHistory{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoadHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadHistory) ProtoMessage() {}
+
+func (x *LoadHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadHistory.ProtoReflect.Descriptor instead.
+func (*LoadHistory) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *LoadHistory) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *LoadHistory) GetAircraftName() string {
+	if x != nil {
+		return x.AircraftName
+	}
+	return ""
+}
+
+func (x *LoadHistory) GetLoadNumber() string {
+	if x != nil {
+		return x.LoadNumber
+	}
+	return ""
+}
+
+func (x *LoadHistory) GetCallMinutes() int32 {
+	if x != nil {
+		return x.CallMinutes
+	}
+	return 0
+}
+
+func (x *LoadHistory) GetFirstSeen() int64 {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return 0
+}
+
+func (x *LoadHistory) GetLastSeen() int64 {
+	if x != nil {
+		return x.LastSeen
+	}
+	return 0
+}
+
+func (x *LoadHistory) GetJumpers() []*LoadHistoryJumper {
+	if x != nil {
+		return x.Jumpers
+	}
+	return nil
+}
+
+func (x *LoadHistory) GetCallTimes() []*LoadHistoryCallTime {
+	if x != nil {
+		return x.CallTimes
+	}
+	return nil
+}
+
+type LoadHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Loads        []*LoadHistory `protobuf:"bytes,1,rep,name=loads,proto3" json:"loads,omitempty"`
+	ErrorMessage string         `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+}
+
+func (x *LoadHistoryResponse) Reset() {
+	*x = LoadHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoadHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadHistoryResponse) ProtoMessage() {}
+
+func (x *LoadHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadHistoryResponse.ProtoReflect.Descriptor instead.
+func (*LoadHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *LoadHistoryResponse) GetLoads() []*LoadHistory {
+	if x != nil {
+		return x.Loads
+	}
+	return nil
+}
+
+func (x *LoadHistoryResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type ManifestEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type             ManifestEventType `protobuf:"varint,1,opt,name=type,proto3,enum=manifest.ManifestEventType" json:"type,omitempty"`
+	Time             int64             `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"` // Unix time
+	LoadId           uint64            `protobuf:"varint,3,opt,name=load_id,json=loadId,proto3" json:"load_id,omitempty"`
+	AircraftName     string            `protobuf:"bytes,4,opt,name=aircraft_name,json=aircraftName,proto3" json:"aircraft_name,omitempty"`
+	LoadNumber       string            `protobuf:"bytes,5,opt,name=load_number,json=loadNumber,proto3" json:"load_number,omitempty"`
+	JumperName       string            `protobuf:"bytes,6,opt,name=jumper_name,json=jumperName,proto3" json:"jumper_name,omitempty"`
+	FromLoadId       uint64            `protobuf:"varint,7,opt,name=from_load_id,json=fromLoadId,proto3" json:"from_load_id,omitempty"`                  // JUMPER_MOVED only
+	FromAircraftName string            `protobuf:"bytes,8,opt,name=from_aircraft_name,json=fromAircraftName,proto3" json:"from_aircraft_name,omitempty"` // JUMPER_MOVED only
+	FromLoadNumber   string            `protobuf:"bytes,9,opt,name=from_load_number,json=fromLoadNumber,proto3" json:"from_load_number,omitempty"`       // JUMPER_MOVED only
+	CallMinutes      int32             `protobuf:"varint,10,opt,name=call_minutes,json=callMinutes,proto3" json:"call_minutes,omitempty"`
+	SlotsAvailable   int32             `protobuf:"varint,11,opt,name=slots_available,json=slotsAvailable,proto3" json:"slots_available,omitempty"`
+	Description      string            `protobuf:"bytes,12,opt,name=description,proto3" json:"description,omitempty"` // human readable, e.g. "Jane Doe added to Otter 12"
+}
+
+func (x *ManifestEvent) Reset() {
+	*x = ManifestEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ManifestEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManifestEvent) ProtoMessage() {}
+
+func (x *ManifestEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManifestEvent.ProtoReflect.Descriptor instead.
+func (*ManifestEvent) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ManifestEvent) GetType() ManifestEventType {
+	if x != nil {
+		return x.Type
+	}
+	return ManifestEventType_JUMPER_ADDED
+}
+
+func (x *ManifestEvent) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *ManifestEvent) GetLoadId() uint64 {
+	if x != nil {
+		return x.LoadId
+	}
+	return 0
+}
+
+func (x *ManifestEvent) GetAircraftName() string {
+	if x != nil {
+		return x.AircraftName
+	}
+	return ""
+}
+
+func (x *ManifestEvent) GetLoadNumber() string {
+	if x != nil {
+		return x.LoadNumber
+	}
+	return ""
+}
+
+func (x *ManifestEvent) GetJumperName() string {
+	if x != nil {
+		return x.JumperName
+	}
+	return ""
+}
+
+func (x *ManifestEvent) GetFromLoadId() uint64 {
+	if x != nil {
+		return x.FromLoadId
+	}
+	return 0
+}
+
+func (x *ManifestEvent) GetFromAircraftName() string {
+	if x != nil {
+		return x.FromAircraftName
+	}
+	return ""
+}
+
+func (x *ManifestEvent) GetFromLoadNumber() string {
+	if x != nil {
+		return x.FromLoadNumber
+	}
+	return ""
+}
+
+func (x *ManifestEvent) GetCallMinutes() int32 {
+	if x != nil {
+		return x.CallMinutes
+	}
+	return 0
+}
+
+func (x *ManifestEvent) GetSlotsAvailable() int32 {
+	if x != nil {
+		return x.SlotsAvailable
+	}
+	return 0
+}
+
+func (x *ManifestEvent) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type Dropzone struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // sent as "dropzone" request metadata to select the dropzone
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	IsDefault bool   `protobuf:"varint,3,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"` // used by requests that do not select a dropzone
+}
+
+func (x *Dropzone) Reset() {
+	*x = Dropzone{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Dropzone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dropzone) ProtoMessage() {}
+
+func (x *Dropzone) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dropzone.ProtoReflect.Descriptor instead.
+func (*Dropzone) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Dropzone) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Dropzone) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Dropzone) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+type ListDropzonesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dropzones []*Dropzone `protobuf:"bytes,1,rep,name=dropzones,proto3" json:"dropzones,omitempty"`
+}
+
+func (x *ListDropzonesResponse) Reset() {
+	*x = ListDropzonesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDropzonesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDropzonesResponse) ProtoMessage() {}
+
+func (x *ListDropzonesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDropzonesResponse.ProtoReflect.Descriptor instead.
+func (*ListDropzonesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListDropzonesResponse) GetDropzones() []*Dropzone {
+	if x != nil {
+		return x.Dropzones
+	}
+	return nil
+}
+
+type Announcement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Time         int64    `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"` // Unix time
+	LoadId       uint64   `protobuf:"varint,3,opt,name=load_id,json=loadId,proto3" json:"load_id,omitempty"`
+	AircraftName string   `protobuf:"bytes,4,opt,name=aircraft_name,json=aircraftName,proto3" json:"aircraft_name,omitempty"`
+	LoadNumber   string   `protobuf:"bytes,5,opt,name=load_number,json=loadNumber,proto3" json:"load_number,omitempty"`
+	CallMinutes  int32    `protobuf:"varint,6,opt,name=call_minutes,json=callMinutes,proto3" json:"call_minutes,omitempty"` // threshold announced; 0 is the NOW call
+	Jumpers      []string `protobuf:"bytes,7,rep,name=jumpers,proto3" json:"jumpers,omitempty"`
+	Text         string   `protobuf:"bytes,8,opt,name=text,proto3" json:"text,omitempty"`
+}
+
+func (x *Announcement) Reset() {
+	*x = Announcement{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Announcement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Announcement) ProtoMessage() {}
+
+func (x *Announcement) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Announcement.ProtoReflect.Descriptor instead.
+func (*Announcement) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *Announcement) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Announcement) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Announcement) GetLoadId() uint64 {
+	if x != nil {
+		return x.LoadId
+	}
+	return 0
+}
+
+func (x *Announcement) GetAircraftName() string {
+	if x != nil {
+		return x.AircraftName
+	}
+	return ""
+}
+
+func (x *Announcement) GetLoadNumber() string {
+	if x != nil {
+		return x.LoadNumber
+	}
+	return ""
+}
+
+func (x *Announcement) GetCallMinutes() int32 {
+	if x != nil {
+		return x.CallMinutes
+	}
+	return 0
+}
+
+func (x *Announcement) GetJumpers() []string {
+	if x != nil {
+		return x.Jumpers
+	}
+	return nil
+}
+
+func (x *Announcement) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type ResumeToken struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Epoch    uint64 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Sequence uint64 `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+}
+
+func (x *ResumeToken) Reset() {
+	*x = ResumeToken{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResumeToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeToken) ProtoMessage() {}
+
+func (x *ResumeToken) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeToken.ProtoReflect.Descriptor instead.
+func (*ResumeToken) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ResumeToken) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *ResumeToken) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type UpdateSubscription struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sections []UpdateSection `protobuf:"varint,1,rep,packed,name=sections,proto3,enum=manifest.UpdateSection" json:"sections,omitempty"` // all sections if empty
+	Aircraft []string        `protobuf:"bytes,2,rep,name=aircraft,proto3" json:"aircraft,omitempty"`                                     // names, tail numbers, or aliases; all if empty
+	MaxLoads int32           `protobuf:"varint,3,opt,name=max_loads,json=maxLoads,proto3" json:"max_loads,omitempty"`                    // 0 for the configured number of loads
+	Units    Units           `protobuf:"varint,4,opt,name=units,proto3,enum=manifest.Units" json:"units,omitempty"`                      // for human-readable strings
+	Resume   *ResumeToken    `protobuf:"bytes,5,opt,name=resume,proto3" json:"resume,omitempty"`                                         // epoch and sequence of the last update received
+}
+
+func (x *UpdateSubscription) Reset() {
+	*x = UpdateSubscription{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_service_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateSubscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSubscription) ProtoMessage() {}
+
+func (x *UpdateSubscription) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSubscription.ProtoReflect.Descriptor instead.
+func (*UpdateSubscription) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *UpdateSubscription) GetSections() []UpdateSection {
+	if x != nil {
+		return x.Sections
+	}
+	return nil
+}
+
+func (x *UpdateSubscription) GetAircraft() []string {
+	if x != nil {
+		return x.Aircraft
+	}
+	return nil
+}
+
+func (x *UpdateSubscription) GetMaxLoads() int32 {
+	if x != nil {
+		return x.MaxLoads
+	}
+	return 0
+}
+
+func (x *UpdateSubscription) GetUnits() Units {
+	if x != nil {
+		return x.Units
+	}
+	return Units_UNITS_DEFAULT
+}
+
+func (x *UpdateSubscription) GetResume() *ResumeToken {
+	if x != nil {
+		return x.Resume
+	}
+	return nil
+}
+
+type GetManifestRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Sections []UpdateSection `protobuf:"varint,1,rep,packed,name=sections,proto3,enum=manifest.UpdateSection" json:"sections,omitempty"` // all sections if empty
+	Aircraft []string        `protobuf:"bytes,2,rep,name=aircraft,proto3" json:"aircraft,omitempty"`                                     // names, tail numbers, or aliases; all if empty
 }
 
-func (x *SignOutRequest) Reset() {
-	*x = SignOutRequest{}
+func (x *GetManifestRequest) Reset() {
+	*x = GetManifestRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_server_service_proto_msgTypes[16]
+		mi := &file_pkg_server_service_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SignOutRequest) String() string {
+func (x *GetManifestRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignOutRequest) ProtoMessage() {}
+func (*GetManifestRequest) ProtoMessage() {}
 
-func (x *SignOutRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_server_service_proto_msgTypes[16]
+func (x *GetManifestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1401,43 +3540,51 @@ func (x *SignOutRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignOutRequest.ProtoReflect.Descriptor instead.
-func (*SignOutRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_server_service_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use GetManifestRequest.ProtoReflect.Descriptor instead.
+func (*GetManifestRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *SignOutRequest) GetSessionId() string {
+func (x *GetManifestRequest) GetSections() []UpdateSection {
 	if x != nil {
-		return x.SessionId
+		return x.Sections
 	}
-	return ""
+	return nil
 }
 
-type SignOutResponse struct {
+func (x *GetManifestRequest) GetAircraft() []string {
+	if x != nil {
+		return x.Aircraft
+	}
+	return nil
+}
+
+type SectionTime struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Section UpdateSection `protobuf:"varint,1,opt,name=section,proto3,enum=manifest.UpdateSection" json:"section,omitempty"`
+	Time    int64         `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"` // Unix time
 }
 
-func (x *SignOutResponse) Reset() {
-	*x = SignOutResponse{}
+func (x *SectionTime) Reset() {
+	*x = SectionTime{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_server_service_proto_msgTypes[17]
+		mi := &file_pkg_server_service_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SignOutResponse) String() string {
+func (x *SectionTime) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignOutResponse) ProtoMessage() {}
+func (*SectionTime) ProtoMessage() {}
 
-func (x *SignOutResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_server_service_proto_msgTypes[17]
+func (x *SectionTime) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1448,43 +3595,52 @@ func (x *SignOutResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignOutResponse.ProtoReflect.Descriptor instead.
-func (*SignOutResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_server_service_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use SectionTime.ProtoReflect.Descriptor instead.
+func (*SectionTime) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *SignOutResponse) GetSessionId() string {
+func (x *SectionTime) GetSection() UpdateSection {
 	if x != nil {
-		return x.SessionId
+		return x.Section
 	}
-	return ""
+	return UpdateSection_SECTION_UNSPECIFIED
 }
 
-type VerifySessionRequest struct {
+func (x *SectionTime) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type GetManifestResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Update       *ManifestUpdate `protobuf:"bytes,1,opt,name=update,proto3" json:"update,omitempty"`                                 // complete current update, in default units
+	Time         int64           `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`                                    // Unix time of the latest change
+	SectionTimes []*SectionTime  `protobuf:"bytes,3,rep,name=section_times,json=sectionTimes,proto3" json:"section_times,omitempty"` // when each section last changed
 }
 
-func (x *VerifySessionRequest) Reset() {
-	*x = VerifySessionRequest{}
+func (x *GetManifestResponse) Reset() {
+	*x = GetManifestResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_server_service_proto_msgTypes[18]
+		mi := &file_pkg_server_service_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *VerifySessionRequest) String() string {
+func (x *GetManifestResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifySessionRequest) ProtoMessage() {}
+func (*GetManifestResponse) ProtoMessage() {}
 
-func (x *VerifySessionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_server_service_proto_msgTypes[18]
+func (x *GetManifestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1495,43 +3651,66 @@ func (x *VerifySessionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifySessionRequest.ProtoReflect.Descriptor instead.
-func (*VerifySessionRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_server_service_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use GetManifestResponse.ProtoReflect.Descriptor instead.
+func (*GetManifestResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *VerifySessionRequest) GetSessionId() string {
+func (x *GetManifestResponse) GetUpdate() *ManifestUpdate {
 	if x != nil {
-		return x.SessionId
+		return x.Update
 	}
-	return ""
+	return nil
 }
 
-type ToggleFuelRequestedRequest struct {
+func (x *GetManifestResponse) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *GetManifestResponse) GetSectionTimes() []*SectionTime {
+	if x != nil {
+		return x.SectionTimes
+	}
+	return nil
+}
+
+type UpdateClient struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Id            uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Peer          string `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"`
+	ConnectedTime int64  `protobuf:"varint,3,opt,name=connected_time,json=connectedTime,proto3" json:"connected_time,omitempty"`
+	LastSendTime  int64  `protobuf:"varint,4,opt,name=last_send_time,json=lastSendTime,proto3" json:"last_send_time,omitempty"` // 0 if nothing has been sent
+	QueueDepth    int32  `protobuf:"varint,5,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"`         // number of updates merged into the pending update
+	Sent          uint64 `protobuf:"varint,6,opt,name=sent,proto3" json:"sent,omitempty"`
+	Coalesced     uint64 `protobuf:"varint,7,opt,name=coalesced,proto3" json:"coalesced,omitempty"`
+	Dropped       uint64 `protobuf:"varint,8,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	IsSending     bool   `protobuf:"varint,9,opt,name=is_sending,json=isSending,proto3" json:"is_sending,omitempty"`
+	IsSlow        bool   `protobuf:"varint,10,opt,name=is_slow,json=isSlow,proto3" json:"is_slow,omitempty"`
 }
 
-func (x *ToggleFuelRequestedRequest) Reset() {
-	*x = ToggleFuelRequestedRequest{}
+func (x *UpdateClient) Reset() {
+	*x = UpdateClient{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_server_service_proto_msgTypes[19]
+		mi := &file_pkg_server_service_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ToggleFuelRequestedRequest) String() string {
+func (x *UpdateClient) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ToggleFuelRequestedRequest) ProtoMessage() {}
+func (*UpdateClient) ProtoMessage() {}
 
-func (x *ToggleFuelRequestedRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_server_service_proto_msgTypes[19]
+func (x *UpdateClient) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1542,66 +3721,82 @@ func (x *ToggleFuelRequestedRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ToggleFuelRequestedRequest.ProtoReflect.Descriptor instead.
-func (*ToggleFuelRequestedRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_server_service_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use UpdateClient.ProtoReflect.Descriptor instead.
+func (*UpdateClient) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *ToggleFuelRequestedRequest) GetSessionId() string {
+func (x *UpdateClient) GetId() uint64 {
 	if x != nil {
-		return x.SessionId
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateClient) GetPeer() string {
+	if x != nil {
+		return x.Peer
 	}
 	return ""
 }
 
-type ToggleFuelRequestedResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *UpdateClient) GetConnectedTime() int64 {
+	if x != nil {
+		return x.ConnectedTime
+	}
+	return 0
+}
 
-	ErrorMessage string `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+func (x *UpdateClient) GetLastSendTime() int64 {
+	if x != nil {
+		return x.LastSendTime
+	}
+	return 0
 }
 
-func (x *ToggleFuelRequestedResponse) Reset() {
-	*x = ToggleFuelRequestedResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_server_service_proto_msgTypes[20]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *UpdateClient) GetQueueDepth() int32 {
+	if x != nil {
+		return x.QueueDepth
 	}
+	return 0
 }
 
-func (x *ToggleFuelRequestedResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *UpdateClient) GetSent() uint64 {
+	if x != nil {
+		return x.Sent
+	}
+	return 0
 }
 
-func (*ToggleFuelRequestedResponse) ProtoMessage() {}
+func (x *UpdateClient) GetCoalesced() uint64 {
+	if x != nil {
+		return x.Coalesced
+	}
+	return 0
+}
 
-func (x *ToggleFuelRequestedResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_server_service_proto_msgTypes[20]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *UpdateClient) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use ToggleFuelRequestedResponse.ProtoReflect.Descriptor instead.
-func (*ToggleFuelRequestedResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_server_service_proto_rawDescGZIP(), []int{20}
+func (x *UpdateClient) GetIsSending() bool {
+	if x != nil {
+		return x.IsSending
+	}
+	return false
 }
 
-func (x *ToggleFuelRequestedResponse) GetErrorMessage() string {
+func (x *UpdateClient) GetIsSlow() bool {
 	if x != nil {
-		return x.ErrorMessage
+		return x.IsSlow
 	}
-	return ""
+	return false
 }
 
-type RestartServerRequest struct {
+type ListUpdateClientsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -1609,23 +3804,23 @@ type RestartServerRequest struct {
 	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
 }
 
-func (x *RestartServerRequest) Reset() {
-	*x = RestartServerRequest{}
+func (x *ListUpdateClientsRequest) Reset() {
+	*x = ListUpdateClientsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_server_service_proto_msgTypes[21]
+		mi := &file_pkg_server_service_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RestartServerRequest) String() string {
+func (x *ListUpdateClientsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RestartServerRequest) ProtoMessage() {}
+func (*ListUpdateClientsRequest) ProtoMessage() {}
 
-func (x *RestartServerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_server_service_proto_msgTypes[21]
+func (x *ListUpdateClientsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1636,43 +3831,44 @@ func (x *RestartServerRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RestartServerRequest.ProtoReflect.Descriptor instead.
-func (*RestartServerRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_server_service_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use ListUpdateClientsRequest.ProtoReflect.Descriptor instead.
+func (*ListUpdateClientsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *RestartServerRequest) GetSessionId() string {
+func (x *ListUpdateClientsRequest) GetSessionId() string {
 	if x != nil {
 		return x.SessionId
 	}
 	return ""
 }
 
-type RestartServerResponse struct {
+type ListUpdateClientsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ErrorMessage string `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	ErrorMessage string          `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Clients      []*UpdateClient `protobuf:"bytes,2,rep,name=clients,proto3" json:"clients,omitempty"`
 }
 
-func (x *RestartServerResponse) Reset() {
-	*x = RestartServerResponse{}
+func (x *ListUpdateClientsResponse) Reset() {
+	*x = ListUpdateClientsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_server_service_proto_msgTypes[22]
+		mi := &file_pkg_server_service_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RestartServerResponse) String() string {
+func (x *ListUpdateClientsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RestartServerResponse) ProtoMessage() {}
+func (*ListUpdateClientsResponse) ProtoMessage() {}
 
-func (x *RestartServerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_server_service_proto_msgTypes[22]
+func (x *ListUpdateClientsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_service_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1683,18 +3879,25 @@ func (x *RestartServerResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RestartServerResponse.ProtoReflect.Descriptor instead.
-func (*RestartServerResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_server_service_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use ListUpdateClientsResponse.ProtoReflect.Descriptor instead.
+func (*ListUpdateClientsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_server_service_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *RestartServerResponse) GetErrorMessage() string {
+func (x *ListUpdateClientsResponse) GetErrorMessage() string {
 	if x != nil {
 		return x.ErrorMessage
 	}
 	return ""
 }
 
+func (x *ListUpdateClientsResponse) GetClients() []*UpdateClient {
+	if x != nil {
+		return x.Clients
+	}
+	return nil
+}
+
 var File_pkg_server_service_proto protoreflect.FileDescriptor
 
 var file_pkg_server_service_proto_rawDesc = []byte{
@@ -1789,7 +3992,7 @@ var file_pkg_server_service_proto_rawDesc = []byte{
 	0x66, 0x74, 0x12, 0x34, 0x0a, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20,
 	0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x57,
 	0x69, 0x6e, 0x64, 0x73, 0x41, 0x6c, 0x6f, 0x66, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52,
-	0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x22, 0xd6, 0x01, 0x0a, 0x06, 0x4a, 0x75, 0x6d,
+	0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x22, 0xb6, 0x02, 0x0a, 0x06, 0x4a, 0x75, 0x6d,
 	0x70, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
 	0x02, 0x69, 0x64, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x0e, 0x32, 0x14, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x4a, 0x75, 0x6d,
@@ -1803,7 +4006,13 @@ var file_pkg_server_service_proto_rawDesc = []byte{
 	0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x04, 0x72, 0x65, 0x70, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x69, 0x67, 0x5f, 0x6e, 0x61,
 	0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x69, 0x67, 0x4e, 0x61, 0x6d,
-	0x65, 0x22, 0x63, 0x0a, 0x0b, 0x4a, 0x75, 0x6d, 0x70, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x65, 0x12, 0x41, 0x0a, 0x0f, 0x73, 0x74, 0x75, 0x64, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x72, 0x6f,
+	0x67, 0x72, 0x61, 0x6d, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x6d, 0x61, 0x6e,
+	0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x75, 0x64, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x6f,
+	0x67, 0x72, 0x61, 0x6d, 0x52, 0x0e, 0x73, 0x74, 0x75, 0x64, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x6f,
+	0x67, 0x72, 0x61, 0x6d, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x66, 0x66, 0x5f, 0x6c, 0x65, 0x76, 0x65,
+	0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x61, 0x66, 0x66, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x22, 0x63, 0x0a, 0x0b, 0x4a, 0x75, 0x6d, 0x70, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70,
 	0x12, 0x28, 0x0a, 0x06, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x10, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x4a, 0x75, 0x6d, 0x70,
 	0x65, 0x72, 0x52, 0x06, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x07, 0x6d, 0x65,
@@ -1841,131 +4050,506 @@ var file_pkg_server_service_proto_rawDesc = []byte{
 	0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x4e, 0x6f, 0x54, 0x69,
 	0x6d, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28,
 	0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x6f, 0x61,
-	0x64, 0x53, 0x6c, 0x6f, 0x74, 0x52, 0x05, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x22, 0x50, 0x0a, 0x05,
-	0x4c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x5f,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x63, 0x6f, 0x6c,
-	0x75, 0x6d, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x05, 0x6c, 0x6f, 0x61, 0x64,
-	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65,
-	0x73, 0x74, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x52, 0x05, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x22, 0xc8,
-	0x02, 0x0a, 0x0e, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x12, 0x2d, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x88, 0x01, 0x01,
-	0x12, 0x30, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x4f, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x48, 0x01, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x88,
-	0x01, 0x01, 0x12, 0x30, 0x0a, 0x07, 0x6a, 0x75, 0x6d, 0x70, 0x72, 0x75, 0x6e, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x4a,
-	0x75, 0x6d, 0x70, 0x72, 0x75, 0x6e, 0x48, 0x02, 0x52, 0x07, 0x6a, 0x75, 0x6d, 0x70, 0x72, 0x75,
-	0x6e, 0x88, 0x01, 0x01, 0x12, 0x3a, 0x0a, 0x0b, 0x77, 0x69, 0x6e, 0x64, 0x73, 0x5f, 0x61, 0x6c,
-	0x6f, 0x66, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x61, 0x6e, 0x69,
-	0x66, 0x65, 0x73, 0x74, 0x2e, 0x57, 0x69, 0x6e, 0x64, 0x73, 0x41, 0x6c, 0x6f, 0x66, 0x74, 0x48,
-	0x03, 0x52, 0x0a, 0x77, 0x69, 0x6e, 0x64, 0x73, 0x41, 0x6c, 0x6f, 0x66, 0x74, 0x88, 0x01, 0x01,
-	0x12, 0x2a, 0x0a, 0x05, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0f, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x73,
-	0x48, 0x04, 0x52, 0x05, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07,
-	0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6a, 0x75, 0x6d, 0x70, 0x72, 0x75, 0x6e, 0x42,
-	0x0e, 0x0a, 0x0c, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x73, 0x5f, 0x61, 0x6c, 0x6f, 0x66, 0x74, 0x42,
-	0x08, 0x0a, 0x06, 0x5f, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x22, 0xe1, 0x01, 0x0a, 0x16, 0x53, 0x69,
-	0x67, 0x6e, 0x49, 0x6e, 0x57, 0x69, 0x74, 0x68, 0x41, 0x70, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x49,
-	0x64, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x64, 0x65, 0x6e, 0x74,
-	0x69, 0x74, 0x79, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0d, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2d,
-	0x0a, 0x12, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
-	0x63, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x61, 0x75, 0x74, 0x68,
-	0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1d, 0x0a,
-	0x0a, 0x67, 0x69, 0x76, 0x65, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x67, 0x69, 0x76, 0x65, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
-	0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0a, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xdd, 0x01,
-	0x0a, 0x0e, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x64, 0x53, 0x6c, 0x6f, 0x74, 0x52, 0x05, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x22, 0xab, 0x02, 0x0a,
+	0x08, 0x41, 0x69, 0x72, 0x63, 0x72, 0x61, 0x66, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
+	0x61, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x69, 0x6c, 0x5f,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x61,
+	0x69, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x63, 0x61, 0x6f,
+	0x5f, 0x68, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x63, 0x61, 0x6f,
+	0x48, 0x65, 0x78, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12,
+	0x29, 0x0a, 0x10, 0x6a, 0x75, 0x6d, 0x70, 0x72, 0x75, 0x6e, 0x5f, 0x61, 0x69, 0x72, 0x73, 0x70,
+	0x65, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x6a, 0x75, 0x6d, 0x70, 0x72,
+	0x75, 0x6e, 0x41, 0x69, 0x72, 0x73, 0x70, 0x65, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6c,
+	0x69, 0x6d, 0x62, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09,
+	0x63, 0x6c, 0x69, 0x6d, 0x62, 0x52, 0x61, 0x74, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x78, 0x69,
+	0x74, 0x5f, 0x61, 0x6c, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0c, 0x65, 0x78, 0x69, 0x74, 0x41, 0x6c, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63,
+	0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x22, 0x80, 0x01, 0x0a, 0x05, 0x4c,
+	0x6f, 0x61, 0x64, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x63, 0x6f, 0x6c, 0x75,
+	0x6d, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x05, 0x6c, 0x6f, 0x61, 0x64, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73,
+	0x74, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x52, 0x05, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x2e, 0x0a,
+	0x08, 0x61, 0x69, 0x72, 0x63, 0x72, 0x61, 0x66, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x41, 0x69, 0x72, 0x63, 0x72,
+	0x61, 0x66, 0x74, 0x52, 0x08, 0x61, 0x69, 0x72, 0x63, 0x72, 0x61, 0x66, 0x74, 0x22, 0xb8, 0x02,
+	0x0a, 0x0b, 0x46, 0x75, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x23, 0x0a,
+	0x0d, 0x61, 0x69, 0x72, 0x63, 0x72, 0x61, 0x66, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x69, 0x72, 0x63, 0x72, 0x61, 0x66, 0x74, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x0f,
+	0x61, 0x63, 0x6b, 0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x63, 0x6b, 0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64,
+	0x67, 0x65, 0x64, 0x42, 0x79, 0x12, 0x29, 0x0a, 0x10, 0x61, 0x63, 0x6b, 0x6e, 0x6f, 0x77, 0x6c,
+	0x65, 0x64, 0x67, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0f, 0x61, 0x63, 0x6b, 0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65,
+	0x64, 0x42, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x63, 0x6f, 0x6d, 0x70,
+	0x6c, 0x65, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x41, 0x0a, 0x0c, 0x46, 0x75, 0x65, 0x6c,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x31, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x61, 0x6e,
+	0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x75, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x22, 0xed, 0x02, 0x0a, 0x10,
+	0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x61, 0x74, 0x74,
+	0x65, 0x6d, 0x70, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74,
+	0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6c,
+	0x61, 0x73, 0x74, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61,
+	0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x31, 0x0a, 0x14, 0x63, 0x6f, 0x6e,
+	0x73, 0x65, 0x63, 0x75, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x63, 0x75,
+	0x74, 0x69, 0x76, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c,
+	0x6e, 0x65, 0x78, 0x74, 0x5f, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x12,
+	0x19, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x61, 0x67, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x41, 0x67, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74,
+	0x61, 0x6c, 0x65, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0a, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x69,
+	0x73, 0x5f, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69,
+	0x73, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x63, 0x6f, 0x64, 0x65,
+	0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x64,
+	0x65, 0x63, 0x6f, 0x64, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x22, 0x44, 0x0a, 0x0c, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x34, 0x0a, 0x07, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d,
+	0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x73, 0x22, 0xc3, 0x04, 0x0a, 0x0e, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x12, 0x2d, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x88, 0x01, 0x01, 0x12, 0x30, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x48, 0x01, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x88, 0x01, 0x01, 0x12, 0x30, 0x0a, 0x07, 0x6a, 0x75, 0x6d, 0x70, 0x72, 0x75, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73,
+	0x74, 0x2e, 0x4a, 0x75, 0x6d, 0x70, 0x72, 0x75, 0x6e, 0x48, 0x02, 0x52, 0x07, 0x6a, 0x75, 0x6d,
+	0x70, 0x72, 0x75, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x3a, 0x0a, 0x0b, 0x77, 0x69, 0x6e, 0x64, 0x73,
+	0x5f, 0x61, 0x6c, 0x6f, 0x66, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d,
+	0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x57, 0x69, 0x6e, 0x64, 0x73, 0x41, 0x6c, 0x6f,
+	0x66, 0x74, 0x48, 0x03, 0x52, 0x0a, 0x77, 0x69, 0x6e, 0x64, 0x73, 0x41, 0x6c, 0x6f, 0x66, 0x74,
+	0x88, 0x01, 0x01, 0x12, 0x2a, 0x0a, 0x05, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x6f,
+	0x61, 0x64, 0x73, 0x48, 0x04, 0x52, 0x05, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x88, 0x01, 0x01, 0x12,
+	0x40, 0x0a, 0x0d, 0x66, 0x75, 0x65, 0x6c, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73,
+	0x74, 0x2e, 0x46, 0x75, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x48, 0x05,
+	0x52, 0x0c, 0x66, 0x75, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x88, 0x01,
+	0x01, 0x12, 0x40, 0x0a, 0x0d, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x68, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66,
+	0x65, 0x73, 0x74, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x48, 0x06, 0x52, 0x0c, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x88, 0x01, 0x01, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x0a, 0x0a,
+	0x08, 0x5f, 0x6a, 0x75, 0x6d, 0x70, 0x72, 0x75, 0x6e, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x77, 0x69,
+	0x6e, 0x64, 0x73, 0x5f, 0x61, 0x6c, 0x6f, 0x66, 0x74, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6c, 0x6f,
+	0x61, 0x64, 0x73, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x66, 0x75, 0x65, 0x6c, 0x5f, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x73, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x22, 0xe1, 0x01, 0x0a, 0x16, 0x53, 0x69, 0x67, 0x6e,
+	0x49, 0x6e, 0x57, 0x69, 0x74, 0x68, 0x41, 0x70, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x49, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x69,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2d, 0x0a, 0x12,
+	0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x67,
+	0x69, 0x76, 0x65, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x67, 0x69, 0x76, 0x65, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x61,
+	0x6d, 0x69, 0x6c, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xdd, 0x01, 0x0a, 0x0e,
+	0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x2d, 0x0a,
+	0x12, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08,
+	0x69, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x69, 0x73, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x23, 0x0a,
+	0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x73, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x22, 0x2f, 0x0a, 0x0e, 0x53,
+	0x69, 0x67, 0x6e, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x30, 0x0a, 0x0f,
+	0x53, 0x69, 0x67, 0x6e, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x35,
+	0x0a, 0x14, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x3b, 0x0a, 0x1a, 0x54, 0x6f, 0x67, 0x67, 0x6c, 0x65, 0x46,
+	0x75, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x22, 0x42, 0x0a, 0x1b, 0x54, 0x6f, 0x67, 0x67, 0x6c, 0x65, 0x46, 0x75, 0x65, 0x6c,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x6c, 0x0a, 0x12, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x46, 0x75, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x61,
+	0x69, 0x72, 0x63, 0x72, 0x61, 0x66, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x61, 0x69, 0x72, 0x63, 0x72, 0x61, 0x66, 0x74, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x6f, 0x74, 0x65, 0x22, 0x49, 0x0a, 0x18, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x75,
+	0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12,
-	0x2d, 0x0a, 0x12, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x73, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19,
-	0x0a, 0x08, 0x69, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x07, 0x69, 0x73, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x6c,
-	0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x12,
-	0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f,
-	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x73,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x22, 0x2f, 0x0a,
-	0x0e, 0x53, 0x69, 0x67, 0x6e, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x30,
-	0x0a, 0x0f, 0x53, 0x69, 0x67, 0x6e, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64,
-	0x22, 0x35, 0x0a, 0x14, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x3b, 0x0a, 0x1a, 0x54, 0x6f, 0x67, 0x67, 0x6c,
-	0x65, 0x46, 0x75, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x65,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22,
+	0x6b, 0x0a, 0x13, 0x46, 0x75, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2f, 0x0a, 0x07, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d,
+	0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x75, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x35, 0x0a, 0x14,
+	0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x22, 0x3c, 0x0a, 0x15, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0xc9, 0x01, 0x0a, 0x12, 0x4c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x69, 0x72, 0x63, 0x72, 0x61, 0x66, 0x74, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x69, 0x72, 0x63, 0x72,
+	0x61, 0x66, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6a, 0x75, 0x6d, 0x70, 0x65,
+	0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6a, 0x75,
+	0x6d, 0x70, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0xd0, 0x01,
+	0x0a, 0x11, 0x4c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x4a, 0x75, 0x6d,
+	0x70, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x68, 0x6f, 0x72, 0x74,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x68, 0x6f,
+	0x72, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x69, 0x67, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x69, 0x67, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x6c, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x4c, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x73, 0x65,
+	0x65, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74, 0x53,
+	0x65, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e,
+	0x22, 0x4c, 0x0a, 0x13, 0x4c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x43,
+	0x61, 0x6c, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x61, 0x6c, 0x6c, 0x5f,
+	0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x63,
+	0x61, 0x6c, 0x6c, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0xb7,
+	0x02, 0x0a, 0x0b, 0x4c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x23,
+	0x0a, 0x0d, 0x61, 0x69, 0x72, 0x63, 0x72, 0x61, 0x66, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x69, 0x72, 0x63, 0x72, 0x61, 0x66, 0x74, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6c, 0x6f, 0x61, 0x64, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x61, 0x6c, 0x6c, 0x5f, 0x6d, 0x69, 0x6e,
+	0x75, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x63, 0x61, 0x6c, 0x6c,
+	0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x72, 0x73, 0x74,
+	0x5f, 0x73, 0x65, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x66, 0x69, 0x72,
+	0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73,
+	0x65, 0x65, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x53,
+	0x65, 0x65, 0x6e, 0x12, 0x35, 0x0a, 0x07, 0x6a, 0x75, 0x6d, 0x70, 0x65, 0x72, 0x73, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e,
+	0x4c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x4a, 0x75, 0x6d, 0x70, 0x65,
+	0x72, 0x52, 0x07, 0x6a, 0x75, 0x6d, 0x70, 0x65, 0x72, 0x73, 0x12, 0x3c, 0x0a, 0x0a, 0x63, 0x61,
+	0x6c, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x43, 0x61, 0x6c, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x09, 0x63,
+	0x61, 0x6c, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x22, 0x67, 0x0a, 0x13, 0x4c, 0x6f, 0x61, 0x64,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2b, 0x0a, 0x05, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x05, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x23, 0x0a, 0x0d,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0xbc, 0x03, 0x0a, 0x0d, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x2f, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1b, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x6e,
+	0x69, 0x66, 0x65, 0x73, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6c, 0x6f, 0x61, 0x64,
+	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6c, 0x6f, 0x61, 0x64, 0x49,
+	0x64, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x69, 0x72, 0x63, 0x72, 0x61, 0x66, 0x74, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x69, 0x72, 0x63, 0x72, 0x61,
+	0x66, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6c, 0x6f, 0x61,
+	0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x6a, 0x75, 0x6d, 0x70, 0x65,
+	0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6a, 0x75,
+	0x6d, 0x70, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x66, 0x72, 0x6f, 0x6d,
+	0x5f, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a,
+	0x66, 0x72, 0x6f, 0x6d, 0x4c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x66, 0x72,
+	0x6f, 0x6d, 0x5f, 0x61, 0x69, 0x72, 0x63, 0x72, 0x61, 0x66, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x66, 0x72, 0x6f, 0x6d, 0x41, 0x69, 0x72, 0x63,
+	0x72, 0x61, 0x66, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x66, 0x72, 0x6f, 0x6d,
+	0x5f, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0e, 0x66, 0x72, 0x6f, 0x6d, 0x4c, 0x6f, 0x61, 0x64, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x61, 0x6c, 0x6c, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74,
+	0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x63, 0x61, 0x6c, 0x6c, 0x4d, 0x69,
+	0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x5f, 0x61,
+	0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e,
+	0x73, 0x6c, 0x6f, 0x74, 0x73, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x20,
+	0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0x4d, 0x0a, 0x08, 0x44, 0x72, 0x6f, 0x70, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x22,
+	0x49, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x72, 0x6f, 0x70, 0x7a, 0x6f, 0x6e, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x09, 0x64, 0x72, 0x6f, 0x70,
+	0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x61,
+	0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x72, 0x6f, 0x70, 0x7a, 0x6f, 0x6e, 0x65, 0x52,
+	0x09, 0x64, 0x72, 0x6f, 0x70, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x22, 0xe2, 0x01, 0x0a, 0x0c, 0x41,
+	0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12,
+	0x17, 0x0a, 0x07, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x69, 0x72, 0x63,
+	0x72, 0x61, 0x66, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x61, 0x69, 0x72, 0x63, 0x72, 0x61, 0x66, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x6c, 0x6f, 0x61, 0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x21,
+	0x0a, 0x0c, 0x63, 0x61, 0x6c, 0x6c, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x63, 0x61, 0x6c, 0x6c, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x6a, 0x75, 0x6d, 0x70, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x07, 0x6a, 0x75, 0x6d, 0x70, 0x65, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x65, 0x78, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x22,
+	0x3f, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65,
+	0x70, 0x6f, 0x63, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x22, 0xd8, 0x01, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x08, 0x73, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x6d, 0x61, 0x6e, 0x69,
+	0x66, 0x65, 0x73, 0x74, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x08, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x61, 0x69, 0x72, 0x63, 0x72, 0x61, 0x66, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08,
+	0x61, 0x69, 0x72, 0x63, 0x72, 0x61, 0x66, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f,
+	0x6c, 0x6f, 0x61, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x61, 0x78,
+	0x4c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x25, 0x0a, 0x05, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e,
+	0x55, 0x6e, 0x69, 0x74, 0x73, 0x52, 0x05, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x12, 0x2d, 0x0a, 0x06,
+	0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d,
+	0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x22, 0x65, 0x0a, 0x12, 0x47,
+	0x65, 0x74, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x33, 0x0a, 0x08, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x73, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x69, 0x72, 0x63, 0x72, 0x61,
+	0x66, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x61, 0x69, 0x72, 0x63, 0x72, 0x61,
+	0x66, 0x74, 0x22, 0x54, 0x0a, 0x0b, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x31, 0x0a, 0x07, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x17, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x73, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x97, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74,
+	0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x30, 0x0a, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x6e, 0x69,
+	0x66, 0x65, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x06, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x3a, 0x0a, 0x0d, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x54, 0x69, 0x6d, 0x65, 0x52, 0x0c, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x22, 0xa4, 0x02, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0d, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x24,
+	0x0a, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x6e, 0x64,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x64, 0x65,
+	0x70, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x44, 0x65, 0x70, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x61,
+	0x6c, 0x65, 0x73, 0x63, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x63, 0x6f,
+	0x61, 0x6c, 0x65, 0x73, 0x63, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70,
+	0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65,
+	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x73, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x53, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x12, 0x17, 0x0a, 0x07, 0x69, 0x73, 0x5f, 0x73, 0x6c, 0x6f, 0x77, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x69, 0x73, 0x53, 0x6c, 0x6f, 0x77, 0x22, 0x39, 0x0a, 0x18, 0x4c, 0x69, 0x73,
+	0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65,
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
 	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x49, 0x64, 0x22, 0x42, 0x0a, 0x1b, 0x54, 0x6f, 0x67, 0x67, 0x6c, 0x65, 0x46, 0x75,
-	0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x35, 0x0a, 0x14, 0x52, 0x65, 0x73, 0x74,
-	0x61, 0x72, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22,
-	0x3c, 0x0a, 0x15, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2a, 0x9d, 0x01,
-	0x0a, 0x0a, 0x4a, 0x75, 0x6d, 0x70, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0f, 0x0a, 0x0b,
-	0x45, 0x58, 0x50, 0x45, 0x52, 0x49, 0x45, 0x4e, 0x43, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a,
-	0x0b, 0x41, 0x46, 0x46, 0x5f, 0x53, 0x54, 0x55, 0x44, 0x45, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x11,
-	0x0a, 0x0d, 0x43, 0x4f, 0x41, 0x43, 0x48, 0x5f, 0x53, 0x54, 0x55, 0x44, 0x45, 0x4e, 0x54, 0x10,
-	0x02, 0x12, 0x12, 0x0a, 0x0e, 0x54, 0x41, 0x4e, 0x44, 0x45, 0x4d, 0x5f, 0x53, 0x54, 0x55, 0x44,
-	0x45, 0x4e, 0x54, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x46, 0x46, 0x5f, 0x49, 0x4e, 0x53,
-	0x54, 0x52, 0x55, 0x43, 0x54, 0x4f, 0x52, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x4f, 0x41,
-	0x43, 0x48, 0x10, 0x05, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x41, 0x4e, 0x44, 0x45, 0x4d, 0x5f, 0x49,
-	0x4e, 0x53, 0x54, 0x52, 0x55, 0x43, 0x54, 0x4f, 0x52, 0x10, 0x06, 0x12, 0x10, 0x0a, 0x0c, 0x56,
-	0x49, 0x44, 0x45, 0x4f, 0x47, 0x52, 0x41, 0x50, 0x48, 0x45, 0x52, 0x10, 0x07, 0x32, 0xe8, 0x03,
-	0x0a, 0x0f, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x43, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x18, 0x2e, 0x6d, 0x61, 0x6e,
-	0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x30, 0x01, 0x12, 0x4d, 0x0a, 0x0f, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e,
-	0x57, 0x69, 0x74, 0x68, 0x41, 0x70, 0x70, 0x6c, 0x65, 0x12, 0x20, 0x2e, 0x6d, 0x61, 0x6e, 0x69,
-	0x66, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x57, 0x69, 0x74, 0x68, 0x41,
-	0x70, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6d, 0x61,
-	0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x07, 0x53, 0x69, 0x67, 0x6e, 0x4f, 0x75, 0x74,
-	0x12, 0x18, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x69, 0x67, 0x6e,
-	0x4f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6d, 0x61, 0x6e,
-	0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x0f, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x53,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1e, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66,
-	0x65, 0x73, 0x74, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66,
-	0x65, 0x73, 0x74, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x62, 0x0a, 0x13, 0x54, 0x6f, 0x67, 0x67, 0x6c, 0x65, 0x46, 0x75, 0x65, 0x6c,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x24, 0x2e, 0x6d, 0x61, 0x6e, 0x69,
-	0x66, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x6f, 0x67, 0x67, 0x6c, 0x65, 0x46, 0x75, 0x65, 0x6c, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x25, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x6f, 0x67, 0x67, 0x6c,
-	0x65, 0x46, 0x75, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72,
-	0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x1e, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65,
-	0x73, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65,
-	0x73, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6a, 0x75, 0x6d, 0x70, 0x74, 0x6f, 0x77, 0x6e, 0x2d,
-	0x73, 0x6b, 0x79, 0x64, 0x69, 0x76, 0x69, 0x6e, 0x67, 0x2f, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65,
-	0x73, 0x74, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x6e, 0x49, 0x64, 0x22, 0x72, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65,
+	0x73, 0x74, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52,
+	0x07, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x2a, 0xdb, 0x01, 0x0a, 0x0a, 0x4a, 0x75, 0x6d,
+	0x70, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x45, 0x58, 0x50, 0x45, 0x52,
+	0x49, 0x45, 0x4e, 0x43, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x46, 0x46, 0x5f,
+	0x53, 0x54, 0x55, 0x44, 0x45, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x43, 0x4f, 0x41,
+	0x43, 0x48, 0x5f, 0x53, 0x54, 0x55, 0x44, 0x45, 0x4e, 0x54, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e,
+	0x54, 0x41, 0x4e, 0x44, 0x45, 0x4d, 0x5f, 0x53, 0x54, 0x55, 0x44, 0x45, 0x4e, 0x54, 0x10, 0x03,
+	0x12, 0x12, 0x0a, 0x0e, 0x41, 0x46, 0x46, 0x5f, 0x49, 0x4e, 0x53, 0x54, 0x52, 0x55, 0x43, 0x54,
+	0x4f, 0x52, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x4f, 0x41, 0x43, 0x48, 0x10, 0x05, 0x12,
+	0x15, 0x0a, 0x11, 0x54, 0x41, 0x4e, 0x44, 0x45, 0x4d, 0x5f, 0x49, 0x4e, 0x53, 0x54, 0x52, 0x55,
+	0x43, 0x54, 0x4f, 0x52, 0x10, 0x06, 0x12, 0x10, 0x0a, 0x0c, 0x56, 0x49, 0x44, 0x45, 0x4f, 0x47,
+	0x52, 0x41, 0x50, 0x48, 0x45, 0x52, 0x10, 0x07, 0x12, 0x0f, 0x0a, 0x0b, 0x49, 0x41, 0x44, 0x5f,
+	0x53, 0x54, 0x55, 0x44, 0x45, 0x4e, 0x54, 0x10, 0x08, 0x12, 0x12, 0x0a, 0x0e, 0x49, 0x41, 0x44,
+	0x5f, 0x49, 0x4e, 0x53, 0x54, 0x52, 0x55, 0x43, 0x54, 0x4f, 0x52, 0x10, 0x09, 0x12, 0x17, 0x0a,
+	0x13, 0x48, 0x4f, 0x50, 0x5f, 0x41, 0x4e, 0x44, 0x5f, 0x50, 0x4f, 0x50, 0x5f, 0x53, 0x54, 0x55,
+	0x44, 0x45, 0x4e, 0x54, 0x10, 0x0a, 0x2a, 0x73, 0x0a, 0x0e, 0x53, 0x74, 0x75, 0x64, 0x65, 0x6e,
+	0x74, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x13, 0x0a, 0x0f, 0x50, 0x52, 0x4f, 0x47,
+	0x52, 0x41, 0x4d, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0f, 0x0a,
+	0x0b, 0x50, 0x52, 0x4f, 0x47, 0x52, 0x41, 0x4d, 0x5f, 0x41, 0x46, 0x46, 0x10, 0x01, 0x12, 0x11,
+	0x0a, 0x0d, 0x50, 0x52, 0x4f, 0x47, 0x52, 0x41, 0x4d, 0x5f, 0x43, 0x4f, 0x41, 0x43, 0x48, 0x10,
+	0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x52, 0x4f, 0x47, 0x52, 0x41, 0x4d, 0x5f, 0x49, 0x41, 0x44,
+	0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x50, 0x52, 0x4f, 0x47, 0x52, 0x41, 0x4d, 0x5f, 0x48, 0x4f,
+	0x50, 0x5f, 0x41, 0x4e, 0x44, 0x5f, 0x50, 0x4f, 0x50, 0x10, 0x04, 0x2a, 0xa3, 0x01, 0x0a, 0x11,
+	0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x10, 0x0a, 0x0c, 0x4a, 0x55, 0x4d, 0x50, 0x45, 0x52, 0x5f, 0x41, 0x44, 0x44, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x4a, 0x55, 0x4d, 0x50, 0x45, 0x52, 0x5f, 0x52, 0x45,
+	0x4d, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x4a, 0x55, 0x4d, 0x50, 0x45,
+	0x52, 0x5f, 0x4d, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x4c, 0x4f, 0x41,
+	0x44, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x4c,
+	0x4f, 0x41, 0x44, 0x5f, 0x44, 0x45, 0x50, 0x41, 0x52, 0x54, 0x45, 0x44, 0x10, 0x04, 0x12, 0x10,
+	0x0a, 0x0c, 0x4c, 0x4f, 0x41, 0x44, 0x5f, 0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x05,
+	0x12, 0x0d, 0x0a, 0x09, 0x43, 0x41, 0x4c, 0x4c, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x10, 0x06, 0x12,
+	0x10, 0x0a, 0x0c, 0x53, 0x4c, 0x4f, 0x54, 0x53, 0x5f, 0x4f, 0x50, 0x45, 0x4e, 0x45, 0x44, 0x10,
+	0x07, 0x2a, 0xc8, 0x01, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e,
+	0x53, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x10, 0x01,
+	0x12, 0x13, 0x0a, 0x0f, 0x53, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4f, 0x50, 0x54, 0x49,
+	0x4f, 0x4e, 0x53, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x4a, 0x55, 0x4d, 0x50, 0x52, 0x55, 0x4e, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x45,
+	0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x57, 0x49, 0x4e, 0x44, 0x53, 0x5f, 0x41, 0x4c, 0x4f, 0x46,
+	0x54, 0x10, 0x04, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4c,
+	0x4f, 0x41, 0x44, 0x53, 0x10, 0x05, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x45, 0x43, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x46, 0x55, 0x45, 0x4c, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x53, 0x10,
+	0x06, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x4f, 0x55,
+	0x52, 0x43, 0x45, 0x5f, 0x48, 0x45, 0x41, 0x4c, 0x54, 0x48, 0x10, 0x07, 0x2a, 0x54, 0x0a, 0x05,
+	0x55, 0x6e, 0x69, 0x74, 0x73, 0x12, 0x11, 0x0a, 0x0d, 0x55, 0x4e, 0x49, 0x54, 0x53, 0x5f, 0x44,
+	0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x55, 0x4e, 0x49, 0x54,
+	0x53, 0x5f, 0x49, 0x4d, 0x50, 0x45, 0x52, 0x49, 0x41, 0x4c, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c,
+	0x55, 0x4e, 0x49, 0x54, 0x53, 0x5f, 0x4d, 0x45, 0x54, 0x52, 0x49, 0x43, 0x10, 0x02, 0x12, 0x12,
+	0x0a, 0x0e, 0x55, 0x4e, 0x49, 0x54, 0x53, 0x5f, 0x4e, 0x41, 0x55, 0x54, 0x49, 0x43, 0x41, 0x4c,
+	0x10, 0x03, 0x32, 0x8a, 0x0a, 0x0a, 0x0f, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x43, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
+	0x18, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x6e, 0x69, 0x66,
+	0x65, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x30, 0x01, 0x12, 0x4c, 0x0a, 0x10, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12,
+	0x1c, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x18, 0x2e,
+	0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73,
+	0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x30, 0x01, 0x12, 0x4a, 0x0a, 0x0b, 0x47, 0x65, 0x74,
+	0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66,
+	0x65, 0x73, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73,
+	0x74, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0f, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x57,
+	0x69, 0x74, 0x68, 0x41, 0x70, 0x70, 0x6c, 0x65, 0x12, 0x20, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66,
+	0x65, 0x73, 0x74, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x57, 0x69, 0x74, 0x68, 0x41, 0x70,
+	0x70, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6d, 0x61, 0x6e,
+	0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x07, 0x53, 0x69, 0x67, 0x6e, 0x4f, 0x75, 0x74, 0x12,
+	0x18, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x4f,
+	0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6d, 0x61, 0x6e, 0x69,
+	0x66, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x0f, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1e, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65,
+	0x73, 0x74, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65,
+	0x73, 0x74, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x62, 0x0a, 0x13, 0x54, 0x6f, 0x67, 0x67, 0x6c, 0x65, 0x46, 0x75, 0x65, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x24, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66,
+	0x65, 0x73, 0x74, 0x2e, 0x54, 0x6f, 0x67, 0x67, 0x6c, 0x65, 0x46, 0x75, 0x65, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25,
+	0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x6f, 0x67, 0x67, 0x6c, 0x65,
+	0x46, 0x75, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x1e, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73,
+	0x74, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73,
+	0x74, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x46, 0x75, 0x65, 0x6c, 0x12, 0x1c, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73,
+	0x74, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x46, 0x75, 0x65, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e,
+	0x46, 0x75, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x5b, 0x0a, 0x16, 0x41, 0x63, 0x6b, 0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64,
+	0x67, 0x65, 0x46, 0x75, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x2e,
+	0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46,
+	0x75, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1d, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x75, 0x65,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x58, 0x0a, 0x13, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x75, 0x65, 0x6c,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65,
+	0x73, 0x74, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x75, 0x65, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6d, 0x61,
+	0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x75, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x10, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x4c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1c,
+	0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6d,
+	0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0c, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x4d,
+	0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x47,
+	0x0a, 0x13, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e,
+	0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x48, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x44,
+	0x72, 0x6f, 0x70, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x1f, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x44, 0x72, 0x6f, 0x70, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x5c, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x22, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73,
+	0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6d, 0x61, 0x6e,
+	0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
+	0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6a, 0x75,
+	0x6d, 0x70, 0x74, 0x6f, 0x77, 0x6e, 0x2d, 0x73, 0x6b, 0x79, 0x64, 0x69, 0x76, 0x69, 0x6e, 0x67,
+	0x2f, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1980,69 +4564,138 @@ func file_pkg_server_service_proto_rawDescGZIP() []byte {
 	return file_pkg_server_service_proto_rawDescData
 }
 
-var file_pkg_server_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_pkg_server_service_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_pkg_server_service_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_pkg_server_service_proto_msgTypes = make([]protoimpl.MessageInfo, 48)
 var file_pkg_server_service_proto_goTypes = []interface{}{
 	(JumperType)(0),                     // 0: manifest.JumperType
-	(*Status)(nil),                      // 1: manifest.Status
-	(*Options)(nil),                     // 2: manifest.Options
-	(*JumprunOrigin)(nil),               // 3: manifest.JumprunOrigin
-	(*JumprunTurn)(nil),                 // 4: manifest.JumprunTurn
-	(*JumprunPath)(nil),                 // 5: manifest.JumprunPath
-	(*Jumprun)(nil),                     // 6: manifest.Jumprun
-	(*WindsAloftSample)(nil),            // 7: manifest.WindsAloftSample
-	(*WindsAloft)(nil),                  // 8: manifest.WindsAloft
-	(*Jumper)(nil),                      // 9: manifest.Jumper
-	(*JumperGroup)(nil),                 // 10: manifest.JumperGroup
-	(*LoadSlot)(nil),                    // 11: manifest.LoadSlot
-	(*Load)(nil),                        // 12: manifest.Load
-	(*Loads)(nil),                       // 13: manifest.Loads
-	(*ManifestUpdate)(nil),              // 14: manifest.ManifestUpdate
-	(*SignInWithAppleRequest)(nil),      // 15: manifest.SignInWithAppleRequest
-	(*SignInResponse)(nil),              // 16: manifest.SignInResponse
-	(*SignOutRequest)(nil),              // 17: manifest.SignOutRequest
-	(*SignOutResponse)(nil),             // 18: manifest.SignOutResponse
-	(*VerifySessionRequest)(nil),        // 19: manifest.VerifySessionRequest
-	(*ToggleFuelRequestedRequest)(nil),  // 20: manifest.ToggleFuelRequestedRequest
-	(*ToggleFuelRequestedResponse)(nil), // 21: manifest.ToggleFuelRequestedResponse
-	(*RestartServerRequest)(nil),        // 22: manifest.RestartServerRequest
-	(*RestartServerResponse)(nil),       // 23: manifest.RestartServerResponse
-	(*emptypb.Empty)(nil),               // 24: google.protobuf.Empty
+	(StudentProgram)(0),                 // 1: manifest.StudentProgram
+	(ManifestEventType)(0),              // 2: manifest.ManifestEventType
+	(UpdateSection)(0),                  // 3: manifest.UpdateSection
+	(Units)(0),                          // 4: manifest.Units
+	(*Status)(nil),                      // 5: manifest.Status
+	(*Options)(nil),                     // 6: manifest.Options
+	(*JumprunOrigin)(nil),               // 7: manifest.JumprunOrigin
+	(*JumprunTurn)(nil),                 // 8: manifest.JumprunTurn
+	(*JumprunPath)(nil),                 // 9: manifest.JumprunPath
+	(*Jumprun)(nil),                     // 10: manifest.Jumprun
+	(*WindsAloftSample)(nil),            // 11: manifest.WindsAloftSample
+	(*WindsAloft)(nil),                  // 12: manifest.WindsAloft
+	(*Jumper)(nil),                      // 13: manifest.Jumper
+	(*JumperGroup)(nil),                 // 14: manifest.JumperGroup
+	(*LoadSlot)(nil),                    // 15: manifest.LoadSlot
+	(*Load)(nil),                        // 16: manifest.Load
+	(*Aircraft)(nil),                    // 17: manifest.Aircraft
+	(*Loads)(nil),                       // 18: manifest.Loads
+	(*FuelRequest)(nil),                 // 19: manifest.FuelRequest
+	(*FuelRequests)(nil),                // 20: manifest.FuelRequests
+	(*DataSourceHealth)(nil),            // 21: manifest.DataSourceHealth
+	(*SourceHealth)(nil),                // 22: manifest.SourceHealth
+	(*ManifestUpdate)(nil),              // 23: manifest.ManifestUpdate
+	(*SignInWithAppleRequest)(nil),      // 24: manifest.SignInWithAppleRequest
+	(*SignInResponse)(nil),              // 25: manifest.SignInResponse
+	(*SignOutRequest)(nil),              // 26: manifest.SignOutRequest
+	(*SignOutResponse)(nil),             // 27: manifest.SignOutResponse
+	(*VerifySessionRequest)(nil),        // 28: manifest.VerifySessionRequest
+	(*ToggleFuelRequestedRequest)(nil),  // 29: manifest.ToggleFuelRequestedRequest
+	(*ToggleFuelRequestedResponse)(nil), // 30: manifest.ToggleFuelRequestedResponse
+	(*RequestFuelRequest)(nil),          // 31: manifest.RequestFuelRequest
+	(*UpdateFuelRequestRequest)(nil),    // 32: manifest.UpdateFuelRequestRequest
+	(*FuelRequestResponse)(nil),         // 33: manifest.FuelRequestResponse
+	(*RestartServerRequest)(nil),        // 34: manifest.RestartServerRequest
+	(*RestartServerResponse)(nil),       // 35: manifest.RestartServerResponse
+	(*LoadHistoryRequest)(nil),          // 36: manifest.LoadHistoryRequest
+	(*LoadHistoryJumper)(nil),           // 37: manifest.LoadHistoryJumper
+	(*LoadHistoryCallTime)(nil),         // 38: manifest.LoadHistoryCallTime
+	(*LoadHistory)(nil),                 // 39: manifest.LoadHistory
+	(*LoadHistoryResponse)(nil),         // 40: manifest.LoadHistoryResponse
+	(*ManifestEvent)(nil),               // 41: manifest.ManifestEvent
+	(*Dropzone)(nil),                    // 42: manifest.Dropzone
+	(*ListDropzonesResponse)(nil),       // 43: manifest.ListDropzonesResponse
+	(*Announcement)(nil),                // 44: manifest.Announcement
+	(*ResumeToken)(nil),                 // 45: manifest.ResumeToken
+	(*UpdateSubscription)(nil),          // 46: manifest.UpdateSubscription
+	(*GetManifestRequest)(nil),          // 47: manifest.GetManifestRequest
+	(*SectionTime)(nil),                 // 48: manifest.SectionTime
+	(*GetManifestResponse)(nil),         // 49: manifest.GetManifestResponse
+	(*UpdateClient)(nil),                // 50: manifest.UpdateClient
+	(*ListUpdateClientsRequest)(nil),    // 51: manifest.ListUpdateClientsRequest
+	(*ListUpdateClientsResponse)(nil),   // 52: manifest.ListUpdateClientsResponse
+	(*emptypb.Empty)(nil),               // 53: google.protobuf.Empty
 }
 var file_pkg_server_service_proto_depIdxs = []int32{
-	4,  // 0: manifest.JumprunPath.turns:type_name -> manifest.JumprunTurn
-	3,  // 1: manifest.Jumprun.origin:type_name -> manifest.JumprunOrigin
-	5,  // 2: manifest.Jumprun.path:type_name -> manifest.JumprunPath
-	7,  // 3: manifest.WindsAloft.samples:type_name -> manifest.WindsAloftSample
+	8,  // 0: manifest.JumprunPath.turns:type_name -> manifest.JumprunTurn
+	7,  // 1: manifest.Jumprun.origin:type_name -> manifest.JumprunOrigin
+	9,  // 2: manifest.Jumprun.path:type_name -> manifest.JumprunPath
+	11, // 3: manifest.WindsAloft.samples:type_name -> manifest.WindsAloftSample
 	0,  // 4: manifest.Jumper.type:type_name -> manifest.JumperType
-	9,  // 5: manifest.JumperGroup.leader:type_name -> manifest.Jumper
-	9,  // 6: manifest.JumperGroup.members:type_name -> manifest.Jumper
-	9,  // 7: manifest.LoadSlot.jumper:type_name -> manifest.Jumper
-	10, // 8: manifest.LoadSlot.group:type_name -> manifest.JumperGroup
-	11, // 9: manifest.Load.slots:type_name -> manifest.LoadSlot
-	12, // 10: manifest.Loads.loads:type_name -> manifest.Load
-	1,  // 11: manifest.ManifestUpdate.status:type_name -> manifest.Status
-	2,  // 12: manifest.ManifestUpdate.options:type_name -> manifest.Options
-	6,  // 13: manifest.ManifestUpdate.jumprun:type_name -> manifest.Jumprun
-	8,  // 14: manifest.ManifestUpdate.winds_aloft:type_name -> manifest.WindsAloft
-	13, // 15: manifest.ManifestUpdate.loads:type_name -> manifest.Loads
-	24, // 16: manifest.ManifestService.StreamUpdates:input_type -> google.protobuf.Empty
-	15, // 17: manifest.ManifestService.SignInWithApple:input_type -> manifest.SignInWithAppleRequest
-	17, // 18: manifest.ManifestService.SignOut:input_type -> manifest.SignOutRequest
-	19, // 19: manifest.ManifestService.VerifySessionID:input_type -> manifest.VerifySessionRequest
-	20, // 20: manifest.ManifestService.ToggleFuelRequested:input_type -> manifest.ToggleFuelRequestedRequest
-	22, // 21: manifest.ManifestService.RestartServer:input_type -> manifest.RestartServerRequest
-	14, // 22: manifest.ManifestService.StreamUpdates:output_type -> manifest.ManifestUpdate
-	16, // 23: manifest.ManifestService.SignInWithApple:output_type -> manifest.SignInResponse
-	18, // 24: manifest.ManifestService.SignOut:output_type -> manifest.SignOutResponse
-	16, // 25: manifest.ManifestService.VerifySessionID:output_type -> manifest.SignInResponse
-	21, // 26: manifest.ManifestService.ToggleFuelRequested:output_type -> manifest.ToggleFuelRequestedResponse
-	23, // 27: manifest.ManifestService.RestartServer:output_type -> manifest.RestartServerResponse
-	22, // [22:28] is the sub-list for method output_type
-	16, // [16:22] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	1,  // 5: manifest.Jumper.student_program:type_name -> manifest.StudentProgram
+	13, // 6: manifest.JumperGroup.leader:type_name -> manifest.Jumper
+	13, // 7: manifest.JumperGroup.members:type_name -> manifest.Jumper
+	13, // 8: manifest.LoadSlot.jumper:type_name -> manifest.Jumper
+	14, // 9: manifest.LoadSlot.group:type_name -> manifest.JumperGroup
+	15, // 10: manifest.Load.slots:type_name -> manifest.LoadSlot
+	16, // 11: manifest.Loads.loads:type_name -> manifest.Load
+	17, // 12: manifest.Loads.aircraft:type_name -> manifest.Aircraft
+	19, // 13: manifest.FuelRequests.requests:type_name -> manifest.FuelRequest
+	21, // 14: manifest.SourceHealth.sources:type_name -> manifest.DataSourceHealth
+	5,  // 15: manifest.ManifestUpdate.status:type_name -> manifest.Status
+	6,  // 16: manifest.ManifestUpdate.options:type_name -> manifest.Options
+	10, // 17: manifest.ManifestUpdate.jumprun:type_name -> manifest.Jumprun
+	12, // 18: manifest.ManifestUpdate.winds_aloft:type_name -> manifest.WindsAloft
+	18, // 19: manifest.ManifestUpdate.loads:type_name -> manifest.Loads
+	20, // 20: manifest.ManifestUpdate.fuel_requests:type_name -> manifest.FuelRequests
+	22, // 21: manifest.ManifestUpdate.source_health:type_name -> manifest.SourceHealth
+	19, // 22: manifest.FuelRequestResponse.request:type_name -> manifest.FuelRequest
+	37, // 23: manifest.LoadHistory.jumpers:type_name -> manifest.LoadHistoryJumper
+	38, // 24: manifest.LoadHistory.call_times:type_name -> manifest.LoadHistoryCallTime
+	39, // 25: manifest.LoadHistoryResponse.loads:type_name -> manifest.LoadHistory
+	2,  // 26: manifest.ManifestEvent.type:type_name -> manifest.ManifestEventType
+	42, // 27: manifest.ListDropzonesResponse.dropzones:type_name -> manifest.Dropzone
+	3,  // 28: manifest.UpdateSubscription.sections:type_name -> manifest.UpdateSection
+	4,  // 29: manifest.UpdateSubscription.units:type_name -> manifest.Units
+	45, // 30: manifest.UpdateSubscription.resume:type_name -> manifest.ResumeToken
+	3,  // 31: manifest.GetManifestRequest.sections:type_name -> manifest.UpdateSection
+	3,  // 32: manifest.SectionTime.section:type_name -> manifest.UpdateSection
+	23, // 33: manifest.GetManifestResponse.update:type_name -> manifest.ManifestUpdate
+	48, // 34: manifest.GetManifestResponse.section_times:type_name -> manifest.SectionTime
+	50, // 35: manifest.ListUpdateClientsResponse.clients:type_name -> manifest.UpdateClient
+	53, // 36: manifest.ManifestService.StreamUpdates:input_type -> google.protobuf.Empty
+	46, // 37: manifest.ManifestService.SubscribeUpdates:input_type -> manifest.UpdateSubscription
+	47, // 38: manifest.ManifestService.GetManifest:input_type -> manifest.GetManifestRequest
+	24, // 39: manifest.ManifestService.SignInWithApple:input_type -> manifest.SignInWithAppleRequest
+	26, // 40: manifest.ManifestService.SignOut:input_type -> manifest.SignOutRequest
+	28, // 41: manifest.ManifestService.VerifySessionID:input_type -> manifest.VerifySessionRequest
+	29, // 42: manifest.ManifestService.ToggleFuelRequested:input_type -> manifest.ToggleFuelRequestedRequest
+	34, // 43: manifest.ManifestService.RestartServer:input_type -> manifest.RestartServerRequest
+	31, // 44: manifest.ManifestService.RequestFuel:input_type -> manifest.RequestFuelRequest
+	32, // 45: manifest.ManifestService.AcknowledgeFuelRequest:input_type -> manifest.UpdateFuelRequestRequest
+	32, // 46: manifest.ManifestService.CompleteFuelRequest:input_type -> manifest.UpdateFuelRequestRequest
+	36, // 47: manifest.ManifestService.QueryLoadHistory:input_type -> manifest.LoadHistoryRequest
+	53, // 48: manifest.ManifestService.StreamEvents:input_type -> google.protobuf.Empty
+	53, // 49: manifest.ManifestService.StreamAnnouncements:input_type -> google.protobuf.Empty
+	53, // 50: manifest.ManifestService.ListDropzones:input_type -> google.protobuf.Empty
+	51, // 51: manifest.ManifestService.ListUpdateClients:input_type -> manifest.ListUpdateClientsRequest
+	23, // 52: manifest.ManifestService.StreamUpdates:output_type -> manifest.ManifestUpdate
+	23, // 53: manifest.ManifestService.SubscribeUpdates:output_type -> manifest.ManifestUpdate
+	49, // 54: manifest.ManifestService.GetManifest:output_type -> manifest.GetManifestResponse
+	25, // 55: manifest.ManifestService.SignInWithApple:output_type -> manifest.SignInResponse
+	27, // 56: manifest.ManifestService.SignOut:output_type -> manifest.SignOutResponse
+	25, // 57: manifest.ManifestService.VerifySessionID:output_type -> manifest.SignInResponse
+	30, // 58: manifest.ManifestService.ToggleFuelRequested:output_type -> manifest.ToggleFuelRequestedResponse
+	35, // 59: manifest.ManifestService.RestartServer:output_type -> manifest.RestartServerResponse
+	33, // 60: manifest.ManifestService.RequestFuel:output_type -> manifest.FuelRequestResponse
+	33, // 61: manifest.ManifestService.AcknowledgeFuelRequest:output_type -> manifest.FuelRequestResponse
+	33, // 62: manifest.ManifestService.CompleteFuelRequest:output_type -> manifest.FuelRequestResponse
+	40, // 63: manifest.ManifestService.QueryLoadHistory:output_type -> manifest.LoadHistoryResponse
+	41, // 64: manifest.ManifestService.StreamEvents:output_type -> manifest.ManifestEvent
+	44, // 65: manifest.ManifestService.StreamAnnouncements:output_type -> manifest.Announcement
+	43, // 66: manifest.ManifestService.ListDropzones:output_type -> manifest.ListDropzonesResponse
+	52, // 67: manifest.ManifestService.ListUpdateClients:output_type -> manifest.ListUpdateClientsResponse
+	52, // [52:68] is the sub-list for method output_type
+	36, // [36:52] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
 }
 
 func init() { file_pkg_server_service_proto_init() }
@@ -2063,8 +4716,92 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_server_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Options); i {
+		file_pkg_server_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Options); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JumprunOrigin); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JumprunTurn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JumprunPath); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Jumprun); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WindsAloftSample); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WindsAloft); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Jumper); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2075,8 +4812,8 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_server_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JumprunOrigin); i {
+		file_pkg_server_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JumperGroup); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2087,8 +4824,8 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_server_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JumprunTurn); i {
+		file_pkg_server_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoadSlot); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2099,8 +4836,8 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_server_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JumprunPath); i {
+		file_pkg_server_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Load); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2111,8 +4848,8 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_server_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Jumprun); i {
+		file_pkg_server_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Aircraft); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2123,8 +4860,8 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_server_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WindsAloftSample); i {
+		file_pkg_server_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Loads); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2135,8 +4872,8 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_server_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WindsAloft); i {
+		file_pkg_server_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FuelRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2147,8 +4884,8 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_server_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Jumper); i {
+		file_pkg_server_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FuelRequests); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2159,8 +4896,8 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_server_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JumperGroup); i {
+		file_pkg_server_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataSourceHealth); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2171,8 +4908,8 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_server_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LoadSlot); i {
+		file_pkg_server_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SourceHealth); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2183,8 +4920,8 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_server_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Load); i {
+		file_pkg_server_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ManifestUpdate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2195,8 +4932,8 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_server_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Loads); i {
+		file_pkg_server_service_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignInWithAppleRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2207,8 +4944,8 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_server_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ManifestUpdate); i {
+		file_pkg_server_service_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignInResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2219,8 +4956,8 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_server_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignInWithAppleRequest); i {
+		file_pkg_server_service_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignOutRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2231,8 +4968,8 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_server_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignInResponse); i {
+		file_pkg_server_service_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignOutResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2243,8 +4980,8 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_server_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignOutRequest); i {
+		file_pkg_server_service_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifySessionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2255,8 +4992,8 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_server_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignOutResponse); i {
+		file_pkg_server_service_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ToggleFuelRequestedRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2267,8 +5004,8 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_server_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifySessionRequest); i {
+		file_pkg_server_service_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ToggleFuelRequestedResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2279,8 +5016,8 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_server_service_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ToggleFuelRequestedRequest); i {
+		file_pkg_server_service_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestFuelRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2291,8 +5028,8 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_server_service_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ToggleFuelRequestedResponse); i {
+		file_pkg_server_service_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateFuelRequestRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2303,7 +5040,19 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_server_service_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+		file_pkg_server_service_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FuelRequestResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RestartServerRequest); i {
 			case 0:
 				return &v.state
@@ -2315,7 +5064,7 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_server_service_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+		file_pkg_server_service_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RestartServerResponse); i {
 			case 0:
 				return &v.state
@@ -2327,20 +5076,224 @@ func file_pkg_server_service_proto_init() {
 				return nil
 			}
 		}
+		file_pkg_server_service_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoadHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoadHistoryJumper); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoadHistoryCallTime); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoadHistory); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoadHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ManifestEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Dropzone); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDropzonesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Announcement); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResumeToken); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateSubscription); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetManifestRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SectionTime); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetManifestResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateClient); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListUpdateClientsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_service_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListUpdateClientsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_pkg_server_service_proto_msgTypes[5].OneofWrappers = []interface{}{}
 	file_pkg_server_service_proto_msgTypes[10].OneofWrappers = []interface{}{
 		(*LoadSlot_Jumper)(nil),
 		(*LoadSlot_Group)(nil),
 	}
-	file_pkg_server_service_proto_msgTypes[13].OneofWrappers = []interface{}{}
+	file_pkg_server_service_proto_msgTypes[18].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_server_service_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   23,
+			NumEnums:      5,
+			NumMessages:   48,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,11 +20,21 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ManifestServiceClient interface {
 	StreamUpdates(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (ManifestService_StreamUpdatesClient, error)
+	SubscribeUpdates(ctx context.Context, in *UpdateSubscription, opts ...grpc.CallOption) (ManifestService_SubscribeUpdatesClient, error)
+	GetManifest(ctx context.Context, in *GetManifestRequest, opts ...grpc.CallOption) (*GetManifestResponse, error)
 	SignInWithApple(ctx context.Context, in *SignInWithAppleRequest, opts ...grpc.CallOption) (*SignInResponse, error)
 	SignOut(ctx context.Context, in *SignOutRequest, opts ...grpc.CallOption) (*SignOutResponse, error)
 	VerifySessionID(ctx context.Context, in *VerifySessionRequest, opts ...grpc.CallOption) (*SignInResponse, error)
 	ToggleFuelRequested(ctx context.Context, in *ToggleFuelRequestedRequest, opts ...grpc.CallOption) (*ToggleFuelRequestedResponse, error)
 	RestartServer(ctx context.Context, in *RestartServerRequest, opts ...grpc.CallOption) (*RestartServerResponse, error)
+	RequestFuel(ctx context.Context, in *RequestFuelRequest, opts ...grpc.CallOption) (*FuelRequestResponse, error)
+	AcknowledgeFuelRequest(ctx context.Context, in *UpdateFuelRequestRequest, opts ...grpc.CallOption) (*FuelRequestResponse, error)
+	CompleteFuelRequest(ctx context.Context, in *UpdateFuelRequestRequest, opts ...grpc.CallOption) (*FuelRequestResponse, error)
+	QueryLoadHistory(ctx context.Context, in *LoadHistoryRequest, opts ...grpc.CallOption) (*LoadHistoryResponse, error)
+	StreamEvents(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (ManifestService_StreamEventsClient, error)
+	StreamAnnouncements(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (ManifestService_StreamAnnouncementsClient, error)
+	ListDropzones(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListDropzonesResponse, error)
+	ListUpdateClients(ctx context.Context, in *ListUpdateClientsRequest, opts ...grpc.CallOption) (*ListUpdateClientsResponse, error)
 }
 
 type manifestServiceClient struct {
@@ -67,6 +77,47 @@ func (x *manifestServiceStreamUpdatesClient) Recv() (*ManifestUpdate, error) {
 	return m, nil
 }
 
+func (c *manifestServiceClient) SubscribeUpdates(ctx context.Context, in *UpdateSubscription, opts ...grpc.CallOption) (ManifestService_SubscribeUpdatesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ManifestService_ServiceDesc.Streams[1], "/manifest.ManifestService/SubscribeUpdates", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &manifestServiceSubscribeUpdatesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ManifestService_SubscribeUpdatesClient interface {
+	Recv() (*ManifestUpdate, error)
+	grpc.ClientStream
+}
+
+type manifestServiceSubscribeUpdatesClient struct {
+	grpc.ClientStream
+}
+
+func (x *manifestServiceSubscribeUpdatesClient) Recv() (*ManifestUpdate, error) {
+	m := new(ManifestUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *manifestServiceClient) GetManifest(ctx context.Context, in *GetManifestRequest, opts ...grpc.CallOption) (*GetManifestResponse, error) {
+	out := new(GetManifestResponse)
+	err := c.cc.Invoke(ctx, "/manifest.ManifestService/GetManifest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *manifestServiceClient) SignInWithApple(ctx context.Context, in *SignInWithAppleRequest, opts ...grpc.CallOption) (*SignInResponse, error) {
 	out := new(SignInResponse)
 	err := c.cc.Invoke(ctx, "/manifest.ManifestService/SignInWithApple", in, out, opts...)
@@ -112,16 +163,144 @@ func (c *manifestServiceClient) RestartServer(ctx context.Context, in *RestartSe
 	return out, nil
 }
 
+func (c *manifestServiceClient) RequestFuel(ctx context.Context, in *RequestFuelRequest, opts ...grpc.CallOption) (*FuelRequestResponse, error) {
+	out := new(FuelRequestResponse)
+	err := c.cc.Invoke(ctx, "/manifest.ManifestService/RequestFuel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *manifestServiceClient) AcknowledgeFuelRequest(ctx context.Context, in *UpdateFuelRequestRequest, opts ...grpc.CallOption) (*FuelRequestResponse, error) {
+	out := new(FuelRequestResponse)
+	err := c.cc.Invoke(ctx, "/manifest.ManifestService/AcknowledgeFuelRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *manifestServiceClient) CompleteFuelRequest(ctx context.Context, in *UpdateFuelRequestRequest, opts ...grpc.CallOption) (*FuelRequestResponse, error) {
+	out := new(FuelRequestResponse)
+	err := c.cc.Invoke(ctx, "/manifest.ManifestService/CompleteFuelRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *manifestServiceClient) QueryLoadHistory(ctx context.Context, in *LoadHistoryRequest, opts ...grpc.CallOption) (*LoadHistoryResponse, error) {
+	out := new(LoadHistoryResponse)
+	err := c.cc.Invoke(ctx, "/manifest.ManifestService/QueryLoadHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *manifestServiceClient) StreamEvents(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (ManifestService_StreamEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ManifestService_ServiceDesc.Streams[2], "/manifest.ManifestService/StreamEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &manifestServiceStreamEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ManifestService_StreamEventsClient interface {
+	Recv() (*ManifestEvent, error)
+	grpc.ClientStream
+}
+
+type manifestServiceStreamEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *manifestServiceStreamEventsClient) Recv() (*ManifestEvent, error) {
+	m := new(ManifestEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *manifestServiceClient) StreamAnnouncements(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (ManifestService_StreamAnnouncementsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ManifestService_ServiceDesc.Streams[3], "/manifest.ManifestService/StreamAnnouncements", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &manifestServiceStreamAnnouncementsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ManifestService_StreamAnnouncementsClient interface {
+	Recv() (*Announcement, error)
+	grpc.ClientStream
+}
+
+type manifestServiceStreamAnnouncementsClient struct {
+	grpc.ClientStream
+}
+
+func (x *manifestServiceStreamAnnouncementsClient) Recv() (*Announcement, error) {
+	m := new(Announcement)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *manifestServiceClient) ListDropzones(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListDropzonesResponse, error) {
+	out := new(ListDropzonesResponse)
+	err := c.cc.Invoke(ctx, "/manifest.ManifestService/ListDropzones", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *manifestServiceClient) ListUpdateClients(ctx context.Context, in *ListUpdateClientsRequest, opts ...grpc.CallOption) (*ListUpdateClientsResponse, error) {
+	out := new(ListUpdateClientsResponse)
+	err := c.cc.Invoke(ctx, "/manifest.ManifestService/ListUpdateClients", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ManifestServiceServer is the server API for ManifestService service.
 // All implementations must embed UnimplementedManifestServiceServer
 // for forward compatibility
 type ManifestServiceServer interface {
 	StreamUpdates(*emptypb.Empty, ManifestService_StreamUpdatesServer) error
+	SubscribeUpdates(*UpdateSubscription, ManifestService_SubscribeUpdatesServer) error
+	GetManifest(context.Context, *GetManifestRequest) (*GetManifestResponse, error)
 	SignInWithApple(context.Context, *SignInWithAppleRequest) (*SignInResponse, error)
 	SignOut(context.Context, *SignOutRequest) (*SignOutResponse, error)
 	VerifySessionID(context.Context, *VerifySessionRequest) (*SignInResponse, error)
 	ToggleFuelRequested(context.Context, *ToggleFuelRequestedRequest) (*ToggleFuelRequestedResponse, error)
 	RestartServer(context.Context, *RestartServerRequest) (*RestartServerResponse, error)
+	RequestFuel(context.Context, *RequestFuelRequest) (*FuelRequestResponse, error)
+	AcknowledgeFuelRequest(context.Context, *UpdateFuelRequestRequest) (*FuelRequestResponse, error)
+	CompleteFuelRequest(context.Context, *UpdateFuelRequestRequest) (*FuelRequestResponse, error)
+	QueryLoadHistory(context.Context, *LoadHistoryRequest) (*LoadHistoryResponse, error)
+	StreamEvents(*emptypb.Empty, ManifestService_StreamEventsServer) error
+	StreamAnnouncements(*emptypb.Empty, ManifestService_StreamAnnouncementsServer) error
+	ListDropzones(context.Context, *emptypb.Empty) (*ListDropzonesResponse, error)
+	ListUpdateClients(context.Context, *ListUpdateClientsRequest) (*ListUpdateClientsResponse, error)
 	mustEmbedUnimplementedManifestServiceServer()
 }
 
@@ -132,6 +311,12 @@ type UnimplementedManifestServiceServer struct {
 func (UnimplementedManifestServiceServer) StreamUpdates(*emptypb.Empty, ManifestService_StreamUpdatesServer) error {
 	return status.Errorf(codes.Unimplemented, "method StreamUpdates not implemented")
 }
+func (UnimplementedManifestServiceServer) SubscribeUpdates(*UpdateSubscription, ManifestService_SubscribeUpdatesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeUpdates not implemented")
+}
+func (UnimplementedManifestServiceServer) GetManifest(context.Context, *GetManifestRequest) (*GetManifestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetManifest not implemented")
+}
 func (UnimplementedManifestServiceServer) SignInWithApple(context.Context, *SignInWithAppleRequest) (*SignInResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SignInWithApple not implemented")
 }
@@ -147,6 +332,30 @@ func (UnimplementedManifestServiceServer) ToggleFuelRequested(context.Context, *
 func (UnimplementedManifestServiceServer) RestartServer(context.Context, *RestartServerRequest) (*RestartServerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RestartServer not implemented")
 }
+func (UnimplementedManifestServiceServer) RequestFuel(context.Context, *RequestFuelRequest) (*FuelRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestFuel not implemented")
+}
+func (UnimplementedManifestServiceServer) AcknowledgeFuelRequest(context.Context, *UpdateFuelRequestRequest) (*FuelRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcknowledgeFuelRequest not implemented")
+}
+func (UnimplementedManifestServiceServer) CompleteFuelRequest(context.Context, *UpdateFuelRequestRequest) (*FuelRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteFuelRequest not implemented")
+}
+func (UnimplementedManifestServiceServer) QueryLoadHistory(context.Context, *LoadHistoryRequest) (*LoadHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryLoadHistory not implemented")
+}
+func (UnimplementedManifestServiceServer) StreamEvents(*emptypb.Empty, ManifestService_StreamEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEvents not implemented")
+}
+func (UnimplementedManifestServiceServer) StreamAnnouncements(*emptypb.Empty, ManifestService_StreamAnnouncementsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamAnnouncements not implemented")
+}
+func (UnimplementedManifestServiceServer) ListDropzones(context.Context, *emptypb.Empty) (*ListDropzonesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDropzones not implemented")
+}
+func (UnimplementedManifestServiceServer) ListUpdateClients(context.Context, *ListUpdateClientsRequest) (*ListUpdateClientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUpdateClients not implemented")
+}
 func (UnimplementedManifestServiceServer) mustEmbedUnimplementedManifestServiceServer() {}
 
 // UnsafeManifestServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -181,6 +390,45 @@ func (x *manifestServiceStreamUpdatesServer) Send(m *ManifestUpdate) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _ManifestService_SubscribeUpdates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UpdateSubscription)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ManifestServiceServer).SubscribeUpdates(m, &manifestServiceSubscribeUpdatesServer{stream})
+}
+
+type ManifestService_SubscribeUpdatesServer interface {
+	Send(*ManifestUpdate) error
+	grpc.ServerStream
+}
+
+type manifestServiceSubscribeUpdatesServer struct {
+	grpc.ServerStream
+}
+
+func (x *manifestServiceSubscribeUpdatesServer) Send(m *ManifestUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ManifestService_GetManifest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetManifestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManifestServiceServer).GetManifest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/manifest.ManifestService/GetManifest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManifestServiceServer).GetManifest(ctx, req.(*GetManifestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ManifestService_SignInWithApple_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SignInWithAppleRequest)
 	if err := dec(in); err != nil {
@@ -271,6 +519,156 @@ func _ManifestService_RestartServer_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ManifestService_RequestFuel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestFuelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManifestServiceServer).RequestFuel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/manifest.ManifestService/RequestFuel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManifestServiceServer).RequestFuel(ctx, req.(*RequestFuelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManifestService_AcknowledgeFuelRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFuelRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManifestServiceServer).AcknowledgeFuelRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/manifest.ManifestService/AcknowledgeFuelRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManifestServiceServer).AcknowledgeFuelRequest(ctx, req.(*UpdateFuelRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManifestService_CompleteFuelRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFuelRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManifestServiceServer).CompleteFuelRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/manifest.ManifestService/CompleteFuelRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManifestServiceServer).CompleteFuelRequest(ctx, req.(*UpdateFuelRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManifestService_QueryLoadHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManifestServiceServer).QueryLoadHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/manifest.ManifestService/QueryLoadHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManifestServiceServer).QueryLoadHistory(ctx, req.(*LoadHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManifestService_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ManifestServiceServer).StreamEvents(m, &manifestServiceStreamEventsServer{stream})
+}
+
+type ManifestService_StreamEventsServer interface {
+	Send(*ManifestEvent) error
+	grpc.ServerStream
+}
+
+type manifestServiceStreamEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *manifestServiceStreamEventsServer) Send(m *ManifestEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ManifestService_StreamAnnouncements_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ManifestServiceServer).StreamAnnouncements(m, &manifestServiceStreamAnnouncementsServer{stream})
+}
+
+type ManifestService_StreamAnnouncementsServer interface {
+	Send(*Announcement) error
+	grpc.ServerStream
+}
+
+type manifestServiceStreamAnnouncementsServer struct {
+	grpc.ServerStream
+}
+
+func (x *manifestServiceStreamAnnouncementsServer) Send(m *Announcement) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ManifestService_ListDropzones_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManifestServiceServer).ListDropzones(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/manifest.ManifestService/ListDropzones",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManifestServiceServer).ListDropzones(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManifestService_ListUpdateClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUpdateClientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManifestServiceServer).ListUpdateClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/manifest.ManifestService/ListUpdateClients",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManifestServiceServer).ListUpdateClients(ctx, req.(*ListUpdateClientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ManifestService_ServiceDesc is the grpc.ServiceDesc for ManifestService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -278,6 +676,10 @@ var ManifestService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "manifest.ManifestService",
 	HandlerType: (*ManifestServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetManifest",
+			Handler:    _ManifestService_GetManifest_Handler,
+		},
 		{
 			MethodName: "SignInWithApple",
 			Handler:    _ManifestService_SignInWithApple_Handler,
@@ -298,6 +700,30 @@ var ManifestService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RestartServer",
 			Handler:    _ManifestService_RestartServer_Handler,
 		},
+		{
+			MethodName: "RequestFuel",
+			Handler:    _ManifestService_RequestFuel_Handler,
+		},
+		{
+			MethodName: "AcknowledgeFuelRequest",
+			Handler:    _ManifestService_AcknowledgeFuelRequest_Handler,
+		},
+		{
+			MethodName: "CompleteFuelRequest",
+			Handler:    _ManifestService_CompleteFuelRequest_Handler,
+		},
+		{
+			MethodName: "QueryLoadHistory",
+			Handler:    _ManifestService_QueryLoadHistory_Handler,
+		},
+		{
+			MethodName: "ListDropzones",
+			Handler:    _ManifestService_ListDropzones_Handler,
+		},
+		{
+			MethodName: "ListUpdateClients",
+			Handler:    _ManifestService_ListUpdateClients_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -305,6 +731,21 @@ var ManifestService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _ManifestService_StreamUpdates_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SubscribeUpdates",
+			Handler:       _ManifestService_SubscribeUpdates_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamEvents",
+			Handler:       _ManifestService_StreamEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamAnnouncements",
+			Handler:       _ManifestService_StreamAnnouncements_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "pkg/server/service.proto",
 }
@@ -0,0 +1,315 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import (
+	"context"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/burble"
+	"github.com/jumptown-skydiving/manifest-server/pkg/core"
+	"github.com/jumptown-skydiving/manifest-server/pkg/metar"
+	"github.com/jumptown-skydiving/manifest-server/pkg/settings"
+)
+
+// The data sources from which each section of a ManifestUpdate is
+// constructed.
+const (
+	sunriseSources    = core.PreSunriseDataSource | core.SunriseDataSource
+	sunsetSources     = core.PreSunsetDataSource | core.SunsetDataSource
+	optionsSources    = core.OptionsDataSource | core.FuelDataSource | sunriseSources | sunsetSources
+	statusSources     = core.METARDataSource | core.WindsAloftDataSource | core.HealthDataSource
+	jumprunSources    = core.JumprunDataSource
+	windsAloftSources = core.WindsAloftDataSource
+	fuelSources       = core.FuelDataSource
+	healthSources     = core.HealthDataSource
+	loadsSources      = core.BurbleDataSource | core.OptionsDataSource
+)
+
+var sectionSources = map[UpdateSection]core.DataSource{
+	UpdateSection_SECTION_STATUS:        statusSources,
+	UpdateSection_SECTION_OPTIONS:       optionsSources,
+	UpdateSection_SECTION_JUMPRUN:       jumprunSources,
+	UpdateSection_SECTION_WINDS_ALOFT:   windsAloftSources,
+	UpdateSection_SECTION_LOADS:         loadsSources,
+	UpdateSection_SECTION_FUEL_REQUESTS: fuelSources,
+	UpdateSection_SECTION_SOURCE_HEALTH: healthSources,
+}
+
+// sections returns the sections that are set in the update.
+func (x *ManifestUpdate) sections() []UpdateSection {
+	var sections []UpdateSection
+	if x.Status != nil {
+		sections = append(sections, UpdateSection_SECTION_STATUS)
+	}
+	if x.Options != nil {
+		sections = append(sections, UpdateSection_SECTION_OPTIONS)
+	}
+	if x.Jumprun != nil {
+		sections = append(sections, UpdateSection_SECTION_JUMPRUN)
+	}
+	if x.WindsAloft != nil {
+		sections = append(sections, UpdateSection_SECTION_WINDS_ALOFT)
+	}
+	if x.Loads != nil {
+		sections = append(sections, UpdateSection_SECTION_LOADS)
+	}
+	if x.FuelRequests != nil {
+		sections = append(sections, UpdateSection_SECTION_FUEL_REQUESTS)
+	}
+	if x.SourceHealth != nil {
+		sections = append(sections, UpdateSection_SECTION_SOURCE_HEALTH)
+	}
+	return sections
+}
+
+var subscriptionUnits = map[Units]metar.Units{
+	Units_UNITS_DEFAULT:  metar.DefaultUnits,
+	Units_UNITS_IMPERIAL: metar.ImperialUnits,
+	Units_UNITS_METRIC:   metar.MetricUnits,
+	Units_UNITS_NAUTICAL: metar.NauticalUnits,
+}
+
+// updateFilter restricts the updates constructed for a SubscribeUpdates
+// client to what it asked for. A nil *updateFilter selects everything.
+type updateFilter struct {
+	sections map[UpdateSection]struct{}
+	aircraft map[string]struct{}
+	maxLoads int
+	units    metar.Units
+}
+
+// newUpdateFilter creates an updateFilter for a subscription. Aircraft are
+// matched by their canonical names so that any name, alias, or tail number
+// may be given.
+func newUpdateFilter(req *UpdateSubscription, settings *settings.Settings) *updateFilter {
+	f := &updateFilter{
+		maxLoads: int(req.MaxLoads),
+		units:    subscriptionUnits[req.Units],
+	}
+	for _, section := range req.Sections {
+		if section == UpdateSection_SECTION_UNSPECIFIED {
+			continue
+		}
+		if f.sections == nil {
+			f.sections = make(map[UpdateSection]struct{})
+		}
+		f.sections[section] = struct{}{}
+	}
+	for _, name := range req.Aircraft {
+		if f.aircraft == nil {
+			f.aircraft = make(map[string]struct{})
+		}
+		f.aircraft[settings.CanonicalAircraftName(name)] = struct{}{}
+	}
+	return f
+}
+
+func (f *updateFilter) wants(section UpdateSection) bool {
+	if f == nil || f.sections == nil {
+		return true
+	}
+	_, ok := f.sections[section]
+	return ok
+}
+
+// wantsAircraft reports whether loads and fuel requests for the named
+// aircraft are wanted. name must be canonical.
+func (f *updateFilter) wantsAircraft(name string) bool {
+	if f == nil || f.aircraft == nil {
+		return true
+	}
+	_, ok := f.aircraft[name]
+	return ok
+}
+
+// topics returns the data sources from which the wanted sections are
+// constructed.
+func (f *updateFilter) topics() core.DataSource {
+	var topics core.DataSource
+	for section, source := range sectionSources {
+		if f.wants(section) {
+			topics |= source
+		}
+	}
+	return topics
+}
+
+func (f *updateFilter) unitsOrDefault() metar.Units {
+	if f == nil {
+		return metar.DefaultUnits
+	}
+	return f.units
+}
+
+// limitLoads returns the wanted loads, no more than maxLoads of them.
+func (f *updateFilter) limitLoads(loads []*burble.Load) []*burble.Load {
+	if f == nil {
+		return loads
+	}
+	var result []*burble.Load
+	for _, l := range loads {
+		if f.maxLoads > 0 && len(result) >= f.maxLoads {
+			break
+		}
+		if f.wantsAircraft(l.AircraftName) {
+			result = append(result, l)
+		}
+	}
+	return result
+}
+
+// limitColumns returns the number of columns in which the wanted loads are
+// displayed.
+func (f *updateFilter) limitColumns(columnCount int) int {
+	if f != nil && f.maxLoads > 0 && f.maxLoads < columnCount {
+		return f.maxLoads
+	}
+	return columnCount
+}
+
+// changedSince returns the sequence of the latest change and the data sources
+// that have changed since the update identified by token. It returns false if
+// the client cannot resume from token and must be sent a complete update.
+func (s *manifestServiceServer) changedSince(token *ResumeToken) (uint64, core.DataSource, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	current := s.lastUpdate.Sequence
+	if token == nil || token.Epoch != s.epoch {
+		return current, 0, false
+	}
+	sources, ok := s.replay.since(token.Sequence, current)
+	return current, sources, ok
+}
+
+// sequence returns the sequence of the latest change.
+func (s *manifestServiceServer) sequence() uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.lastUpdate.Sequence
+}
+
+// restrict removes what is not wanted from a complete update that was
+// constructed without a filter. The units of its human-readable strings
+// cannot be changed, so they remain the defaults.
+func (f *updateFilter) restrict(u *ManifestUpdate, settings *settings.Settings) {
+	if !f.wants(UpdateSection_SECTION_STATUS) {
+		u.Status = nil
+	}
+	if !f.wants(UpdateSection_SECTION_OPTIONS) {
+		u.Options = nil
+	}
+	if !f.wants(UpdateSection_SECTION_JUMPRUN) {
+		u.Jumprun = nil
+	}
+	if !f.wants(UpdateSection_SECTION_WINDS_ALOFT) {
+		u.WindsAloft = nil
+	}
+	if !f.wants(UpdateSection_SECTION_LOADS) {
+		u.Loads = nil
+	}
+	if !f.wants(UpdateSection_SECTION_FUEL_REQUESTS) {
+		u.FuelRequests = nil
+	}
+	if !f.wants(UpdateSection_SECTION_SOURCE_HEALTH) {
+		u.SourceHealth = nil
+	}
+
+	if u.Loads != nil {
+		var aircraft []*Aircraft
+		for _, a := range u.Loads.Aircraft {
+			if f.wantsAircraft(a.Name) {
+				aircraft = append(aircraft, a)
+			}
+		}
+		u.Loads.Aircraft = aircraft
+
+		var loads []*Load
+		for _, l := range u.Loads.Loads {
+			if f.wantsAircraft(settings.CanonicalAircraftName(l.AircraftName)) {
+				loads = append(loads, l)
+			}
+		}
+		u.Loads.Loads = loads
+	}
+	if u.FuelRequests != nil {
+		var requests []*FuelRequest
+		for _, r := range u.FuelRequests.Requests {
+			if f.wantsAircraft(settings.CanonicalAircraftName(r.AircraftName)) {
+				requests = append(requests, r)
+			}
+		}
+		u.FuelRequests.Requests = requests
+	}
+}
+
+// SubscribeUpdates is like StreamUpdates, but sends only the sections, loads,
+// and aircraft that the client subscribes to, with human-readable strings
+// expressed in the requested units. Updates are constructed for each
+// subscriber, so there is no server-wide slow client policy; a slow
+// subscriber simply receives its changes less often.
+//
+// A client that resumes its stream is sent the sections that have changed
+// since the update identified by its resume token instead of a complete
+// update, if the changes are still remembered. Each update's sequence is read
+// before it is constructed, so an update may include changes after its
+// sequence. Resuming may then resend them, but never misses any.
+func (s *manifestServiceServer) SubscribeUpdates(
+	req *UpdateSubscription,
+	stream ManifestService_SubscribeUpdatesServer,
+) error {
+	return s.subscribe(stream.Context(), req, stream.Send)
+}
+
+// subscribe calls send with the updates for a subscription until ctx is done
+// or send returns an error.
+func (s *manifestServiceServer) subscribe(
+	ctx context.Context,
+	req *UpdateSubscription,
+	send func(*ManifestUpdate) error,
+) error {
+	f := newUpdateFilter(req, s.app.Settings())
+
+	// Subscribe before creating the first update so that no changes
+	// are missed.
+	sub := s.app.Subscribe(ctx, f.topics())
+	defer sub.Unsubscribe()
+
+	sequence, sources, resumed := s.changedSince(req.Resume)
+	if !resumed {
+		sources = s.baselineSources()
+	}
+	last := s.constructUpdate(sources, f)
+	last.Sequence = sequence
+	last.Epoch = s.epoch
+	last.IsSnapshot = !resumed
+	if !resumed || last.sources() != 0 {
+		if err := send(last); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.app.Done():
+			return nil
+		case <-sub.Ready():
+			source := sub.Take()
+			if source == 0 {
+				continue
+			}
+			sequence := s.sequence()
+			u := s.constructUpdate(source, f)
+			if !u.diff(last) {
+				continue
+			}
+			u.Sequence = sequence
+			u.Epoch = s.epoch
+			if err := send(u); err != nil {
+				return err
+			}
+			last.merge(u)
+		}
+	}
+}
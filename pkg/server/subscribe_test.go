@@ -0,0 +1,251 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/burble"
+	"github.com/jumptown-skydiving/manifest-server/pkg/core"
+	"github.com/jumptown-skydiving/manifest-server/pkg/metar"
+	"github.com/jumptown-skydiving/manifest-server/pkg/settings"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// testSettings returns settings with an Otter, which is also known as
+// "Twin Otter", and a King Air registered.
+func testSettings(t *testing.T) *settings.Settings {
+	t.Helper()
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "config.yaml")
+	config := "options_file: " + filepath.Join(dir, "options.json") + "\n" +
+		"jumper_rules_file: " + filepath.Join(dir, "jumper_rules.json") + "\n" +
+		"aircraft:\n" +
+		"  - name: Otter\n" +
+		"    aliases: [Twin Otter]\n" +
+		"  - name: King Air\n"
+	if err := os.WriteFile(filename, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s, err := settings.NewSettingsWithFilename(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// testUpdate returns a complete update with loads and fuel requests for
+// each aircraft, named as the manifest names them.
+func testUpdate() *ManifestUpdate {
+	return &ManifestUpdate{
+		Status:     &Status{Winds: "calm"},
+		Options:    &Options{Message: "hello"},
+		Jumprun:    &Jumprun{},
+		WindsAloft: &WindsAloft{},
+		Loads: &Loads{
+			Aircraft: []*Aircraft{{Name: "Otter"}, {Name: "King Air"}},
+			Loads: []*Load{
+				{Id: 1, AircraftName: "Twin Otter"},
+				{Id: 2, AircraftName: "King Air"},
+				{Id: 3, AircraftName: "Otter"},
+			},
+		},
+		FuelRequests: &FuelRequests{Requests: []*FuelRequest{
+			{Id: 1, AircraftName: "King Air"},
+			{Id: 2, AircraftName: "Twin Otter"},
+		}},
+		SourceHealth: &SourceHealth{},
+		Sequence:     1,
+		IsSnapshot:   true,
+	}
+}
+
+func TestUpdateFilterRestrict(t *testing.T) {
+	s := testSettings(t)
+	tests := []struct {
+		name string
+		req  *UpdateSubscription
+		want func(u *ManifestUpdate)
+	}{
+		{
+			name: "everything",
+			req:  &UpdateSubscription{},
+			want: func(*ManifestUpdate) {},
+		},
+		{
+			name: "sections",
+			req: &UpdateSubscription{Sections: []UpdateSection{
+				UpdateSection_SECTION_STATUS,
+				UpdateSection_SECTION_FUEL_REQUESTS,
+				UpdateSection_SECTION_UNSPECIFIED,
+			}},
+			want: func(u *ManifestUpdate) {
+				u.Options, u.Jumprun, u.WindsAloft, u.Loads, u.SourceHealth = nil, nil, nil, nil, nil
+			},
+		},
+		{
+			name: "unspecified section",
+			req:  &UpdateSubscription{Sections: []UpdateSection{UpdateSection_SECTION_UNSPECIFIED}},
+			want: func(*ManifestUpdate) {},
+		},
+		{
+			name: "aircraft",
+			req:  &UpdateSubscription{Aircraft: []string{"otter"}},
+			want: func(u *ManifestUpdate) {
+				u.Loads.Aircraft = u.Loads.Aircraft[:1]
+				u.Loads.Loads = []*Load{u.Loads.Loads[0], u.Loads.Loads[2]}
+				u.FuelRequests.Requests = u.FuelRequests.Requests[1:]
+			},
+		},
+		{
+			name: "aircraft by alias",
+			req:  &UpdateSubscription{Aircraft: []string{"Twin Otter"}},
+			want: func(u *ManifestUpdate) {
+				u.Loads.Aircraft = u.Loads.Aircraft[:1]
+				u.Loads.Loads = []*Load{u.Loads.Loads[0], u.Loads.Loads[2]}
+				u.FuelRequests.Requests = u.FuelRequests.Requests[1:]
+			},
+		},
+		{
+			name: "unregistered aircraft",
+			req:  &UpdateSubscription{Aircraft: []string{"Caravan"}},
+			want: func(u *ManifestUpdate) {
+				u.Loads.Aircraft = nil
+				u.Loads.Loads = nil
+				u.FuelRequests.Requests = nil
+			},
+		},
+		{
+			name: "sections and aircraft",
+			req: &UpdateSubscription{
+				Sections: []UpdateSection{UpdateSection_SECTION_LOADS},
+				Aircraft: []string{"King Air"},
+			},
+			want: func(u *ManifestUpdate) {
+				u.Status, u.Options, u.Jumprun, u.WindsAloft, u.FuelRequests, u.SourceHealth =
+					nil, nil, nil, nil, nil, nil
+				u.Loads.Aircraft = u.Loads.Aircraft[1:]
+				u.Loads.Loads = u.Loads.Loads[1:2]
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := testUpdate()
+			tt.want(want)
+			got := testUpdate()
+			newUpdateFilter(tt.req, s).restrict(got, s)
+			if !proto.Equal(got, want) {
+				t.Errorf("restrict() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestUpdateFilterLoads(t *testing.T) {
+	s := testSettings(t)
+	loads := []*burble.Load{
+		{ID: 1, AircraftName: "Otter"},
+		{ID: 2, AircraftName: "King Air"},
+		{ID: 3, AircraftName: "Otter"},
+		{ID: 4, AircraftName: "Otter"},
+	}
+	tests := []struct {
+		name        string
+		req         *UpdateSubscription
+		want        []int64
+		wantColumns int
+	}{
+		{"everything", &UpdateSubscription{}, []int64{1, 2, 3, 4}, 5},
+		{"max loads", &UpdateSubscription{MaxLoads: 2}, []int64{1, 2}, 2},
+		{"more max loads than columns", &UpdateSubscription{MaxLoads: 10}, []int64{1, 2, 3, 4}, 5},
+		{"aircraft", &UpdateSubscription{Aircraft: []string{"Twin Otter"}}, []int64{1, 3, 4}, 5},
+		{"aircraft and max loads", &UpdateSubscription{Aircraft: []string{"Otter"}, MaxLoads: 2}, []int64{1, 3}, 2},
+		{"several aircraft", &UpdateSubscription{Aircraft: []string{"Otter", "King Air"}}, []int64{1, 2, 3, 4}, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newUpdateFilter(tt.req, s)
+			var got []int64
+			for _, l := range f.limitLoads(loads) {
+				got = append(got, l.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("limitLoads() = %v, want %v", got, tt.want)
+			}
+			if got := f.limitColumns(5); got != tt.wantColumns {
+				t.Errorf("limitColumns(5) = %d, want %d", got, tt.wantColumns)
+			}
+		})
+	}
+
+	// Without a filter, everything is wanted.
+	var f *updateFilter
+	if got := f.limitLoads(loads); len(got) != len(loads) {
+		t.Errorf("nil filter limited loads to %d", len(got))
+	}
+	if got := f.limitColumns(5); got != 5 {
+		t.Errorf("nil filter limited columns to %d", got)
+	}
+}
+
+func TestUpdateFilterTopics(t *testing.T) {
+	s := testSettings(t)
+	tests := []struct {
+		name     string
+		sections []UpdateSection
+		want     core.DataSource
+	}{
+		{"winds aloft", []UpdateSection{UpdateSection_SECTION_WINDS_ALOFT}, core.WindsAloftDataSource},
+		{"loads", []UpdateSection{UpdateSection_SECTION_LOADS}, core.BurbleDataSource | core.OptionsDataSource},
+		{"jumprun and fuel requests",
+			[]UpdateSection{UpdateSection_SECTION_JUMPRUN, UpdateSection_SECTION_FUEL_REQUESTS},
+			core.JumprunDataSource | core.FuelDataSource},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newUpdateFilter(&UpdateSubscription{Sections: tt.sections}, s)
+			if got := f.topics(); got != tt.want {
+				t.Errorf("topics() = %#x, want %#x", got, tt.want)
+			}
+		})
+	}
+
+	var all core.DataSource
+	for _, source := range sectionSources {
+		all |= source
+	}
+	if got := newUpdateFilter(&UpdateSubscription{}, s).topics(); got != all {
+		t.Errorf("topics() = %#x for every section, want %#x", got, all)
+	}
+}
+
+func TestUpdateFilterUnits(t *testing.T) {
+	s := testSettings(t)
+	tests := []struct {
+		units Units
+		want  metar.Units
+	}{
+		{Units_UNITS_DEFAULT, metar.DefaultUnits},
+		{Units_UNITS_IMPERIAL, metar.ImperialUnits},
+		{Units_UNITS_METRIC, metar.MetricUnits},
+		{Units_UNITS_NAUTICAL, metar.NauticalUnits},
+	}
+	for _, tt := range tests {
+		t.Run(tt.units.String(), func(t *testing.T) {
+			f := newUpdateFilter(&UpdateSubscription{Units: tt.units}, s)
+			if got := f.unitsOrDefault(); got != tt.want {
+				t.Errorf("unitsOrDefault() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	var f *updateFilter
+	if got := f.unitsOrDefault(); got != metar.DefaultUnits {
+		t.Errorf("unitsOrDefault() = %v without a filter, want the defaults", got)
+	}
+}
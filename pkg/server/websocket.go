@@ -0,0 +1,121 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/protobuf/proto"
+)
+
+// WebSocket clients select the encoding of updates with a subprotocol.
+// Updates are sent as JSON text messages if no subprotocol is selected.
+const (
+	wsJSONProtocol     = "manifest.json"
+	wsProtobufProtocol = "manifest.protobuf"
+)
+
+// A ping is sent to WebSocket clients every wsPingInterval, and a client that
+// does not respond within wsPongTimeout is disconnected.
+const (
+	wsPingInterval = 30 * time.Second
+	wsPongTimeout  = 2 * wsPingInterval
+)
+
+var wsUpgrader = websocket.Upgrader{
+	HandshakeTimeout: writeTimeout,
+	Subprotocols:     []string{wsJSONProtocol, wsProtobufProtocol},
+
+	// Like StreamUpdates, the stream is read-only and available to
+	// anyone, so displays may be served from any origin.
+	CheckOrigin: func(*http.Request) bool { return true },
+}
+
+// apiWebSocketHandler streams updates over a WebSocket. Like a
+// SubscribeUpdates client, a WebSocket client may restrict its updates with
+// the "section", "aircraft", "max_loads", and "units" query parameters.
+// Messages that the client sends are ignored.
+func (s *manifestServiceServer) apiWebSocketHandler(w http.ResponseWriter, req *http.Request) {
+	sub, err := parseAPISubscription(req.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	conn, err := wsUpgrader.Upgrade(w, req, nil)
+	if err != nil {
+		// Upgrade has already responded with an error
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+
+	// Messages must be read for control messages, including pongs, to
+	// be processed. The connection has failed if reading does.
+	_ = conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	})
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	// WriteControl may be called concurrently with WriteMessage, so pings
+	// need not wait for updates.
+	go func() {
+		ticker := time.NewTicker(wsPingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				deadline := time.Now().Add(writeTimeout)
+				if err := conn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	isBinary := conn.Subprotocol() == wsProtobufProtocol
+	err = s.subscribe(ctx, sub, func(u *ManifestUpdate) error {
+		var (
+			data        []byte
+			err         error
+			messageType int
+		)
+		if isBinary {
+			data, err = proto.Marshal(u)
+			messageType = websocket.BinaryMessage
+		} else {
+			data, err = apiMarshalOptions.Marshal(u)
+			messageType = websocket.TextMessage
+		}
+		if err != nil {
+			return err
+		}
+		if err = conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+			return err
+		}
+		return conn.WriteMessage(messageType, data)
+	})
+
+	if err == nil && ctx.Err() == nil {
+		// The server is shutting down
+		deadline := time.Now().Add(writeTimeout)
+		message := websocket.FormatCloseMessage(websocket.CloseGoingAway, "")
+		_ = conn.WriteControl(websocket.CloseMessage, message, deadline)
+	}
+}
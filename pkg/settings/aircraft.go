@@ -0,0 +1,157 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package settings
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultJumprunAirspeed = 85    // knots
+	defaultExitAltitude    = 13000 // feet
+	defaultAircraftColor   = 0xffffff
+)
+
+// Aircraft describes an aircraft in the registry defined by the "aircraft"
+// section of the config.
+type Aircraft struct {
+	Name            string   // canonical name, as displayed
+	Aliases         []string // other names the manifest may use
+	TailNumber      string
+	ICAOHex         string // Mode S transponder address
+	Capacity        int    // number of jumpers
+	JumprunAirspeed int    // knots
+	ClimbRate       int    // feet per minute
+	ExitAltitude    int    // feet
+	Color           uint32
+	Order           int // display order; lower values display first
+}
+
+// aircraftConfig is the config representation of Aircraft.
+type aircraftConfig struct {
+	Name            string   `mapstructure:"name"`
+	Aliases         []string `mapstructure:"aliases"`
+	TailNumber      string   `mapstructure:"tail_number"`
+	ICAOHex         string   `mapstructure:"icao_hex"`
+	Capacity        int      `mapstructure:"capacity"`
+	JumprunAirspeed int      `mapstructure:"jumprun_airspeed"`
+	ClimbRate       int      `mapstructure:"climb_rate"`
+	ExitAltitude    int      `mapstructure:"exit_altitude"`
+	Color           string   `mapstructure:"color"`
+	Order           int      `mapstructure:"order"`
+}
+
+// ParseColor parses a color as "#rrggbb" or as an integer, which may be
+// written in hex as 0xrrggbb.
+func ParseColor(s string) (uint32, error) {
+	var (
+		v   uint64
+		err error
+	)
+	if strings.HasPrefix(s, "#") {
+		v, err = strconv.ParseUint(s[1:], 16, 32)
+	} else {
+		v, err = strconv.ParseUint(s, 0, 32)
+	}
+	return uint32(v), err
+}
+
+func newAircraft(c aircraftConfig) Aircraft {
+	a := Aircraft{
+		Name:            strings.TrimSpace(c.Name),
+		Aliases:         c.Aliases,
+		TailNumber:      c.TailNumber,
+		ICAOHex:         strings.ToUpper(c.ICAOHex),
+		Capacity:        c.Capacity,
+		JumprunAirspeed: c.JumprunAirspeed,
+		ClimbRate:       c.ClimbRate,
+		ExitAltitude:    c.ExitAltitude,
+		Color:           defaultAircraftColor,
+		Order:           c.Order,
+	}
+	if a.JumprunAirspeed <= 0 {
+		a.JumprunAirspeed = defaultJumprunAirspeed
+	}
+	if a.ExitAltitude <= 0 {
+		a.ExitAltitude = defaultExitAltitude
+	}
+	if c.Color != "" {
+		if color, err := ParseColor(c.Color); err != nil {
+			fmt.Fprintf(os.Stderr, "error: invalid color %q for aircraft %q\n",
+				c.Color, a.Name)
+		} else {
+			a.Color = color
+		}
+	}
+	return a
+}
+
+// Aircraft returns the aircraft registry in display order. The registry is
+// parsed once when the config is loaded, and must not be modified.
+func (s *Settings) Aircraft() []Aircraft {
+	return s.aircraft
+}
+
+// parseAircraft returns the aircraft registry defined by the config in
+// display order, reporting any aircraft that are invalid.
+func (s *Settings) parseAircraft() []Aircraft {
+	var configs []aircraftConfig
+	if err := s.config.UnmarshalKey("aircraft", &configs); err != nil {
+		fmt.Fprintf(os.Stderr, "error: invalid aircraft configuration: %v\n", err)
+		return nil
+	}
+
+	aircraft := make([]Aircraft, 0, len(configs))
+	for _, c := range configs {
+		if strings.TrimSpace(c.Name) == "" {
+			fmt.Fprintf(os.Stderr, "error: missing name for aircraft\n")
+			continue
+		}
+		aircraft = append(aircraft, newAircraft(c))
+	}
+	sort.SliceStable(aircraft, func(i, j int) bool {
+		return aircraft[i].Order < aircraft[j].Order
+	})
+	return aircraft
+}
+
+// LookupAircraft returns the registered aircraft known by name, which may be
+// its canonical name, one of its aliases, or its tail number.
+func (s *Settings) LookupAircraft(name string) (Aircraft, bool) {
+	name = strings.TrimSpace(name)
+	for _, a := range s.Aircraft() {
+		if strings.EqualFold(a.Name, name) ||
+			(a.TailNumber != "" && strings.EqualFold(a.TailNumber, name)) {
+			return a, true
+		}
+		for _, alias := range a.Aliases {
+			if strings.EqualFold(alias, name) {
+				return a, true
+			}
+		}
+	}
+	return Aircraft{}, false
+}
+
+// CanonicalAircraftName returns the canonical name of the aircraft known by
+// name, or name itself if the aircraft is not registered.
+func (s *Settings) CanonicalAircraftName(name string) string {
+	if a, ok := s.LookupAircraft(name); ok {
+		return a.Name
+	}
+	return name
+}
+
+// PrimaryAircraft returns the first aircraft in display order. If no
+// aircraft are registered, an unnamed aircraft with default performance
+// figures is returned.
+func (s *Settings) PrimaryAircraft() Aircraft {
+	if aircraft := s.Aircraft(); len(aircraft) > 0 {
+		return aircraft[0]
+	}
+	return newAircraft(aircraftConfig{})
+}
@@ -0,0 +1,169 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// testSettings returns settings read from config, saving state to a
+// temporary directory.
+func testSettings(t *testing.T, config string) *Settings {
+	t.Helper()
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "config.yaml")
+	config = "options_file: " + filepath.Join(dir, "options.json") + "\n" +
+		"jumper_rules_file: " + filepath.Join(dir, "jumper_rules.json") + "\n" +
+		config
+	if err := os.WriteFile(filename, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewSettingsWithFilename(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+const testAircraftConfig = `
+aircraft:
+  - name: King Air
+    tail_number: N456KA
+    color: "#ff0000"
+    order: 2
+  - name: " Otter "
+    aliases: [Twin Otter, DHC-6]
+    tail_number: N123JT
+    icao_hex: a1b2c3
+    capacity: 23
+    jumprun_airspeed: 80
+    climb_rate: 1500
+    exit_altitude: 14000
+    color: 0xffff00
+    order: 1
+  - tail_number: N789
+  - name: Caravan
+    color: blue
+    order: 2
+`
+
+func TestAircraft(t *testing.T) {
+	s := testSettings(t, testAircraftConfig)
+	want := []Aircraft{
+		{
+			Name:            "Otter",
+			Aliases:         []string{"Twin Otter", "DHC-6"},
+			TailNumber:      "N123JT",
+			ICAOHex:         "A1B2C3",
+			Capacity:        23,
+			JumprunAirspeed: 80,
+			ClimbRate:       1500,
+			ExitAltitude:    14000,
+			Color:           0xffff00,
+			Order:           1,
+		},
+		{
+			Name:            "King Air",
+			TailNumber:      "N456KA",
+			JumprunAirspeed: defaultJumprunAirspeed,
+			ExitAltitude:    defaultExitAltitude,
+			Color:           0xff0000,
+			Order:           2,
+		},
+		{
+			// An invalid color is reported and the default is used.
+			Name:            "Caravan",
+			JumprunAirspeed: defaultJumprunAirspeed,
+			ExitAltitude:    defaultExitAltitude,
+			Color:           defaultAircraftColor,
+			Order:           2,
+		},
+	}
+	aircraft := s.Aircraft()
+	if !reflect.DeepEqual(aircraft, want) {
+		t.Fatalf("Aircraft() = %+v, want %+v", aircraft, want)
+	}
+	if again := s.Aircraft(); &again[0] != &aircraft[0] {
+		t.Error("registry parsed again")
+	}
+	if got := s.PrimaryAircraft(); got.Name != "Otter" {
+		t.Errorf("PrimaryAircraft() = %q, want Otter", got.Name)
+	}
+}
+
+func TestAircraftUnregistered(t *testing.T) {
+	s := testSettings(t, "")
+	if got := s.Aircraft(); len(got) != 0 {
+		t.Errorf("Aircraft() = %+v, want none", got)
+	}
+	want := Aircraft{
+		JumprunAirspeed: defaultJumprunAirspeed,
+		ExitAltitude:    defaultExitAltitude,
+		Color:           defaultAircraftColor,
+	}
+	if got := s.PrimaryAircraft(); !reflect.DeepEqual(got, want) {
+		t.Errorf("PrimaryAircraft() = %+v, want %+v", got, want)
+	}
+	if _, ok := s.LookupAircraft("Otter"); ok {
+		t.Error("LookupAircraft() found an unregistered aircraft")
+	}
+}
+
+func TestLookupAircraft(t *testing.T) {
+	s := testSettings(t, testAircraftConfig)
+	tests := []struct {
+		name      string
+		want      string
+		canonical string
+	}{
+		{"Otter", "Otter", "Otter"},
+		{" otter ", "Otter", "Otter"},
+		{"Twin Otter", "Otter", "Otter"},
+		{"dhc-6", "Otter", "Otter"},
+		{"N123JT", "Otter", "Otter"},
+		{"n456ka", "King Air", "King Air"},
+		{"Caravan", "Caravan", "Caravan"},
+		{"Cessna", "", "Cessna"},
+		{"N789", "", "N789"},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, ok := s.LookupAircraft(tt.name)
+			if ok != (tt.want != "") || a.Name != tt.want {
+				t.Errorf("LookupAircraft(%q) = %q, %v, want %q", tt.name, a.Name, ok, tt.want)
+			}
+			if got := s.CanonicalAircraftName(tt.name); got != tt.canonical {
+				t.Errorf("CanonicalAircraftName(%q) = %q, want %q", tt.name, got, tt.canonical)
+			}
+		})
+	}
+}
+
+func TestAircraftForDropzone(t *testing.T) {
+	dir := t.TempDir()
+	s := testSettings(t, testAircraftConfig+`
+dropzones:
+  otherdz:
+    options_file: `+filepath.Join(dir, "options.json")+`
+    jumper_rules_file: `+filepath.Join(dir, "jumper_rules.json")+`
+    aircraft:
+      - name: Skyvan
+`)
+	d, err := s.ForDropzone("otherdz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := d.LookupAircraft("Skyvan"); !ok {
+		t.Error("dropzone's own aircraft not registered")
+	}
+	if _, ok := d.LookupAircraft("Otter"); ok {
+		t.Error("dropzone inherited aircraft that it overrides")
+	}
+	if _, ok := s.LookupAircraft("Skyvan"); ok {
+		t.Error("dropzone's aircraft registered for the top-level settings")
+	}
+}
@@ -0,0 +1,45 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package settings
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// AnnouncementsEnabled returns true if load call announcements should be
+// generated.
+func (s *Settings) AnnouncementsEnabled() bool {
+	return s.config.GetBool("announcements.enabled")
+}
+
+// AnnouncementThresholds returns the call times, in minutes, at which loads
+// are announced, in descending order. A threshold of 0 announces the load's
+// "NOW" call.
+func (s *Settings) AnnouncementThresholds() []int64 {
+	var thresholds []int64
+	for _, t := range s.config.GetIntSlice("announcements.thresholds") {
+		if t < 0 {
+			fmt.Fprintf(os.Stderr, "error: ignoring negative announcements.threshold %d\n", t)
+			continue
+		}
+		thresholds = append(thresholds, int64(t))
+	}
+	sort.Slice(thresholds, func(i, j int) bool {
+		return thresholds[i] > thresholds[j]
+	})
+	return thresholds
+}
+
+// AnnouncementTemplate returns the text/template used to produce the text of
+// each announcement.
+func (s *Settings) AnnouncementTemplate() string {
+	return s.config.GetString("announcements.template")
+}
+
+// AnnouncementsPath returns the web server path from which announcements may
+// be retrieved. Announcements are not served via HTTP if it is empty.
+func (s *Settings) AnnouncementsPath() string {
+	return s.config.GetString("announcements.path")
+}
@@ -12,6 +12,35 @@ func (s *Settings) BurbleDropzoneID() int {
 	return s.config.GetInt("burble.dzid")
 }
 
+func (s *Settings) BurbleBaseURL() string {
+	return strings.TrimSuffix(s.config.GetString("burble.base_url"), "/")
+}
+
+// BurbleCaptureDir returns the directory into which every raw response from
+// Burble is archived. Capturing is disabled if it is empty.
+func (s *Settings) BurbleCaptureDir() string {
+	return s.config.GetString("burble.capture_dir")
+}
+
+// BurbleReplayDir returns the directory from which previously captured
+// Burble responses are replayed instead of querying Burble. Replay is
+// disabled if it is empty.
+func (s *Settings) BurbleReplayDir() string {
+	return s.config.GetString("burble.replay_dir")
+}
+
+// BurbleReplaySpeed returns the rate at which the simulated replay clock
+// advances relative to real time.
+func (s *Settings) BurbleReplaySpeed() float64 {
+	return s.config.GetFloat64("burble.replay_speed")
+}
+
+// BurbleReplayLoop returns true if replay should start over from the first
+// capture once the last capture has been reached.
+func (s *Settings) BurbleReplayLoop() bool {
+	return s.config.GetBool("burble.replay_loop")
+}
+
 func (s *Settings) OrganizerStrings() []string {
 	o := s.config.GetStringSlice("burble.organizer_strings")
 	if len(o) == 0 {
@@ -2,17 +2,52 @@
 
 package settings
 
+import "time"
+
 var defaults = map[string]interface{}{
-	"options_file": "/var/lib/manifest-server/options.json",
-	"timezone":     "America/New_York",
+	"options_file":      "/var/lib/manifest-server/options.json",
+	"jumper_rules_file": "/var/lib/manifest-server/jumper_rules.json",
+	"timezone":          "America/New_York",
 
 	"server.http_address":  ":http",
 	"server.https_address": ":https",
 	"server.grpc_address":  ":9090",
 	"server.cert_file":     nil,
 	"server.key_file":      nil,
+	"server.h2c":           false,
+	"server.cors_origins":  []string{},
+
+	"server.slow_client_policy":  "coalesce",
+	"server.slow_client_timeout": 30 * time.Second,
+	"server.replay_buffer_size":  256,
+
+	"manifest.source":    "burble",
+	"manifest.filename":  nil,
+	"manifest.push_path": "/setmanifest",
+
+	"announcements.enabled":    false,
+	"announcements.thresholds": []int{20, 15, 10, 5, 0},
+	"announcements.template":   "{{.Call}} call for {{.AircraftName}} load {{.LoadNumber}}",
+	"announcements.path":       "/announcements",
 
-	"burble.dzid": 417,
+	"refresh.manifest.interval":           10 * time.Second,
+	"refresh.manifest.near_call_interval": 5 * time.Second,
+	"refresh.manifest.near_call_minutes":  5,
+	"refresh.manifest.idle_interval":      time.Minute,
+	"refresh.manifest.night_interval":     5 * time.Minute,
+	"refresh.manifest.stale_after":        time.Minute,
+	"refresh.metar.interval":              5 * time.Minute,
+	"refresh.metar.night_interval":        30 * time.Minute,
+	"refresh.metar.stale_after":           90 * time.Minute,
+	"refresh.winds.interval":              15 * time.Minute,
+	"refresh.winds.night_interval":        time.Hour,
+	"refresh.winds.stale_after":           3 * time.Hour,
+	"refresh.max_backoff":                 15 * time.Minute,
+	"refresh.jitter":                      0.1,
+
+	"burble.dzid":         417,
+	"burble.base_url":     "https://dzm.burblesoft.com",
+	"burble.replay_speed": 1.0,
 
 	"jumprun.enabled":              false,
 	"jumprun.latitude":             "42.5700",
@@ -36,3 +71,65 @@ var defaultOptions = Options{
 	MinCallMinutes: -10,
 	FuelRequested:  false,
 }
+
+// defaultJumperRules classify jumpers when no rules are configured. Their
+// patterns ignore case, as all rule patterns do, so that unlike the fixed
+// checks that they replace, " H/P" also matches "Sport h/p" and "3-5k" also
+// matches "3-5K".
+var defaultJumperRules = []JumperRule{
+	{
+		Match:      JumperRuleMatch{FormationType: "^pond swoop$"},
+		Categories: []string{"pond_swoop"},
+		Icon:       "🏄",
+		Color:      "#00ffff",
+	},
+	{
+		Match:      JumperRuleMatch{JumpType: "^vs$"},
+		Categories: []string{"videographer"},
+		ShortName:  "Video",
+	},
+	{
+		Match:      JumperRuleMatch{JumpType: " H/P$"},
+		Categories: []string{"low_pull"},
+	},
+	{
+		Match:      JumperRuleMatch{JumpType: `^(3-5k|3\.5k)`},
+		Categories: []string{"low_pull"},
+	},
+	{
+		// Students are only given a prefix by their H/P rule below.
+		Match:  JumperRuleMatch{JumpType: `^(3-5k|3\.5k)`, MemberType: "^Sport Jumper$"},
+		Prefix: "H&P",
+		Color:  "#ff00ff",
+	},
+	{
+		Match: JumperRuleMatch{JumpType: `^(3-5k|3\.5k)`, FormationType: "^pond swoop$"},
+		Color: "#00ffff",
+	},
+	{
+		Match: JumperRuleMatch{JumpType: ` \+ Gear$`},
+		Color: "#00ff00",
+	},
+	{
+		Match: JumperRuleMatch{MemberType: "^Student$"},
+		Color: "#00ff00",
+	},
+	{
+		Match: JumperRuleMatch{MemberType: "^Student$", StudentProgram: "^coach$"},
+		Color: "#ff8000",
+	},
+	{
+		Match: JumperRuleMatch{MemberType: "^Student$", StudentProgram: "^iad$"},
+		Color: "#c080ff",
+	},
+	{
+		Match:  JumperRuleMatch{MemberType: "^Student$", JumpType: " H/P$"},
+		Prefix: "H&P",
+	},
+	{
+		Match:         JumperRuleMatch{MemberType: "^Tandem$"},
+		Prefix:        "Tandem",
+		HideShortName: true,
+		Color:         "#ffff00",
+	},
+}
@@ -0,0 +1,128 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package settings
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Dropzones returns the IDs of the dropzones defined in the "dropzones"
+// section of the config, sorted. IDs are always lower case. It returns nil if no dropzones are defined,
+// in which case the top-level settings describe the only dropzone.
+func (s *Settings) Dropzones() []string {
+	dropzones := s.config.GetStringMap("dropzones")
+	if len(dropzones) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(dropzones))
+	for id := range dropzones {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// DefaultDropzone returns the ID of the dropzone used when a client does not
+// select one. If it is not configured, the first dropzone is the default.
+func (s *Settings) DefaultDropzone() string {
+	if id := s.config.GetString("default_dropzone"); id != "" {
+		return strings.ToLower(id)
+	}
+	if ids := s.Dropzones(); len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
+
+// DropzoneID returns the ID of the dropzone that the settings describe. It is
+// empty for the top-level settings.
+func (s *Settings) DropzoneID() string {
+	return s.dropzoneID
+}
+
+// DropzoneName returns the display name of the dropzone that the settings
+// describe.
+func (s *Settings) DropzoneName() string {
+	if name := s.config.GetString("name"); name != "" {
+		return name
+	}
+	return s.dropzoneID
+}
+
+// ForDropzone returns the settings for the dropzone with the given ID. Any
+// setting may be overridden for a dropzone by defining it within the
+// dropzone's section of the config; all others are inherited from the
+// top-level settings. Each dropzone must have its own state files, which
+// CheckDropzones verifies.
+func (s *Settings) ForDropzone(id string) (*Settings, error) {
+	key := "dropzones." + id
+	if !s.config.IsSet(key) {
+		return nil, fmt.Errorf("unknown dropzone %q", id)
+	}
+
+	d := newSettings()
+	d.dropzoneID = id
+	if err := d.config.MergeConfigMap(s.config.AllSettings()); err != nil {
+		return nil, fmt.Errorf("Could not configure dropzone %q: %w", id, err)
+	}
+	if err := d.config.MergeConfigMap(s.config.GetStringMap(key)); err != nil {
+		return nil, fmt.Errorf("Could not configure dropzone %q: %w", id, err)
+	}
+	d.parseConfig()
+	if err := d.restore(); err != nil {
+		fmt.Fprintf(os.Stderr, "Could not read options for dropzone %q: %v\n", id, err)
+	}
+	if err := d.restoreJumperRules(); err != nil {
+		fmt.Fprintf(os.Stderr, "Could not read jumper rules for dropzone %q: %v\n", id, err)
+	}
+	return d, nil
+}
+
+// stateFiles returns the files and directories, keyed by setting, to which
+// the settings' dropzone saves its state.
+func (s *Settings) stateFiles() map[string]string {
+	files := map[string]string{
+		"options_file":       s.config.GetString("options_file"),
+		"jumper_rules_file":  s.config.GetString("jumper_rules_file"),
+		"burble.capture_dir": s.BurbleCaptureDir(),
+	}
+	if s.JumprunEnabled() {
+		files["jumprun.state_file"] = s.JumprunStateFile()
+	}
+	return files
+}
+
+// CheckDropzones returns an error if any of dropzones save their state to
+// the same file, which would overwrite each other's state. Dropzones may
+// share a database, which keeps their data separate.
+func CheckDropzones(dropzones []*Settings) error {
+	type owner struct {
+		id, key string
+	}
+	owners := make(map[string]owner)
+	for _, d := range dropzones {
+		files := d.stateFiles()
+		keys := make([]string, 0, len(files))
+		for key := range files {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			filename := files[key]
+			if filename == "" {
+				continue
+			}
+			filename = filepath.Clean(filename)
+			if o, ok := owners[filename]; ok {
+				return fmt.Errorf("dropzones %q and %q share %s %q; set %s for each dropzone",
+					o.id, d.DropzoneID(), o.key, filename, key)
+			}
+			owners[filename] = owner{id: d.DropzoneID(), key: key}
+		}
+	}
+	return nil
+}
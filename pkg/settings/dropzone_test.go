@@ -0,0 +1,155 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package settings
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckDropzones(t *testing.T) {
+	// Each dropzone saves its options and jumper rules to its own files
+	// unless the test's config says otherwise.
+	const separate = `
+dropzones:
+  a:
+    options_file: /state/a/options.json
+    jumper_rules_file: /state/a/jumper_rules.json
+  b:
+    options_file: /state/b/options.json
+    jumper_rules_file: /state/b/jumper_rules.json
+`
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string // in the error, or "" for none
+	}{
+		{
+			name:   "separate state",
+			config: separate,
+		},
+		{
+			name: "shared options",
+			config: `
+dropzones:
+  a:
+    jumper_rules_file: /state/a/jumper_rules.json
+  b:
+    jumper_rules_file: /state/b/jumper_rules.json
+`,
+			wantErr: "options_file",
+		},
+		{
+			name: "same file by another path",
+			config: `
+dropzones:
+  a:
+    options_file: /state/a/options.json
+    jumper_rules_file: /state/a/jumper_rules.json
+  b:
+    options_file: /state/b/../a/options.json
+    jumper_rules_file: /state/b/jumper_rules.json
+`,
+			wantErr: "options_file",
+		},
+		{
+			name:   "shared database",
+			config: "database:\n  filename: /state/database.sqlite3\n" + separate,
+		},
+		{
+			name:    "shared capture directory",
+			config:  "burble:\n  capture_dir: /state/captures\n" + separate,
+			wantErr: "burble.capture_dir",
+		},
+		{
+			name:    "shared jumprun state",
+			config:  "jumprun:\n  enabled: true\n  state_file: /state/jumprun.json\n" + separate,
+			wantErr: "jumprun.state_file",
+		},
+		{
+			name:   "shared jumprun state while disabled",
+			config: "jumprun:\n  enabled: false\n  state_file: /state/jumprun.json\n" + separate,
+		},
+		{
+			name: "separate jumprun state",
+			config: "jumprun:\n  enabled: true\n  state_file: /state/jumprun.json\n" + separate +
+				"    jumprun:\n      state_file: /state/b/jumprun.json\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := testSettings(t, tt.config)
+			var dropzones []*Settings
+			for _, id := range s.Dropzones() {
+				d, err := s.ForDropzone(id)
+				if err != nil {
+					t.Fatal(err)
+				}
+				dropzones = append(dropzones, d)
+			}
+			if len(dropzones) != 2 {
+				t.Fatalf("%d dropzones, want 2", len(dropzones))
+			}
+
+			err := CheckDropzones(dropzones)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("CheckDropzones() = %v, want nil", err)
+			case tt.wantErr != "" && err == nil:
+				t.Errorf("CheckDropzones() = nil, want an error for %s", tt.wantErr)
+			case err != nil && !strings.Contains(err.Error(), tt.wantErr):
+				t.Errorf("CheckDropzones() = %v, want an error for %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestForDropzone(t *testing.T) {
+	s := testSettings(t, `
+default_dropzone: OtherDZ
+burble:
+  dzid: 1
+dropzones:
+  jumptown:
+    name: Jumptown
+  otherdz:
+    burble:
+      dzid: 2
+`)
+	if got := s.Dropzones(); len(got) != 2 || got[0] != "jumptown" || got[1] != "otherdz" {
+		t.Errorf("Dropzones() = %q, want jumptown and otherdz", got)
+	}
+	if got := s.DefaultDropzone(); got != "otherdz" {
+		t.Errorf("DefaultDropzone() = %q, want otherdz", got)
+	}
+
+	tests := []struct {
+		id       string
+		name     string
+		burbleID int
+	}{
+		{"jumptown", "Jumptown", 1},
+		{"otherdz", "otherdz", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			d, err := s.ForDropzone(tt.id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := d.DropzoneID(); got != tt.id {
+				t.Errorf("DropzoneID() = %q, want %q", got, tt.id)
+			}
+			if got := d.DropzoneName(); got != tt.name {
+				t.Errorf("DropzoneName() = %q, want %q", got, tt.name)
+			}
+			if got := d.BurbleDropzoneID(); got != tt.burbleID {
+				t.Errorf("BurbleDropzoneID() = %d, want %d", got, tt.burbleID)
+			}
+		})
+	}
+
+	if _, err := s.ForDropzone("skydivechicago"); err == nil {
+		t.Error("ForDropzone() succeeded for an unknown dropzone")
+	}
+}
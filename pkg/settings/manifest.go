@@ -0,0 +1,27 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package settings
+
+// ManifestSource returns the name of the source of manifest data. Currently
+// supported sources are "burble" and "json".
+func (s *Settings) ManifestSource() string {
+	return s.config.GetString("manifest.source")
+}
+
+// ManifestFilename returns the name of the file from which the "json" manifest
+// source reads loads. It may be empty if loads are only pushed via HTTP.
+func (s *Settings) ManifestFilename() string {
+	return s.config.GetString("manifest.filename")
+}
+
+// ManifestPushPath returns the web server path that accepts loads pushed via
+// HTTP to the "json" manifest source.
+func (s *Settings) ManifestPushPath() string {
+	return s.config.GetString("manifest.push_path")
+}
+
+// ManifestPushToken returns the bearer token required to push loads via HTTP.
+// If it is empty, loads cannot be pushed.
+func (s *Settings) ManifestPushToken() string {
+	return s.config.GetString("manifest.push_token")
+}
@@ -0,0 +1,97 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package settings
+
+import "time"
+
+// ManifestRefreshInterval returns how often the manifest source is refreshed
+// while there are loads on the manifest.
+func (s *Settings) ManifestRefreshInterval() time.Duration {
+	return s.positiveDuration("refresh.manifest.interval")
+}
+
+// ManifestRefreshNearCallInterval returns how often the manifest source is
+// refreshed while a load is within ManifestRefreshNearCallMinutes of its
+// call.
+func (s *Settings) ManifestRefreshNearCallInterval() time.Duration {
+	return s.positiveDuration("refresh.manifest.near_call_interval")
+}
+
+func (s *Settings) ManifestRefreshNearCallMinutes() int64 {
+	return s.config.GetInt64("refresh.manifest.near_call_minutes")
+}
+
+// ManifestRefreshIdleInterval returns how often the manifest source is
+// refreshed during the day while there are no loads on the manifest.
+func (s *Settings) ManifestRefreshIdleInterval() time.Duration {
+	return s.positiveDuration("refresh.manifest.idle_interval")
+}
+
+// ManifestRefreshNightInterval returns how often the manifest source is
+// refreshed between sunset and sunrise while there are no loads on the
+// manifest.
+func (s *Settings) ManifestRefreshNightInterval() time.Duration {
+	return s.positiveDuration("refresh.manifest.night_interval")
+}
+
+// ManifestStaleAfter returns how long after the last successful refresh the
+// manifest is considered to be stale.
+func (s *Settings) ManifestStaleAfter() time.Duration {
+	return s.positiveDuration("refresh.manifest.stale_after")
+}
+
+func (s *Settings) METARRefreshInterval() time.Duration {
+	return s.positiveDuration("refresh.metar.interval")
+}
+
+func (s *Settings) METARRefreshNightInterval() time.Duration {
+	return s.positiveDuration("refresh.metar.night_interval")
+}
+
+func (s *Settings) METARStaleAfter() time.Duration {
+	return s.positiveDuration("refresh.metar.stale_after")
+}
+
+func (s *Settings) WindsRefreshInterval() time.Duration {
+	return s.positiveDuration("refresh.winds.interval")
+}
+
+func (s *Settings) WindsRefreshNightInterval() time.Duration {
+	return s.positiveDuration("refresh.winds.night_interval")
+}
+
+func (s *Settings) WindsStaleAfter() time.Duration {
+	return s.positiveDuration("refresh.winds.stale_after")
+}
+
+// RefreshMaxBackoff returns the longest that a failing source waits before
+// it is retried, unless its own refresh interval is longer. Retry-After and
+// Cache-Control delays requested by upstreams are limited to the same.
+func (s *Settings) RefreshMaxBackoff() time.Duration {
+	return s.positiveDuration("refresh.max_backoff")
+}
+
+// RefreshJitter returns the fraction by which refresh delays are randomly
+// varied, so that refreshes do not happen in lockstep.
+func (s *Settings) RefreshJitter() float64 {
+	jitter := s.config.GetFloat64("refresh.jitter")
+	switch {
+	case jitter < 0:
+		return 0
+	case jitter > 1:
+		return 1
+	}
+	return jitter
+}
+
+// positiveDuration returns the duration for key, falling back to the default
+// if it is not positive.
+func (s *Settings) positiveDuration(key string) time.Duration {
+	if d := s.config.GetDuration(key); d > 0 {
+		return d
+	}
+	if d, ok := defaults[key].(time.Duration); ok {
+		return d
+	}
+	return time.Minute
+}
@@ -0,0 +1,302 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package settings
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/jumptown-skydiving/manifest-server/pkg/webauth"
+)
+
+// JumperRuleMatch selects the jumpers to which a JumperRule applies. Each
+// field is a case-insensitive regular expression, so "h/p" matches a jump
+// type of "Sport H/P" just as "H/P" does; patterns that must match case
+// exactly may begin with "(?-i)". Empty fields match anything, and a jumper
+// must match all of the non-empty fields.
+type JumperRuleMatch struct {
+	JumpType      string `json:"jump_type,omitempty" mapstructure:"jump_type"`
+	GroupName     string `json:"group_name,omitempty" mapstructure:"group_name"`
+	FormationType string `json:"formation_type,omitempty" mapstructure:"formation_type"`
+	MemberType    string `json:"member_type,omitempty" mapstructure:"member_type"` // e.g. "Tandem", "Student", "Sport Jumper"
+
+	// StudentProgram matches the program of a student, which is one of
+	// "aff", "coach", "iad", or "hop_and_pop", or empty if it is unknown.
+	StudentProgram string `json:"student_program,omitempty" mapstructure:"student_program"`
+}
+
+// JumperRule classifies the jumpers that it matches. Rules are applied in
+// order, and every rule that matches a jumper is applied, so later rules
+// take precedence over earlier ones.
+type JumperRule struct {
+	Match JumperRuleMatch `json:"match" mapstructure:"match"`
+
+	// Categories are added to the jumper. Recognized categories are
+	// "videographer", "low_pull", "pond_swoop", "organizer", and
+	// "student".
+	Categories []string `json:"categories,omitempty" mapstructure:"categories"`
+
+	ShortName     string `json:"short_name,omitempty" mapstructure:"short_name"` // replaces the jump type
+	HideShortName bool   `json:"hide_short_name,omitempty" mapstructure:"hide_short_name"`
+	Prefix        string `json:"prefix,omitempty" mapstructure:"prefix"`
+	Icon          string `json:"icon,omitempty" mapstructure:"icon"`
+	Color         string `json:"color,omitempty" mapstructure:"color"` // see ParseColor
+
+	// StudentProgram overrides the program that is derived from a
+	// student's jump type. See JumperRuleMatch.StudentProgram.
+	StudentProgram string `json:"student_program,omitempty" mapstructure:"student_program"`
+}
+
+var jumperCategories = map[string]struct{}{
+	"videographer": {},
+	"low_pull":     {},
+	"pond_swoop":   {},
+	"organizer":    {},
+	"student":      {},
+}
+
+var studentPrograms = map[string]struct{}{
+	"aff":         {},
+	"coach":       {},
+	"iad":         {},
+	"hop_and_pop": {},
+}
+
+// CompileRulePattern compiles a JumperRuleMatch pattern. It returns nil for
+// an empty pattern, which matches anything.
+func CompileRulePattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	return regexp.Compile("(?i)" + pattern)
+}
+
+// Validate returns an error if the rule cannot be applied.
+func (r JumperRule) Validate() error {
+	for _, pattern := range []string{r.Match.JumpType, r.Match.GroupName,
+		r.Match.FormationType, r.Match.MemberType, r.Match.StudentProgram} {
+		if _, err := CompileRulePattern(pattern); err != nil {
+			return err
+		}
+	}
+	for _, c := range r.Categories {
+		if _, ok := jumperCategories[c]; !ok {
+			return fmt.Errorf("unrecognized category %q", c)
+		}
+	}
+	if r.StudentProgram != "" {
+		if _, ok := studentPrograms[r.StudentProgram]; !ok {
+			return fmt.Errorf("unrecognized student program %q", r.StudentProgram)
+		}
+	}
+	if r.Color != "" {
+		if _, err := ParseColor(r.Color); err != nil {
+			return fmt.Errorf("invalid color %q", r.Color)
+		}
+	}
+	return nil
+}
+
+func validateJumperRules(rules []JumperRule) error {
+	for i, r := range rules {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("rule %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// JumperRules returns the rules used to classify jumpers. Rules edited at
+// runtime take precedence over those in the config, which in turn take
+// precedence over the built-in defaults.
+func (s *Settings) JumperRules() []JumperRule {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.jumperRules != nil {
+		return s.jumperRules
+	}
+	if s.configJumperRules != nil {
+		return s.configJumperRules
+	}
+	return defaultJumperRules
+}
+
+// parseConfigJumperRules returns the rules in the config, or nil if there
+// are none or they are invalid.
+func (s *Settings) parseConfigJumperRules() []JumperRule {
+	if !s.config.IsSet("jumper_rules") {
+		return nil
+	}
+	var rules []JumperRule
+	if err := s.config.UnmarshalKey("jumper_rules", &rules); err != nil {
+		fmt.Fprintf(os.Stderr, "error: invalid jumper_rules: %v\n", err)
+		return nil
+	}
+	if err := validateJumperRules(rules); err != nil {
+		fmt.Fprintf(os.Stderr, "error: invalid jumper_rules: %v\n", err)
+		return nil
+	}
+	if rules == nil {
+		rules = []JumperRule{}
+	}
+	return rules
+}
+
+// SetJumperRules replaces the rules used to classify jumpers and saves them
+// so that they persist across restarts.
+func (s *Settings) SetJumperRules(rules []JumperRule) error {
+	if rules == nil {
+		rules = []JumperRule{}
+	}
+	if err := validateJumperRules(rules); err != nil {
+		return err
+	}
+
+	dataBytes, err := json.MarshalIndent(rules, "", "\t")
+	if err != nil {
+		return err
+	}
+	filename := s.config.GetString("jumper_rules_file")
+	if filename == "" {
+		return errors.New("jumper_rules_file is not configured")
+	}
+	tempFilename := filename + ".tmp"
+	if err = ioutil.WriteFile(tempFilename, dataBytes, 0600); err != nil {
+		return err
+	}
+	if err = os.Rename(tempFilename, filename); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	s.jumperRules = rules
+	s.lock.Unlock()
+
+	if s.update != nil {
+		s.update("jumper_rules")
+	}
+	return nil
+}
+
+// restoreJumperRules reads rules previously saved by SetJumperRules.
+func (s *Settings) restoreJumperRules() error {
+	filename := s.config.GetString("jumper_rules_file")
+	if filename == "" {
+		return nil
+	}
+	dataBytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	var rules []JumperRule
+	if err = json.Unmarshal(dataBytes, &rules); err != nil {
+		return fmt.Errorf("%s: %w", filename, err)
+	}
+	if err = validateJumperRules(rules); err != nil {
+		return fmt.Errorf("%s: %w", filename, err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.jumperRules = rules
+	return nil
+}
+
+type jumperRulesPage struct {
+	Rules     string
+	Error     string
+	CSRFToken string
+}
+
+var jumperRulesTemplate = template.Must(template.New("jumper_rules").Parse(jumperRulesHTML))
+
+func (s *Settings) writeJumperRulesPage(w http.ResponseWriter, req *http.Request, page jumperRulesPage) {
+	page.CSRFToken = webauth.CSRFToken(req)
+	b := &bytes.Buffer{}
+	if err := jumperRulesTemplate.Execute(b, &page); err != nil {
+		http.NotFound(w, req)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	http.ServeContent(w, req, "", time.Now(), bytes.NewReader(b.Bytes()))
+}
+
+// JumperRulesHTML serves a page for editing the jumper rules as JSON.
+func (s *Settings) JumperRulesHTML(w http.ResponseWriter, req *http.Request) {
+	dataBytes, err := json.MarshalIndent(s.JumperRules(), "", "\t")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.writeJumperRulesPage(w, req, jumperRulesPage{Rules: string(dataBytes)})
+}
+
+// JumperRulesFormHandler saves jumper rules submitted from JumperRulesHTML.
+func (s *Settings) JumperRulesFormHandler(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := req.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	text := req.Form.Get("rules")
+	var rules []JumperRule
+	err := json.Unmarshal([]byte(text), &rules)
+	if err == nil {
+		err = s.SetJumperRules(rules)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		s.writeJumperRulesPage(w, req, jumperRulesPage{
+			Rules: text,
+			Error: err.Error(),
+		})
+		return
+	}
+	http.Redirect(w, req, "jumper_rules.html", http.StatusSeeOther)
+}
+
+const jumperRulesHTML = `<html>
+<head>
+	<title>Jumper Rules</title>
+</head>
+<body>
+	<form action="setjumperrules" method="post">
+		<input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
+		<div>
+			<h3>Jumper Rules</h3>
+			<hr>
+			<br>
+		</div>
+		{{if .Error}}<div><b>Error: {{.Error}}</b></div>{{end}}
+		<div>
+			<textarea name="rules" rows="40" cols="100">{{.Rules}}</textarea>
+		</div>
+		<div>
+			<input type="submit" value="Save">
+		</div>
+	</form>
+	<form action="logout" method="post">
+		<input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
+		<hr>
+		<button type="submit">Sign Out</button>
+	</form>
+</body>
+</html>
+`
@@ -2,6 +2,13 @@
 
 package settings
 
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
 func (s *Settings) WebServerAddress() string {
 	return s.config.GetString("server.http_address")
 }
@@ -21,3 +28,46 @@ func (s *Settings) ServerCertFile() string {
 func (s *Settings) ServerKeyFile() string {
 	return s.config.GetString("server.key_file")
 }
+
+// SlowClientPolicy returns how StreamUpdates clients that fall behind are
+// handled: "coalesce" merges pending updates for them indefinitely, "drop"
+// discards their pending updates and resynchronizes them with a complete
+// update once they catch up, and "disconnect" closes their streams.
+func (s *Settings) SlowClientPolicy() string {
+	switch policy := strings.ToLower(s.config.GetString("server.slow_client_policy")); policy {
+	case "coalesce", "drop", "disconnect":
+		return policy
+	default:
+		fmt.Fprintf(os.Stderr, "error: unrecognized server.slow_client_policy %q\n", policy)
+		return "coalesce"
+	}
+}
+
+// SlowClientTimeout returns how long a StreamUpdates client may take to
+// accept an update before it is considered to be slow.
+func (s *Settings) SlowClientTimeout() time.Duration {
+	return s.positiveDuration("server.slow_client_timeout")
+}
+
+// ReplayBufferSize returns the number of recent changes that are remembered
+// so that SubscribeUpdates clients may resume their streams after
+// reconnecting. 0 disables resumption.
+func (s *Settings) ReplayBufferSize() int {
+	if n := s.config.GetInt("server.replay_buffer_size"); n > 0 {
+		return n
+	}
+	return 0
+}
+
+// ServerH2C returns true if the HTTP listener serves cleartext HTTP/2 when
+// there is no certificate, as needed to serve native gRPC on it from behind
+// a TLS-terminating proxy.
+func (s *Settings) ServerH2C() bool {
+	return s.config.GetBool("server.h2c")
+}
+
+// ServerCORSOrigins returns the origins whose pages may make cross-origin
+// requests, including gRPC-Web requests.
+func (s *Settings) ServerCORSOrigins() []string {
+	return s.config.GetStringSlice("server.cors_origins")
+}
@@ -20,6 +20,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/jumptown-skydiving/manifest-server/pkg/webauth"
+
 	"github.com/spf13/viper"
 )
 
@@ -33,11 +35,23 @@ type UpdateFunc func(string)
 // Settings are configurable options that may be changed via the web interface
 // while the server is running.
 type Settings struct {
-	update   UpdateFunc
-	lock     sync.Mutex
-	config   *viper.Viper
-	options  Options
-	template *template.Template
+	update     UpdateFunc
+	lock       sync.Mutex
+	config     *viper.Viper
+	options    Options
+	template   *template.Template
+	dropzoneID string
+
+	// jumperRules are rules edited at runtime, or nil if there are none.
+	jumperRules []JumperRule
+
+	// configJumperRules are the valid rules in the config, or nil if
+	// there are none. They are parsed once when the config is loaded.
+	configJumperRules []JumperRule
+
+	// aircraft is the aircraft registry, parsed once when the config is
+	// loaded.
+	aircraft []Aircraft
 }
 
 func newSettings() *Settings {
@@ -66,12 +80,24 @@ func (s *Settings) loadConfig() error {
 	if err := s.config.ReadInConfig(); err != nil {
 		return fmt.Errorf("Could not read config: %w\n", err)
 	}
+	s.parseConfig()
 	if err := s.restore(); err != nil {
 		fmt.Fprintf(os.Stderr, "Could not read options: %v\n", err)
 	}
+	if err := s.restoreJumperRules(); err != nil {
+		fmt.Fprintf(os.Stderr, "Could not read jumper rules: %v\n", err)
+	}
 	return nil;
 }
 
+// parseConfig parses the settings in the config that are too costly to
+// parse each time that they are used. Invalid settings are reported here,
+// once, rather than on every use.
+func (s *Settings) parseConfig() {
+	s.configJumperRules = s.parseConfigJumperRules()
+	s.aircraft = s.parseAircraft()
+}
+
 func NewSettings() (*Settings, error) {
 	s := newSettings()
 	s.config.AddConfigPath("/etc/manifest-server")
@@ -261,8 +287,15 @@ func (s *Settings) HTML(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	page := struct {
+		Options
+		CSRFToken string
+	}{
+		Options:   o,
+		CSRFToken: webauth.CSRFToken(req),
+	}
 	b := &bytes.Buffer{}
-	if err := tmpl.Execute(b, &o); err != nil {
+	if err := tmpl.Execute(b, &page); err != nil {
 		http.NotFound(w, req)
 		return
 	}
@@ -272,6 +305,11 @@ func (s *Settings) HTML(w http.ResponseWriter, req *http.Request) {
 }
 
 func (s *Settings) FormHandler(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
 	if err := req.ParseForm(); err != nil {
 		fmt.Fprintf(os.Stderr, "cannot parse form: %v\n", err)
 		http.NotFound(w, req)
@@ -287,15 +325,20 @@ const settingsHTML = `<html>
 	<title>Manifest Settings</title>
 	<script>
 	function change(id) {
-		var v = document.getElementById(id).checked
+		var input = document.getElementById(id);
+		var body = new URLSearchParams();
+		body.append(id, input.type == "checkbox" ? input.checked : input.value);
+		body.append("csrf_token", document.getElementById("csrf_token").value);
 		var xmlhttp = new XMLHttpRequest();
-		xmlhttp.open("GET", "/setconfig?" + id + "=" + v, true);
-		xmlhttp.send();
+		xmlhttp.open("POST", "setconfig", true);
+		xmlhttp.setRequestHeader("Content-Type", "application/x-www-form-urlencoded");
+		xmlhttp.send(body.toString());
 	}
 	</script>
 </head>
 <body>
 	<form>
+		<input type="hidden" id="csrf_token" name="csrf_token" value="{{.CSRFToken}}">
 		<div>
 			<h3>Settings</h3>
 			<hr>
@@ -311,7 +354,7 @@ const settingsHTML = `<html>
 		</div>
 		<div>
 			<label># Manifest loads to display:<label>
-			<input type="text" id="DisplayColumns" onchange="change('DisplayColumns');" value="{{.DispalyColumns}}">
+			<input type="text" id="DisplayColumns" onchange="change('DisplayColumns');" value="{{.DisplayColumns}}">
 		</div>
 		<div>
 			<label>Minimum call time to display:<label>
@@ -322,6 +365,11 @@ const settingsHTML = `<html>
 			<input type="text" id="Message" size="80" onchange="change('Message');" value="{{.Message}}">
 		</div>
 	</form>
+	<form action="logout" method="post">
+		<input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
+		<hr>
+		<button type="submit">Sign Out</button>
+	</form>
 </body>
 </html>
 `
@@ -32,3 +32,34 @@ func (s *Settings) NewSignInWithAppleManager() (*siwa.Manager, error) {
 
 	return siwa.NewManagerFromKeyFile(bundleID, teamID, keyID, keyFile)
 }
+
+// SignInWithAppleServicesID returns the Services ID with which users sign in
+// to the web pages, or "" if they cannot.
+func (s *Settings) SignInWithAppleServicesID() string {
+	return s.config.GetString("siwa.services_id")
+}
+
+// NewSignInWithAppleWebManager creates a manager for signing in to the web
+// pages, which is like signing in to the app, but with the Services ID in
+// place of the bundle ID. It returns nil if no Services ID is configured.
+func (s *Settings) NewSignInWithAppleWebManager() (*siwa.Manager, error) {
+	servicesID := s.SignInWithAppleServicesID()
+	if servicesID == "" {
+		return nil, nil
+	}
+
+	teamID := s.config.GetString("siwa.team_id")
+	if teamID == "" {
+		return nil, errors.New("Missing team_id for siwa configuration")
+	}
+	keyID := s.config.GetString("siwa.key_id")
+	if keyID == "" {
+		return nil, errors.New("Missing key_id for siwa configuration")
+	}
+	keyFile := s.config.GetString("siwa.key_file")
+	if keyFile == "" {
+		return nil, errors.New("missing key_file for siwa configuration")
+	}
+
+	return siwa.NewManagerFromKeyFile(servicesID, teamID, keyID, keyFile)
+}
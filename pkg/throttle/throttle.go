@@ -0,0 +1,121 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+// Package throttle interprets the hints that upstream HTTP servers give about
+// how often they may be queried.
+package throttle
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StatusError is returned for an HTTP response with an unsuccessful status.
+type StatusError struct {
+	URL        string
+	StatusCode int
+	Status     string
+
+	// RetryAfter is the delay requested by the Retry-After header, or 0
+	// if there was none.
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%s: %s (retry after %s)", e.URL, e.Status, e.RetryAfter)
+	}
+	return fmt.Sprintf("%s: %s", e.URL, e.Status)
+}
+
+// CheckResponse returns a *StatusError if resp has an unsuccessful status.
+func CheckResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	e := &StatusError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		RetryAfter: RetryAfter(resp.Header, time.Now()),
+	}
+	if resp.Request != nil && resp.Request.URL != nil {
+		u := *resp.Request.URL
+		u.RawQuery = ""
+		e.URL = u.String()
+	}
+	return e
+}
+
+// RetryAfter returns the delay requested by a Retry-After header, which may be
+// either a number of seconds or an HTTP date, or 0 if there is none.
+func RetryAfter(h http.Header, now time.Time) time.Duration {
+	value := strings.TrimSpace(h.Get("Retry-After"))
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+// MaxAge returns how long a response may be cached according to its
+// Cache-Control header, or 0 if it may not be cached or does not say.
+func MaxAge(h http.Header) time.Duration {
+	var maxAge time.Duration
+	for _, directive := range strings.Split(h.Get("Cache-Control"), ",") {
+		directive = strings.ToLower(strings.TrimSpace(directive))
+		switch {
+		case directive == "no-cache", directive == "no-store":
+			return 0
+		case strings.HasPrefix(directive, "max-age="):
+			seconds, err := strconv.ParseInt(strings.Trim(directive[8:], `"`), 10, 64)
+			if err == nil && seconds > 0 {
+				maxAge = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	return maxAge
+}
+
+// Hint tracks the earliest time at which an upstream should next be queried.
+// The zero value is ready to use.
+type Hint struct {
+	lock      sync.Mutex
+	notBefore time.Time
+}
+
+// Update records the hints given by a response: Retry-After for an
+// unsuccessful response, or Cache-Control for a successful one.
+func (h *Hint) Update(resp *http.Response) {
+	now := time.Now()
+	var delay time.Duration
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		delay = MaxAge(resp.Header)
+	} else {
+		delay = RetryAfter(resp.Header, now)
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if notBefore := now.Add(delay); notBefore.After(h.notBefore) {
+		h.notBefore = notBefore
+	}
+}
+
+// NotBefore returns the earliest time at which the upstream should next be
+// queried.
+func (h *Hint) NotBefore() time.Time {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.notBefore
+}
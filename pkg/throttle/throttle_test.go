@@ -0,0 +1,151 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+package throttle
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{"none", "", 0},
+		{"seconds", "120", 2 * time.Minute},
+		{"padded seconds", " 5 ", 5 * time.Second},
+		{"zero seconds", "0", 0},
+		{"negative seconds", "-5", 0},
+		{"date", "Thu, 01 Jun 2023 12:01:30 GMT", 90 * time.Second},
+		{"past date", "Thu, 01 Jun 2023 11:00:00 GMT", 0},
+		{"malformed", "soon", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			if tt.value != "" {
+				h.Set("Retry-After", tt.value)
+			}
+			if got := RetryAfter(h, now); got != tt.want {
+				t.Errorf("RetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxAge(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"max-age=300", 5 * time.Minute},
+		{"public, MAX-AGE=60", time.Minute},
+		{`max-age="60"`, time.Minute},
+		{"max-age=60, no-cache", 0},
+		{"no-store, max-age=60", 0},
+		{"max-age=0", 0},
+		{"max-age=-1", 0},
+		{"max-age=soon", 0},
+		{"private", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			h := http.Header{}
+			h.Set("Cache-Control", tt.value)
+			if got := MaxAge(h); got != tt.want {
+				t.Errorf("MaxAge(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckResponse(t *testing.T) {
+	req := &http.Request{URL: &url.URL{Scheme: "https", Host: "example.com", Path: "/data", RawQuery: "key=secret"}}
+	tests := []struct {
+		name    string
+		resp    *http.Response
+		wantErr string
+	}{
+		{
+			name: "success",
+			resp: &http.Response{StatusCode: 204, Status: "204 No Content", Request: req},
+		},
+		{
+			name: "failure",
+			resp: &http.Response{StatusCode: 500, Status: "500 Internal Server Error",
+				Header: http.Header{}, Request: req},
+			wantErr: "https://example.com/data: 500 Internal Server Error",
+		},
+		{
+			name: "failure with Retry-After",
+			resp: &http.Response{StatusCode: 429, Status: "429 Too Many Requests",
+				Header: http.Header{"Retry-After": {"30"}}, Request: req},
+			wantErr: "https://example.com/data: 429 Too Many Requests (retry after 30s)",
+		},
+		{
+			name: "failure without a request",
+			resp: &http.Response{StatusCode: 503, Status: "503 Service Unavailable",
+				Header: http.Header{}},
+			wantErr: ": 503 Service Unavailable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckResponse(tt.resp)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckResponse() = %v, want nil", err)
+				}
+				return
+			}
+			var statusErr *StatusError
+			if !errors.As(err, &statusErr) {
+				t.Fatalf("CheckResponse() = %v, want a *StatusError", err)
+			}
+			if statusErr.StatusCode != tt.resp.StatusCode {
+				t.Errorf("StatusCode = %d, want %d", statusErr.StatusCode, tt.resp.StatusCode)
+			}
+			if got := err.Error(); got != tt.wantErr {
+				t.Errorf("Error() = %q, want %q", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHint(t *testing.T) {
+	var h Hint
+	if !h.NotBefore().IsZero() {
+		t.Fatalf("NotBefore() = %v for the zero value, want the zero time", h.NotBefore())
+	}
+
+	start := time.Now()
+	h.Update(&http.Response{StatusCode: 200, Header: http.Header{"Cache-Control": {"max-age=600"}}})
+	first := h.NotBefore()
+	if d := first.Sub(start); d < 10*time.Minute || d > 11*time.Minute {
+		t.Errorf("NotBefore() is %v after a 10 minute max-age, want 10m", d)
+	}
+
+	// A shorter hint does not move the time earlier.
+	h.Update(&http.Response{StatusCode: 503, Header: http.Header{"Retry-After": {"60"}}})
+	if got := h.NotBefore(); !got.Equal(first) {
+		t.Errorf("NotBefore() = %v after a shorter hint, want %v", got, first)
+	}
+
+	// Retry-After is ignored for a successful response.
+	h.Update(&http.Response{StatusCode: 200, Header: http.Header{"Retry-After": {"3600"}}})
+	if got := h.NotBefore(); !got.Equal(first) {
+		t.Errorf("NotBefore() = %v after Retry-After on a success, want %v", got, first)
+	}
+
+	h.Update(&http.Response{StatusCode: 429, Header: http.Header{"Retry-After": {"3600"}}})
+	if d := h.NotBefore().Sub(start); d < time.Hour || d > time.Hour+time.Minute {
+		t.Errorf("NotBefore() is %v after a one hour Retry-After, want 1h", d)
+	}
+}
@@ -0,0 +1,29 @@
+// (c) Copyright 2017-2023 Matt Messier
+
+// Package webauth carries the state of a web session to the pages that
+// require one, which cannot depend on the package that manages sessions.
+package webauth
+
+import (
+	"context"
+	"net/http"
+)
+
+// CSRFField is the name of the form field in which forms submit the
+// session's CSRF token.
+const CSRFField = "csrf_token"
+
+type csrfTokenKey struct{}
+
+// WithCSRFToken returns req with the session's CSRF token attached.
+func WithCSRFToken(req *http.Request, token string) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), csrfTokenKey{}, token))
+}
+
+// CSRFToken returns the CSRF token of the session for which req is being
+// served, which forms must submit in CSRFField. It returns "" if req is not
+// being served for a session.
+func CSRFToken(req *http.Request) string {
+	token, _ := req.Context().Value(csrfTokenKey{}).(string)
+	return token
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/jumptown-skydiving/manifest-server/pkg/decode"
 	"github.com/jumptown-skydiving/manifest-server/pkg/settings"
+	"github.com/jumptown-skydiving/manifest-server/pkg/throttle"
 )
 
 type Controller struct {
@@ -31,6 +32,9 @@ type Controller struct {
 	// url is the full url used to request winds aloft data.
 	url string
 
+	// hint tracks the refresh delays requested by the server.
+	hint throttle.Hint
+
 	lock sync.Mutex
 }
 
@@ -51,6 +55,12 @@ func NewController(settings *settings.Settings) *Controller {
 	return wa
 }
 
+// NotBefore returns the earliest time at which the server has asked to be
+// queried again.
+func (c *Controller) NotBefore() time.Time {
+	return c.hint.NotBefore()
+}
+
 func (c *Controller) Refresh() (bool, error) {
 	request, err := c.settings.NewHTTPRequest(http.MethodGet, c.url, nil)
 	if err != nil {
@@ -64,6 +74,11 @@ func (c *Controller) Refresh() (bool, error) {
 	}
 	defer resp.Body.Close()
 
+	c.hint.Update(resp)
+	if err = throttle.CheckResponse(resp); err != nil {
+		return false, err
+	}
+
 	data, err := ioutil.ReadAll(resp.Body)
 	if err != nil || len(data) == 0 {
 		return false, err